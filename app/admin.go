@@ -0,0 +1,346 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/muchlist/mini_pos/configs"
+	"github.com/muchlist/mini_pos/configs/permissions"
+	"github.com/muchlist/mini_pos/configs/roles"
+	"github.com/muchlist/mini_pos/dao/impersonation_dao"
+	"github.com/muchlist/mini_pos/dao/merchant_dao"
+	"github.com/muchlist/mini_pos/dao/outlet_dao"
+	"github.com/muchlist/mini_pos/dao/password_reset_dao"
+	"github.com/muchlist/mini_pos/dao/plan_dao"
+	"github.com/muchlist/mini_pos/dao/product_dao"
+	"github.com/muchlist/mini_pos/dao/refresh_token_dao"
+	"github.com/muchlist/mini_pos/dao/role_dao"
+	"github.com/muchlist/mini_pos/dao/session_dao"
+	"github.com/muchlist/mini_pos/dao/tax_dao"
+	"github.com/muchlist/mini_pos/dao/totp_dao"
+	"github.com/muchlist/mini_pos/dao/user_dao"
+	"github.com/muchlist/mini_pos/dao/user_outlet_dao"
+	"github.com/muchlist/mini_pos/db"
+	"github.com/muchlist/mini_pos/db/seeder"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/service/merchant_serv"
+	"github.com/muchlist/mini_pos/service/outlet_serv"
+	"github.com/muchlist/mini_pos/service/password_serv"
+	"github.com/muchlist/mini_pos/service/product_serv"
+	"github.com/muchlist/mini_pos/service/tax_serv"
+	"github.com/muchlist/mini_pos/service/user_serv"
+	"github.com/muchlist/mini_pos/utils/bruteforce"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/mcrypt"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/muchlist/mini_pos/utils/notifier"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// exportPageSize jumlah baris per halaman ketika membaca data untuk export
+const exportPageSize = 500
+
+// adminServices dao dan service yang dipakai perintah administrasi, dirangkai sama seperti prepareEndPoint
+type adminServices struct {
+	merchantDao merchant_dao.MerchantDaoAssumer
+	planDao     plan_dao.PlanDaoAssumer
+	outletDao   outlet_dao.OutletDaoAssumer
+	userDao     user_dao.UserDaoAssumer
+	roleDao     role_dao.RoleDaoAssumer
+	taxDao      tax_dao.TaxDaoAssumer
+	productDao  product_dao.ProductDaoAssumer
+
+	merchant merchant_serv.MerchantServiceAssumer
+	user     user_serv.UserServiceAssumer
+	password password_serv.PasswordServiceAssumer
+	outlet   outlet_serv.OutletServiceAssumer
+	product  product_serv.ProductServiceAssumer
+	tax      tax_serv.TaxServiceAssumer
+}
+
+// newAdminServices menginisiasi config, logger dan database lalu merangkai service,
+// panggil db.Close setelah selesai
+func newAdminServices(cfg *configs.Config) *adminServices {
+	logger.InitLogger(cfg.Log)
+	db.Init(cfg.Database)
+
+	cryptoUtils := mcrypt.NewCrypto()
+	merchantDao := merchant_dao.New(db.DB)
+	planDao := plan_dao.New(db.DB)
+	outletDao := outlet_dao.New(db.DB)
+	userOutletDao := user_outlet_dao.New(db.DB)
+	userDao := user_dao.New(db.DB)
+	roleDao := role_dao.New(db.DB)
+	taxDao := tax_dao.New(db.DB)
+	productDao := product_dao.New(db.DB)
+	outletService := outlet_serv.NewOutletService(outletDao, planDao, userOutletDao, merchantDao)
+
+	return &adminServices{
+		merchantDao: merchantDao,
+		planDao:     planDao,
+		outletDao:   outletDao,
+		userDao:     userDao,
+		roleDao:     roleDao,
+		taxDao:      taxDao,
+		productDao:  productDao,
+
+		merchant: merchant_serv.NewMerchantService(merchantDao, cryptoUtils),
+		user: user_serv.NewUserService(userDao, impersonation_dao.New(db.DB), planDao, refresh_token_dao.New(db.DB), session_dao.New(db.DB),
+			totp_dao.New(db.DB), roleDao, userOutletDao, merchantDao, outletService, cryptoUtils, mjwt.NewJwt(), bruteforce.NewGuard(), tokenLifetime(cfg.Auth)),
+		password: password_serv.NewPasswordService(userDao, password_reset_dao.New(db.DB), cryptoUtils, notifier.NewMailNotifier(newMailer(cfg.Mail)), cfg.AppURL),
+		outlet:   outletService,
+		product:  product_serv.NewProductService(productDao, taxDao, merchantDao, planDao, outletService),
+		tax:      tax_serv.NewTaxService(taxDao, merchantDao),
+	}
+}
+
+// ownerClaims identitas owner merchant untuk memanggil service yang memerlukan claims
+func (a *adminServices) ownerClaims(ctx context.Context, merchantID int) (mjwt.CustomClaim, rest_err.APIError) {
+	owner, err := a.userDao.GetOwnerByMerchant(ctx, merchantID)
+	if err != nil {
+		return mjwt.CustomClaim{}, err
+	}
+	return mjwt.CustomClaim{
+		Identity:    owner.ID,
+		Name:        string(owner.Name),
+		Type:        mjwt.Access,
+		Role:        roles.RoleOwner,
+		Merchant:    merchantID,
+		Permissions: []string{permissions.All},
+	}, nil
+}
+
+// passwordOrGenerate mengembalikan password yang diberikan atau membuat password acak
+func passwordOrGenerate(password string) string {
+	if password != "" {
+		return password
+	}
+	token, _, err := mcrypt.GenerateToken()
+	if err != nil {
+		log.Fatal(err)
+	}
+	generated := token[:12]
+	fmt.Printf("password dibuat otomatis: %s\n", generated)
+	return generated
+}
+
+// fatalIf menghentikan perintah apabila terjadi error
+func fatalIf(err rest_err.APIError) {
+	if err != nil {
+		db.Close()
+		log.Fatal(err.Message())
+	}
+}
+
+func runCreateSuperUser(cfg *configs.Config, args []string) {
+	fs := flag.NewFlagSet("create-super-user", flag.ExitOnError)
+	email := fs.String("email", "", "email super user (wajib)")
+	name := fs.String("name", "super", "nama super user")
+	password := fs.String("password", "", "password, dibuat acak apabila kosong")
+	_ = fs.Parse(args)
+	if *email == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	s := newAdminServices(cfg)
+	defer db.Close()
+
+	msg, err := s.user.CreateSuperUser(context.Background(), dto.UserModel{
+		Email:    dto.LowercaseString(strings.ToLower(*email)),
+		Name:     dto.UppercaseString(strings.ToUpper(*name)),
+		Password: passwordOrGenerate(*password),
+	})
+	fatalIf(err)
+	fmt.Println(msg)
+}
+
+func runCreateMerchant(cfg *configs.Config, args []string) {
+	fs := flag.NewFlagSet("create-merchant", flag.ExitOnError)
+	name := fs.String("name", "", "nama merchant (wajib)")
+	description := fs.String("description", "", "deskripsi merchant")
+	ownerName := fs.String("owner-name", "", "nama owner (wajib)")
+	ownerEmail := fs.String("owner-email", "", "email owner (wajib)")
+	password := fs.String("password", "", "password owner, dibuat acak apabila kosong")
+	_ = fs.Parse(args)
+
+	req := dto.MerchantCreateReq{
+		MerchantName:    strings.ToUpper(*name),
+		Description:     *description,
+		OwnerEmail:      strings.ToLower(*ownerEmail),
+		OwnerName:       strings.ToUpper(*ownerName),
+		DefaultPassword: passwordOrGenerate(*password),
+	}
+	if err := req.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
+	s := newAdminServices(cfg)
+	defer db.Close()
+
+	res, err := s.merchant.CreateMerchant(context.Background(), req)
+	fatalIf(err)
+	fmt.Printf("merchant %d %s dibuat dengan owner %s\n", res.MerchantID, res.MerchantName, res.OwnerEmail)
+}
+
+func runResetPassword(cfg *configs.Config, args []string) {
+	fs := flag.NewFlagSet("reset-password", flag.ExitOnError)
+	email := fs.String("email", "", "email user (wajib)")
+	password := fs.String("password", "", "password baru, dibuat acak apabila kosong")
+	_ = fs.Parse(args)
+	if *email == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	s := newAdminServices(cfg)
+	defer db.Close()
+
+	msg, err := s.password.AdminSetPassword(context.Background(), *email, passwordOrGenerate(*password))
+	fatalIf(err)
+	fmt.Println(msg)
+}
+
+// runSeedDemo membuat data demo deterministik sesuai preset ukuran, seed yang sama menghasilkan data yang sama
+func runSeedDemo(cfg *configs.Config, args []string) {
+	fs := flag.NewFlagSet("seed-demo", flag.ExitOnError)
+	size := fs.String("size", "small", fmt.Sprintf("ukuran data demo %v", seeder.PresetNames()))
+	seed := fs.Int64("seed", 1, "seed angka acak, gunakan seed berbeda untuk menambah data pada database yang sama")
+	password := fs.String("password", "demo123", "password seluruh user demo")
+	_ = fs.Parse(args)
+
+	preset, err := seeder.GetPreset(*size)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	s := newAdminServices(cfg)
+	defer db.Close()
+
+	start := time.Now()
+	result, apiErr := seeder.New(s.merchantDao, s.planDao, s.outletDao, s.userDao, s.taxDao, s.productDao, mcrypt.NewCrypto()).
+		Run(context.Background(), preset, *seed, *password)
+	fatalIf(apiErr)
+
+	fmt.Printf("%d merchant, %d outlet, %d user, %d product dan %d harga khusus dibuat dalam %s\n",
+		len(result.MerchantIDs), result.Outlets, result.Users, result.Products, result.CustomPrices, time.Since(start).Round(time.Millisecond))
+	for i, merchantID := range result.MerchantIDs {
+		fmt.Printf("merchant %d login dengan %s / %s\n", merchantID, result.Owners[i], *password)
+	}
+}
+
+// merchantExport seluruh data merchant tanpa password, hash token maupun secret
+type merchantExport struct {
+	ExportedAt   int64                   `json:"exported_at"`
+	Merchant     *dto.Merchant           `json:"merchant"`
+	Setting      *dto.MerchantSetting    `json:"setting"`
+	TaxSetting   *dto.TaxSetting         `json:"tax_setting"`
+	Taxes        []dto.TaxModel          `json:"taxes"`
+	Roles        []dto.RoleModel         `json:"roles"`
+	Outlets      []dto.OutletModel       `json:"outlets"`
+	Users        []dto.UserModel         `json:"users"`
+	Products     []dto.ProductModel      `json:"products"`
+	CustomPrices []dto.ProductPriceModel `json:"custom_prices"`
+}
+
+func runExportMerchant(cfg *configs.Config, args []string) {
+	fs := flag.NewFlagSet("export-merchant", flag.ExitOnError)
+	merchantID := fs.Int("id", 0, "id merchant (wajib)")
+	output := fs.String("o", "", "file tujuan, kosong berarti stdout")
+	_ = fs.Parse(args)
+	if *merchantID == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	s := newAdminServices(cfg)
+	defer db.Close()
+	ctx := context.Background()
+
+	export, err := s.exportMerchant(ctx, *merchantID)
+	fatalIf(err)
+
+	data, errJSON := json.MarshalIndent(export, "", "  ")
+	if errJSON != nil {
+		log.Fatal(errJSON)
+	}
+
+	if *output == "" {
+		fmt.Println(string(data))
+		return
+	}
+	if err := ioutil.WriteFile(*output, data, 0600); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("merchant %d diexport ke %s\n", *merchantID, *output)
+}
+
+func (a *adminServices) exportMerchant(ctx context.Context, merchantID int) (*merchantExport, rest_err.APIError) {
+	var err rest_err.APIError
+	export := merchantExport{ExportedAt: time.Now().Unix()}
+
+	if export.Merchant, err = a.merchantDao.Get(ctx, merchantID); err != nil {
+		return nil, err
+	}
+	if export.Setting, err = a.merchantDao.GetSetting(ctx, merchantID); err != nil {
+		return nil, err
+	}
+	if export.TaxSetting, err = a.taxDao.GetSetting(ctx, merchantID); err != nil {
+		return nil, err
+	}
+	if export.Taxes, err = a.taxDao.FindByMerchant(ctx, merchantID); err != nil {
+		return nil, err
+	}
+	if export.Roles, err = a.roleDao.FindByMerchant(ctx, merchantID); err != nil {
+		return nil, err
+	}
+
+	for offset := 0; ; offset += exportPageSize {
+		outlets, err := a.outletDao.FindWithPagination(ctx, outlet_dao.FindParams{Limit: exportPageSize, Offset: offset}, merchantID)
+		if err != nil {
+			return nil, err
+		}
+		export.Outlets = append(export.Outlets, outlets...)
+		if len(outlets) < exportPageSize {
+			break
+		}
+	}
+
+	for offset := 0; ; offset += exportPageSize {
+		users, err := a.userDao.FindWithPagination(ctx, user_dao.FindPaginationParams{Limit: exportPageSize, Offset: offset, MerchantID: merchantID})
+		if err != nil {
+			return nil, err
+		}
+		export.Users = append(export.Users, users...)
+		if len(users) < exportPageSize {
+			break
+		}
+	}
+
+	for offset := 0; ; offset += exportPageSize {
+		products, err := a.productDao.FindWithPagination(ctx, product_dao.FindParams{Limit: exportPageSize, Offset: offset}, merchantID)
+		if err != nil {
+			return nil, err
+		}
+		export.Products = append(export.Products, products...)
+		if len(products) < exportPageSize {
+			break
+		}
+	}
+
+	for _, outlet := range export.Outlets {
+		prices, err := a.productDao.FindCustomPriceOutlet(ctx, outlet.ID)
+		if err != nil {
+			return nil, err
+		}
+		export.CustomPrices = append(export.CustomPrices, prices...)
+	}
+
+	return &export, nil
+}
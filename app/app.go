@@ -1,15 +1,23 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"github.com/gofiber/fiber/v2"
 	"github.com/muchlist/mini_pos/configs"
 	"github.com/muchlist/mini_pos/db"
 	_ "github.com/muchlist/mini_pos/docs"
+	"github.com/muchlist/mini_pos/service/health_serv"
 	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/metrics"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/muchlist/mini_pos/utils/tracing"
 	"log"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
 )
 
 // RunApp
@@ -26,34 +34,58 @@ import (
 // @name Authorization
 // @host localhost:3500
 // @BasePath /api/v1
-func RunApp() {
-	// Init config, logger dan db
-	configs.InitConfig()
-	logger.InitLogger()
-	db.Init()
+func RunApp(cfg *configs.Config) {
+	// Init logger, kunci jwt dan db
+	logger.InitLogger(cfg.Log)
+	mjwt.Init(cfg.Auth.SecretKey, cfg.Auth.KeyDir)
+	db.Init(cfg.Database)
 	defer db.Close()
 
+	migrator, err := db.NewMigrator(db.DB)
+	if err != nil {
+		log.Fatal(err)
+	}
+	metrics.Register(metrics.NewPoolCollector(db.DB))
+	shutdownTracing, err := tracing.Init(cfg.Tracing)
+	if err != nil {
+		log.Fatal(err)
+	}
+	healthService := health_serv.NewHealthService(db.DB, migrator, []string{
+		filepath.Join(cfg.Storage.ImageDir, "products"),
+		filepath.Join(cfg.Storage.ImageDir, "merchants"),
+	})
+
 	// membuat fiber app
-	app := fiber.New()
+	app := fiber.New(fiber.Config{
+		BodyLimit: cfg.Server.BodyLimit,
+	})
 
-	// gracefully shutdown
+	// gracefully shutdown, readiness dibuat gagal lebih dulu agar load balancer berhenti mengirim request
 	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 
 	go func() {
 		_ = <-c
 		fmt.Println("Gracefully shutting down...")
+		healthService.StartShutdown()
+		time.Sleep(cfg.Server.ShutdownDelay)
 		_ = app.Shutdown()
 	}()
 
-	prepareEndPoint(app)
+	prepareEndPoint(app, cfg, healthService)
 
 	// blocking and listen for fiber
-	if err := app.Listen(":3500"); err != nil {
+	if err := app.Listen(cfg.Server.Addr); err != nil {
 		logger.Error("error fiber listen", err)
 		log.Panic()
 	}
 
 	// cleanup app
 	fmt.Println("Running cleanup tasks...")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// mengirim span yang masih tersisa di antrian exporter
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Error("error saat menghentikan tracing (RunApp:1)", err)
+	}
 }
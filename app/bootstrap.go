@@ -0,0 +1,88 @@
+package app
+
+import (
+	"context"
+	"github.com/muchlist/mini_pos/configs"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/handler"
+	"github.com/muchlist/mini_pos/service/user_serv"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/mailer"
+	"github.com/muchlist/mini_pos/utils/ratelimit"
+	"strings"
+)
+
+// bootstrapSuperUser membuat super user pertama apabila email dan password super user diisi
+// dan belum ada satupun super user di database
+func bootstrapSuperUser(cfg configs.SuperUserConfig, userService user_serv.UserServiceAssumer) {
+	if cfg.Email == "" || cfg.Password == "" {
+		return
+	}
+
+	name := cfg.Name
+	if name == "" {
+		name = "super"
+	}
+
+	msg, err := userService.BootstrapSuperUser(context.Background(), dto.UserModel{
+		Email:    dto.LowercaseString(strings.ToLower(cfg.Email)),
+		Name:     dto.UppercaseString(strings.ToUpper(name)),
+		Password: cfg.Password,
+	})
+	if err != nil {
+		logger.Error("gagal membuat super user", err)
+		return
+	}
+	logger.Info(msg)
+}
+
+// newMailer memakai SMTP apabila host smtp diisi, selain itu email hanya ditulis ke log
+// tanpa isi kecuali mail.log_body diaktifkan untuk development
+func newMailer(cfg configs.MailConfig) mailer.Mailer {
+	if cfg.Host == "" {
+		if cfg.LogBody {
+			logger.Warn("BA_SMTP_HOST kosong dan BA_SMTP_LOG_BODY aktif, isi email termasuk token akan ditulis ke log")
+		} else {
+			logger.Info("BA_SMTP_HOST kosong, email tidak dikirim dan hanya penerima serta subject yang ditulis ke log")
+		}
+		return mailer.NewLogMailer(cfg.LogBody)
+	}
+
+	return mailer.NewSMTPMailer(mailer.SMTPConfig{
+		Host:     cfg.Host,
+		Port:     cfg.Port,
+		Username: cfg.User,
+		Password: cfg.Password,
+		From:     cfg.From,
+		Timeout:  cfg.Timeout,
+	})
+}
+
+// tokenLifetime masa berlaku token user service dari konfigurasi auth
+func tokenLifetime(cfg configs.AuthConfig) user_serv.TokenLifetime {
+	return user_serv.TokenLifetime{
+		Access:      cfg.AccessTokenTTL,
+		Refresh:     cfg.RefreshTokenTTL,
+		Impersonate: cfg.ImpersonateTokenTTL,
+		Challenge:   cfg.ChallengeTokenTTL,
+	}
+}
+
+// imageStorage lokasi dan batas ukuran gambar upload dari konfigurasi
+func imageStorage(cfg *configs.Config) handler.ImageStorage {
+	return handler.ImageStorage{
+		Dir:     cfg.Storage.ImageDir,
+		MaxSize: cfg.Upload.MaxImageSize,
+	}
+}
+
+// rateLimitRule rule token bucket dari konfigurasi rate limit
+func rateLimitRule(name string, by string, cfg configs.RateLimitRule) ratelimit.Rule {
+	return ratelimit.Rule{
+		Name:     name,
+		By:       by,
+		Requests: cfg.Requests,
+		Per:      cfg.Per,
+		Burst:    cfg.Burst,
+	}
+}
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/muchlist/mini_pos/configs"
+	"os"
+)
+
+const cliUsage = `penggunaan: mini_pos [flag konfigurasi] <perintah> [argumen]
+
+perintah:
+  serve                 menjalankan http server (default apabila perintah kosong)
+  migrate               mengelola migrasi skema database
+  keys                  mengelola kunci penandatangan jwt
+  create-super-user     membuat super user baru
+  create-merchant       membuat merchant beserta owner
+  reset-password        mengganti password user dan mencabut seluruh sesinya
+  seed-demo             mengisi database dengan data demo (-size small|medium|large)
+  export-merchant       menulis seluruh data merchant dalam format json
+
+jalankan mini_pos <perintah> -h untuk melihat argumen perintah.
+konfigurasi dibaca dari config.yaml, environment (termasuk .env) lalu flag, sumber terakhir menimpa sebelumnya`
+
+// RunCLI membaca konfigurasi dari flag sebelum nama perintah, file yaml dan environment lalu menjalankan
+// perintah sesuai argumen pertama, tanpa argumen aplikasi berjalan sebagai http server
+func RunCLI(args []string) {
+	if len(args) > 0 && args[0] == "help" {
+		printUsage()
+		return
+	}
+
+	cfg, args, err := configs.LoadFromOS(args)
+	if errors.Is(err, flag.ErrHelp) {
+		printUsage()
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if len(args) == 0 {
+		RunApp(cfg)
+		return
+	}
+
+	switch args[0] {
+	case "serve":
+		RunApp(cfg)
+	case "migrate":
+		RunMigrate(cfg, args[1:])
+	case "keys":
+		RunKeys(cfg, args[1:])
+	case "create-super-user":
+		runCreateSuperUser(cfg, args[1:])
+	case "create-merchant":
+		runCreateMerchant(cfg, args[1:])
+	case "reset-password":
+		runResetPassword(cfg, args[1:])
+	case "seed-demo":
+		runSeedDemo(cfg, args[1:])
+	case "export-merchant":
+		runExportMerchant(cfg, args[1:])
+	case "help":
+		printUsage()
+	default:
+		printUsage()
+		os.Exit(2)
+	}
+}
+
+func printUsage() {
+	fmt.Println(cliUsage)
+	fmt.Println()
+	fmt.Println("flag konfigurasi:")
+	fmt.Print(configs.Usage())
+}
@@ -4,54 +4,144 @@ import (
 	swagger "github.com/arsmn/fiber-swagger/v2"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/gofiber/fiber/v2/middleware/logger"
+	"github.com/muchlist/mini_pos/configs"
+	"github.com/muchlist/mini_pos/configs/permissions"
 	"github.com/muchlist/mini_pos/configs/roles"
+	"github.com/muchlist/mini_pos/dao/api_key_dao"
+	"github.com/muchlist/mini_pos/dao/audit_dao"
+	"github.com/muchlist/mini_pos/dao/device_dao"
+	"github.com/muchlist/mini_pos/dao/impersonation_dao"
 	"github.com/muchlist/mini_pos/dao/merchant_dao"
 	"github.com/muchlist/mini_pos/dao/outlet_dao"
+	"github.com/muchlist/mini_pos/dao/password_reset_dao"
+	"github.com/muchlist/mini_pos/dao/plan_dao"
 	"github.com/muchlist/mini_pos/dao/product_dao"
+	"github.com/muchlist/mini_pos/dao/refresh_token_dao"
+	"github.com/muchlist/mini_pos/dao/role_dao"
+	"github.com/muchlist/mini_pos/dao/session_dao"
+	"github.com/muchlist/mini_pos/dao/signup_dao"
+	"github.com/muchlist/mini_pos/dao/tax_dao"
+	"github.com/muchlist/mini_pos/dao/totp_dao"
 	"github.com/muchlist/mini_pos/dao/user_dao"
+	"github.com/muchlist/mini_pos/dao/user_outlet_dao"
 	"github.com/muchlist/mini_pos/db"
 	"github.com/muchlist/mini_pos/handler"
 	"github.com/muchlist/mini_pos/middleware"
+	"github.com/muchlist/mini_pos/service/api_key_serv"
+	"github.com/muchlist/mini_pos/service/audit_serv"
+	"github.com/muchlist/mini_pos/service/device_serv"
+	"github.com/muchlist/mini_pos/service/health_serv"
 	"github.com/muchlist/mini_pos/service/merchant_serv"
 	"github.com/muchlist/mini_pos/service/outlet_serv"
+	"github.com/muchlist/mini_pos/service/password_serv"
+	"github.com/muchlist/mini_pos/service/plan_serv"
 	"github.com/muchlist/mini_pos/service/product_serv"
+	"github.com/muchlist/mini_pos/service/role_serv"
+	"github.com/muchlist/mini_pos/service/setting_serv"
+	"github.com/muchlist/mini_pos/service/signup_serv"
+	"github.com/muchlist/mini_pos/service/tax_serv"
 	"github.com/muchlist/mini_pos/service/user_serv"
+	"github.com/muchlist/mini_pos/utils/bruteforce"
 	"github.com/muchlist/mini_pos/utils/mcrypt"
+	"github.com/muchlist/mini_pos/utils/metrics"
 	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/muchlist/mini_pos/utils/notifier"
+	"github.com/muchlist/mini_pos/utils/ratelimit"
+	"github.com/muchlist/mini_pos/utils/tracing"
+	"path/filepath"
+	"strings"
 )
 
-func prepareEndPoint(app *fiber.App) {
+func prepareEndPoint(app *fiber.App, cfg *configs.Config, healthService health_serv.HealthServiceAssumer) {
 
 	// Utils
 	cryptoUtils := mcrypt.NewCrypto()
 	jwt := mjwt.NewJwt()
+	loginGuard := bruteforce.NewGuard()
+	mail := newMailer(cfg.Mail)
+	notify := notifier.NewMailNotifier(mail)
 
 	// Merchant Domain
 	merchantDao := merchant_dao.New(db.DB)
 	merchantService := merchant_serv.NewMerchantService(merchantDao, cryptoUtils)
 	merchantHandler := handler.NewMerchantHandler(merchantService)
+	settingService := setting_serv.NewSettingService(merchantDao)
+	settingHandler := handler.NewSettingHandler(settingService, imageStorage(cfg))
 
-	// User Domain
-	userDao := user_dao.New(db.DB)
-	userService := user_serv.NewUserService(userDao, cryptoUtils, jwt)
-	userHandler := handler.NewUserHandler(userService)
+	// Plan Domain
+	planDao := plan_dao.New(db.DB)
+	planService := plan_serv.NewPlanService(planDao, merchantDao)
+	planHandler := handler.NewPlanHandler(planService)
 
 	// Outlet Domain
 	outletDao := outlet_dao.New(db.DB)
-	outletService := outlet_serv.NewOutletService(outletDao)
+	userOutletDao := user_outlet_dao.New(db.DB)
+	outletService := outlet_serv.NewOutletService(outletDao, planDao, userOutletDao, merchantDao)
 	outletHandler := handler.NewOutletHandler(outletService)
 
+	// User Domain
+	userDao := user_dao.New(db.DB)
+	impersonationDao := impersonation_dao.New(db.DB)
+	refreshTokenDao := refresh_token_dao.New(db.DB)
+	sessionDao := session_dao.New(db.DB)
+	middleware.SetSessionChecker(sessionDao)
+	totpDao := totp_dao.New(db.DB)
+	roleDao := role_dao.New(db.DB)
+	userService := user_serv.NewUserService(userDao, impersonationDao, planDao, refreshTokenDao, sessionDao, totpDao, roleDao, userOutletDao, merchantDao, outletService, cryptoUtils, jwt, loginGuard, tokenLifetime(cfg.Auth))
+	userHandler := handler.NewUserHandler(userService)
+	bootstrapSuperUser(cfg.Super, userService)
+	roleService := role_serv.NewRoleService(roleDao, merchantDao)
+	roleHandler := handler.NewRoleHandler(roleService)
+	passwordResetDao := password_reset_dao.New(db.DB)
+	passwordService := password_serv.NewPasswordService(userDao, passwordResetDao, cryptoUtils, notify, cfg.AppURL)
+	passwordHandler := handler.NewPasswordHandler(passwordService)
+
+	// Signup Domain
+	signupDao := signup_dao.New(db.DB)
+	signupService := signup_serv.NewSignupService(signupDao, userDao, cryptoUtils, mail, cfg.AppURL)
+	signupHandler := handler.NewSignupHandler(signupService)
+
+	// Device Domain
+	deviceDao := device_dao.New(db.DB)
+	deviceService := device_serv.NewDeviceService(deviceDao, userDao, userOutletDao, outletDao, sessionDao, roleDao, merchantDao, cryptoUtils, jwt, loginGuard)
+	deviceHandler := handler.NewDeviceHandler(deviceService)
+
+	// ApiKey Domain
+	apiKeyDao := api_key_dao.New(db.DB)
+	apiKeyService := api_key_serv.NewAPIKeyService(apiKeyDao, outletService, merchantDao)
+	middleware.SetAPIKeyAuthenticator(apiKeyService)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyService)
+
+	// Audit Domain
+	auditDao := audit_dao.New(db.DB)
+	auditService := audit_serv.NewAuditService(auditDao, merchantDao)
+	auditHandler := handler.NewAuditHandler(auditService)
+
+	// Tax Domain
+	taxDao := tax_dao.New(db.DB)
+	taxService := tax_serv.NewTaxService(taxDao, merchantDao)
+	taxHandler := handler.NewTaxHandler(taxService)
+
 	// Product Domain
 	productDao := product_dao.New(db.DB)
-	productService := product_serv.NewProductService(productDao)
-	productHandler := handler.NewProductHandler(productService)
+	productService := product_serv.NewProductService(productDao, taxDao, merchantDao, planDao, outletService)
+	productHandler := handler.NewProductHandler(productService, imageStorage(cfg))
 
-	app.Use(logger.New())
+	// Health Domain
+	healthHandler := handler.NewHealthHandler(healthService)
+
+	app.Use(middleware.RequestID())
+	app.Use(metrics.Middleware())
+	// tracing wajib terpasang sebelum route karena handler memakai c.UserContext()
+	app.Use(tracing.Middleware())
+	app.Use(middleware.AccessLog())
 	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowHeaders: "Content-Type, Accept, Authorization",
+		AllowOrigins: strings.Join(cfg.Server.CORSOrigins, ","),
+		AllowHeaders: "Content-Type, Accept, Authorization, X-Device-Token, X-Request-ID",
+		// header rate limit perlu dibuka agar dapat dibaca client browser
+		ExposeHeaders: "X-Request-ID, RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, Retry-After",
 	}))
+	app.Use(middleware.AuditContext())
 
 	app.Get("/swagger/*", swagger.Handler) // default
 
@@ -62,43 +152,128 @@ func prepareEndPoint(app *fiber.App) {
 		DocExpansion: "none",
 	}))
 
-	app.Static("/image/products", "./static/image/products")
+	app.Static("/image/products", filepath.Join(cfg.Storage.ImageDir, "products"))
+	app.Static("/image/merchants", filepath.Join(cfg.Storage.ImageDir, "merchants"))
+
+	// kunci publik untuk layanan lain yang memverifikasi access token
+	app.Get("/.well-known/jwks.json", handler.JWKS)
+	app.Get("/healthz", healthHandler.Healthz)
+	app.Get("/readyz", healthHandler.Readyz)
+	app.Get("/version", healthHandler.Version)
+	app.Get("/metrics", metrics.Handler())
+
+	// rate limit, api berlaku untuk seluruh /api/v1 sedangkan auth hanya untuk endpoint tanpa token
+	rateLimitStore := ratelimit.NewMemoryStore()
+	apiLimit := middleware.RateLimit(rateLimitStore,
+		rateLimitRule("api", ratelimit.ByIdentity, cfg.Limit.API),
+		rateLimitRule("merchant", ratelimit.ByMerchant, cfg.Limit.Merchant),
+	)
+	authLimit := middleware.RateLimit(rateLimitStore, rateLimitRule("auth", ratelimit.ByIP, cfg.Limit.Auth))
 
 	// url mapping
-	api := app.Group("/api/v1")
+	api := app.Group("/api/v1", apiLimit)
 
 	// Merchant Endpoint
-	api.Post("/merchant", merchantHandler.CreateMerchant)
-	api.Get("/merchant/:id", merchantHandler.GetMerchant)
-	api.Get("/merchant", merchantHandler.FindMerchant)
-	api.Put("/merchant/:id", merchantHandler.EditMerchant)
-	api.Delete("/merchant/:id", merchantHandler.DeleteMerchant)
+	api.Post("/merchant", middleware.NormalAuth(roles.RoleSuper), merchantHandler.CreateMerchant)
+	api.Get("/merchant/:id", middleware.NormalAuth(roles.RoleSuper), merchantHandler.GetMerchant)
+	api.Get("/merchant", middleware.NormalAuth(roles.RoleSuper), merchantHandler.FindMerchant)
+	api.Put("/merchant/:id", middleware.NormalAuth(roles.RoleSuper), merchantHandler.EditMerchant)
+	api.Delete("/merchant/:id", middleware.FreshAuth(roles.RoleSuper), merchantHandler.DeleteMerchant)
+	api.Post("/merchant/:id/impersonate", middleware.FreshAuth(roles.RoleSuper), userHandler.Impersonate)
+	api.Get("/impersonations", middleware.NormalAuth(roles.RoleSuper), userHandler.FindImpersonations)
+	api.Put("/merchant/:id/plan", middleware.NormalAuth(roles.RoleSuper), planHandler.SetMerchantPlan)
+
+	// Plan Endpoint
+	api.Get("/plans", middleware.NormalAuth(), planHandler.Find)
+	api.Post("/plans", middleware.NormalAuth(roles.RoleSuper), planHandler.CreatePlan)
+	api.Put("/plans/:id", middleware.NormalAuth(roles.RoleSuper), planHandler.Edit)
+	api.Get("/usage", middleware.PermissionAuth(permissions.UsageView), planHandler.GetUsage)
+
+	// Signup Endpoint
+	api.Post("/signup", authLimit, signupHandler.Signup)
+	api.Post("/signup/verify", authLimit, signupHandler.Verify)
+
+	// Merchant Setting Endpoint
+	api.Get("/merchant-settings", middleware.NormalAuth(), settingHandler.Get)
+	api.Put("/merchant-settings", middleware.PermissionAuth(permissions.SettingEdit), settingHandler.Edit)
+	api.Post("/merchant-settings/logo", middleware.PermissionAuth(permissions.SettingEdit), settingHandler.UploadLogo)
 
 	// USER Endpont
 	api.Get("/users/:id", userHandler.Get)
 	api.Get("/users", userHandler.Find)
-	api.Post("/login", userHandler.Login)
-	api.Post("/refresh", userHandler.RefreshToken)
+	api.Post("/login", authLimit, userHandler.Login)
+	api.Post("/login/2fa", authLimit, userHandler.LoginTwoFactor)
+	api.Post("/login/2fa/setup", authLimit, userHandler.SetupTwoFactorLogin)
+	api.Post("/refresh", authLimit, userHandler.RefreshToken)
+	api.Post("/logout", middleware.NormalAuth(), userHandler.Logout)
+	api.Post("/logout-all", middleware.NormalAuth(), userHandler.LogoutAll)
 	api.Get("/profile", middleware.NormalAuth(), userHandler.GetProfile)
-	api.Post("/register", middleware.FreshAuth(roles.RoleOwner), userHandler.Register)
-	api.Put("/users/:id", middleware.NormalAuth(roles.RoleOwner), userHandler.Edit)
-	api.Delete("/users/:id", middleware.NormalAuth(roles.RoleOwner), userHandler.Delete)
+	api.Get("/profile/sessions", middleware.NormalAuth(), userHandler.GetProfileSessions)
+	api.Delete("/profile/sessions/:sid", middleware.NormalAuth(), userHandler.DeleteProfileSession)
+	api.Get("/users/:id/sessions", middleware.PermissionAuth(permissions.UserManage), userHandler.GetUserSessions)
+	api.Delete("/users/:id/sessions/:sid", middleware.PermissionAuth(permissions.UserManage), userHandler.DeleteUserSession)
+	api.Post("/profile/2fa", middleware.FreshAuth(roles.RoleOwner), userHandler.EnrollTwoFactor)
+	api.Post("/profile/2fa/confirm", middleware.FreshAuth(roles.RoleOwner), userHandler.ConfirmTwoFactor)
+	api.Post("/profile/2fa/disable", middleware.FreshAuth(roles.RoleOwner), userHandler.DisableTwoFactor)
+	api.Post("/register", middleware.FreshPermissionAuth(permissions.UserManage), userHandler.Register)
+	api.Put("/users/:id", middleware.PermissionAuth(permissions.UserManage), userHandler.Edit)
+	api.Delete("/users/:id", middleware.PermissionAuth(permissions.UserManage), userHandler.Delete)
+	api.Post("/users/:id/unlock", middleware.PermissionAuth(permissions.UserManage), userHandler.Unlock)
+	api.Get("/users/:id/outlets", middleware.PermissionAuth(permissions.UserManage), userHandler.GetUserOutlets)
+	api.Put("/users/:id/outlets", middleware.PermissionAuth(permissions.UserManage), userHandler.SetUserOutlets)
+	api.Post("/profile/outlet", middleware.NormalAuth(), userHandler.SwitchOutlet)
+	api.Put("/change-password", middleware.FreshAuth(), passwordHandler.ChangePassword)
+	api.Post("/forgot-password", authLimit, passwordHandler.ForgotPassword)
+	api.Post("/reset-password", authLimit, passwordHandler.ResetPassword)
+	api.Post("/users/:id/reset-password", middleware.FreshPermissionAuth(permissions.UserManage), passwordHandler.OwnerResetPassword)
+
+	// Role Endpoint
+	api.Get("/permissions", middleware.NormalAuth(), roleHandler.FindPermissions)
+	api.Get("/roles", middleware.NormalAuth(), roleHandler.Find)
+	api.Post("/roles", middleware.PermissionAuth(permissions.RoleManage), roleHandler.CreateRole)
+	api.Put("/roles/:id", middleware.PermissionAuth(permissions.RoleManage), roleHandler.Edit)
+	api.Delete("/roles/:id", middleware.PermissionAuth(permissions.RoleManage), roleHandler.Delete)
+
+	// Device Endpoint
+	api.Post("/devices", middleware.FreshPermissionAuth(permissions.DeviceManage), deviceHandler.RegisterDevice)
+	api.Get("/devices", middleware.PermissionAuth(permissions.DeviceManage), deviceHandler.Find)
+	api.Delete("/devices/:id", middleware.PermissionAuth(permissions.DeviceManage), deviceHandler.Revoke)
+	api.Get("/device/users", deviceHandler.FindUsers)
+	api.Post("/pin-login", authLimit, deviceHandler.PinLogin)
+	api.Put("/profile/pin", middleware.FreshAuth(), deviceHandler.SetPin)
+
+	// ApiKey Endpoint
+	api.Post("/api-keys", middleware.FreshPermissionAuth(permissions.APIKeyManage), apiKeyHandler.Create)
+	api.Get("/api-keys", middleware.PermissionAuth(permissions.APIKeyManage), apiKeyHandler.Find)
+	api.Delete("/api-keys/:id", middleware.PermissionAuth(permissions.APIKeyManage), apiKeyHandler.Revoke)
+
+	// Audit Endpoint
+	api.Get("/audit-logs", middleware.PermissionAuth(permissions.AuditView), auditHandler.Find)
 
 	// Outlet Endpont
 	api.Get("/outlets/:id", middleware.NormalAuth(), outletHandler.Get)
 	api.Get("/outlets", middleware.NormalAuth(), outletHandler.Find)
 	api.Get("/current-outlet", middleware.NormalAuth(), outletHandler.GetCurrentOutlet)
-	api.Post("/outlets", middleware.NormalAuth(roles.RoleOwner), outletHandler.CreateOutlet)
-	api.Put("/outlets/:id", middleware.NormalAuth(roles.RoleOwner), outletHandler.Edit)
-	api.Delete("/outlets/:id", middleware.NormalAuth(roles.RoleOwner), outletHandler.Delete)
+	api.Post("/outlets", middleware.PermissionAuth(permissions.OutletManage), outletHandler.CreateOutlet)
+	api.Put("/outlets/:id", middleware.PermissionAuth(permissions.OutletManage), outletHandler.Edit)
+	api.Delete("/outlets/:id", middleware.PermissionAuth(permissions.OutletManage), outletHandler.Delete)
 
 	// Product Endpont
 	api.Get("/products/:id", middleware.NormalAuth(), productHandler.Get)
 	api.Get("/products", middleware.NormalAuth(), productHandler.Find)
-	api.Post("/products", middleware.NormalAuth(roles.RoleOwner), productHandler.CreateProduct)
-	api.Put("/products/:id", middleware.NormalAuth(roles.RoleOwner), productHandler.Edit)
-	api.Delete("/products/:id", middleware.NormalAuth(roles.RoleOwner), productHandler.Delete)
-	api.Post("/set-price", middleware.NormalAuth(roles.RoleOwner), productHandler.SetCustomPrice)
-	api.Post("/products-image/:id", middleware.NormalAuth(roles.RoleOwner), productHandler.UploadImage)
+	api.Post("/products", middleware.PermissionAuth(permissions.ProductCreate), productHandler.CreateProduct)
+	api.Put("/products/:id", middleware.PermissionAuth(permissions.ProductEdit), productHandler.Edit)
+	api.Delete("/products/:id", middleware.PermissionAuth(permissions.ProductDelete), productHandler.Delete)
+	api.Post("/set-price", middleware.PermissionAuth(permissions.PriceSet), productHandler.SetCustomPrice)
+	api.Post("/products-image/:id", middleware.PermissionAuth(permissions.ProductEdit), productHandler.UploadImage)
+
+	// Tax Endpoint
+	api.Get("/taxes/:id", middleware.NormalAuth(), taxHandler.Get)
+	api.Get("/taxes", middleware.NormalAuth(), taxHandler.Find)
+	api.Post("/taxes", middleware.PermissionAuth(permissions.TaxManage), taxHandler.CreateTax)
+	api.Put("/taxes/:id", middleware.PermissionAuth(permissions.TaxManage), taxHandler.Edit)
+	api.Delete("/taxes/:id", middleware.PermissionAuth(permissions.TaxManage), taxHandler.Delete)
+	api.Get("/tax-setting", middleware.NormalAuth(), taxHandler.GetSetting)
+	api.Put("/tax-setting", middleware.PermissionAuth(permissions.TaxManage), taxHandler.EditSetting)
 
 }
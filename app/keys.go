@@ -0,0 +1,85 @@
+package app
+
+import (
+	"flag"
+	"fmt"
+	"github.com/muchlist/mini_pos/configs"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"log"
+	"os"
+)
+
+const keysUsage = `penggunaan: mini_pos keys <perintah>
+
+perintah:
+  list                       menampilkan seluruh kunci dan kunci aktif
+  generate [-alg RS256|EdDSA] membuat kunci verifikasi baru tanpa mengaktifkannya
+  activate <kid>             menjadikan kunci sebagai penandatangan token baru
+  rotate [-alg RS256|EdDSA]  generate lalu activate, kunci lama tetap dipakai untuk verifikasi
+  remove <kid>               menghapus kunci yang tidak aktif
+
+rotasi pada beberapa instance: generate lalu restart seluruh instance agar kunci baru dikenal,
+activate lalu restart kembali, remove kunci lama setelah seluruh token lama kadaluarsa`
+
+// RunKeys mengelola kunci jwt pada direktori BA_JWT_KEY_DIR, perubahan berlaku setelah aplikasi di restart
+func RunKeys(cfg *configs.Config, args []string) {
+	dir := cfg.Auth.KeyDir
+
+	if len(args) == 0 {
+		fmt.Println(keysUsage)
+		os.Exit(2)
+	}
+
+	switch args[0] {
+	case "list":
+		set, err := mjwt.LoadKeys(dir)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, kid := range set.SortedKIDs() {
+			mark := ""
+			if set.Active != nil && set.Active.KID == kid {
+				mark = " (aktif)"
+			}
+			fmt.Printf("%s %s%s\n", kid, set.Keys[kid].Alg, mark)
+		}
+	case "generate", "rotate":
+		fs := flag.NewFlagSet(args[0], flag.ExitOnError)
+		alg := fs.String("alg", mjwt.AlgEdDSA, "algoritma kunci, RS256 atau EdDSA")
+		_ = fs.Parse(args[1:])
+
+		key, err := mjwt.GenerateKey(*alg)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := mjwt.SaveKey(dir, key); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("kunci %s %s dibuat pada %s\n", key.KID, key.Alg, dir)
+		if args[0] == "rotate" {
+			if err := mjwt.SetActiveKey(dir, key.KID); err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("kunci %s aktif\n", key.KID)
+		}
+	case "activate":
+		if len(args) != 2 {
+			log.Fatal("penggunaan: mini_pos keys activate <kid>")
+		}
+		if err := mjwt.SetActiveKey(dir, args[1]); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("kunci %s aktif\n", args[1])
+	case "remove":
+		if len(args) != 2 {
+			log.Fatal("penggunaan: mini_pos keys remove <kid>")
+		}
+		if err := mjwt.RemoveKey(dir, args[1]); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("kunci %s dihapus\n", args[1])
+	default:
+		fmt.Println(keysUsage)
+		os.Exit(2)
+	}
+}
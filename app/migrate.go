@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"github.com/muchlist/mini_pos/configs"
+	"github.com/muchlist/mini_pos/db"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+const migrateUsage = `penggunaan: mini_pos migrate <perintah>
+
+perintah:
+  up [versi]         menjalankan migrasi yang belum diterapkan sampai versi (default versi terakhir)
+  down [jumlah]      membatalkan migrasi terbaru sebanyak jumlah (default 1)
+  status             menampilkan versi migrasi dan waktu diterapkan
+  baseline <versi>   menandai migrasi sampai versi sebagai sudah diterapkan tanpa menjalankannya,
+                     untuk database yang dibuat manual dari doc/database.sql sebelum ada migrasi
+                     (skema 0001_init), lalu jalankan up untuk migrasi berikutnya`
+
+// RunMigrate mengelola skema database melalui migrasi yang di embed pada binary
+func RunMigrate(cfg *configs.Config, args []string) {
+	if len(args) == 0 {
+		fmt.Println(migrateUsage)
+		os.Exit(2)
+	}
+
+	logger.InitLogger(cfg.Log)
+	db.Init(cfg.Database)
+	defer db.Close()
+
+	migrator, err := db.NewMigrator(db.DB)
+	if err != nil {
+		log.Fatal(err)
+	}
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		migrations, err := migrator.Up(ctx, intArg(args, 0))
+		for _, m := range migrations {
+			fmt.Printf("up %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(migrations) == 0 {
+			fmt.Println("skema sudah versi terbaru")
+		}
+	case "down":
+		migrations, err := migrator.Down(ctx, intArg(args, 1))
+		for _, m := range migrations {
+			fmt.Printf("down %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, s := range statuses {
+			applied := "belum diterapkan"
+			if s.AppliedAt != 0 {
+				applied = time.Unix(s.AppliedAt, 0).Format(time.RFC3339)
+			}
+			fmt.Printf("%04d_%s %s\n", s.Version, s.Name, applied)
+		}
+	case "baseline":
+		version := intArg(args, 0)
+		if version == 0 {
+			log.Fatal("penggunaan: mini_pos migrate baseline <versi>")
+		}
+		if err := migrator.Baseline(ctx, version); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("migrasi sampai versi %d ditandai sudah diterapkan\n", version)
+	default:
+		fmt.Println(migrateUsage)
+		os.Exit(2)
+	}
+}
+
+// intArg argumen angka kedua perintah, defaultValue apabila tidak diisi
+func intArg(args []string, defaultValue int) int {
+	if len(args) < 2 {
+		return defaultValue
+	}
+	value, err := strconv.Atoi(args[1])
+	if err != nil || value < 0 {
+		log.Fatalf("argumen %s harus berupa angka", args[1])
+	}
+	return value
+}
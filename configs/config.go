@@ -1,39 +1,524 @@
 package configs
 
 import (
+	"errors"
+	"flag"
+	"fmt"
 	"github.com/joho/godotenv"
-	"log"
+	"github.com/muchlist/mini_pos/dto"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
-type configuration struct {
-	DBUSER    string
-	DBPASS    string
-	DBHOST    string
-	DBPORT    string
-	DBNAME    string
-	LOGLEVEL  string
-	LOGOUTPUT string
-	SECRETKEY string
+const (
+	// DefaultConfigFile dibaca apabila ada, file lain dapat dipilih melalui -config atau BA_CONFIG_FILE
+	DefaultConfigFile = "config.yaml"
+	envConfigFile     = "BA_CONFIG_FILE"
+	envFile           = ".env"
+)
+
+// Config seluruh konfigurasi aplikasi. Nilai diambil berurutan dari default, file yaml, environment
+// (termasuk .env) lalu flag, sumber yang lebih akhir menimpa sumber sebelumnya
+type Config struct {
+	Server   ServerConfig    `yaml:"server"`
+	Database DatabaseConfig  `yaml:"database"`
+	Log      LogConfig       `yaml:"log"`
+	Auth     AuthConfig      `yaml:"auth"`
+	Upload   UploadConfig    `yaml:"upload"`
+	Storage  StorageConfig   `yaml:"storage"`
+	Super    SuperUserConfig `yaml:"super_user"`
+	Mail     MailConfig      `yaml:"mail"`
+	Tracing  TracingConfig   `yaml:"tracing"`
+	Limit    RateLimitConfig `yaml:"rate_limit"`
+	// AppURL url frontend untuk link pada email
+	AppURL string `yaml:"app_url"`
 }
 
-var (
-	Config *configuration
-)
+type ServerConfig struct {
+	Addr        string   `yaml:"addr"`
+	CORSOrigins []string `yaml:"cors_origins"`
+	// BodyLimit ukuran maksimal body request dalam byte
+	BodyLimit int `yaml:"body_limit"`
+	// ShutdownDelay jeda antara readiness gagal dan server berhenti menerima koneksi,
+	// memberi waktu load balancer mengeluarkan instance
+	ShutdownDelay time.Duration `yaml:"shutdown_delay"`
+}
+
+type DatabaseConfig struct {
+	User            string        `yaml:"user"`
+	Password        string        `yaml:"password"`
+	Host            string        `yaml:"host"`
+	Port            int           `yaml:"port"`
+	Name            string        `yaml:"name"`
+	MaxConns        int32         `yaml:"max_conns"`
+	MinConns        int32         `yaml:"min_conns"`
+	MaxConnLifetime time.Duration `yaml:"max_conn_lifetime"`
+	MaxConnIdleTime time.Duration `yaml:"max_conn_idle_time"`
+}
+
+// URL connection string postgres
+func (d DatabaseConfig) URL() string {
+	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s", d.User, d.Password, d.Host, d.Port, d.Name)
+}
+
+type LogConfig struct {
+	Level  string `yaml:"level"`
+	Output string `yaml:"output"`
+}
+
+type AuthConfig struct {
+	// SecretKey kunci HS256 untuk token lama tanpa kid
+	SecretKey string `yaml:"secret_key"`
+	// KeyDir direktori kunci jwt RS256/EdDSA, dikelola melalui perintah `keys`
+	KeyDir              string        `yaml:"key_dir"`
+	AccessTokenTTL      time.Duration `yaml:"access_token_ttl"`
+	RefreshTokenTTL     time.Duration `yaml:"refresh_token_ttl"`
+	ImpersonateTokenTTL time.Duration `yaml:"impersonate_token_ttl"`
+	ChallengeTokenTTL   time.Duration `yaml:"challenge_token_ttl"`
+}
 
-func InitConfig() {
-	if err := godotenv.Load(); err != nil {
-		log.Fatal("File .env tidak ditemukan")
+type UploadConfig struct {
+	// MaxImageSize ukuran maksimal satu file gambar dalam byte
+	MaxImageSize int64 `yaml:"max_image_size"`
+}
+
+type StorageConfig struct {
+	// ImageDir direktori gambar product dan logo merchant yang disajikan pada /image
+	ImageDir string `yaml:"image_dir"`
+}
+
+// SuperUserConfig super user pertama, dibuat saat aplikasi berjalan apabila belum ada
+type SuperUserConfig struct {
+	Email    string `yaml:"email"`
+	Name     string `yaml:"name"`
+	Password string `yaml:"password"`
+}
+
+// MailConfig smtp, apabila Host kosong email hanya ditulis ke log
+type MailConfig struct {
+	Host     string `yaml:"host"`
+	Port     string `yaml:"port"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	From     string `yaml:"from"`
+	// Timeout batas waktu koneksi smtp sampai email terkirim
+	Timeout time.Duration `yaml:"timeout"`
+	// LogBody menulis isi email (berisi token aktif) ke log ketika Host kosong, hanya untuk development
+	LogBody bool `yaml:"log_body"`
+}
+
+// TracingConfig opentelemetry tracing, Exporter none mematikan tracing
+type TracingConfig struct {
+	// Exporter none, stdout atau otlp
+	Exporter string `yaml:"exporter"`
+	// Endpoint url OTLP/HTTP collector, contoh http://localhost:4318/v1/traces
+	Endpoint    string  `yaml:"endpoint"`
+	ServiceName string  `yaml:"service_name"`
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+// RateLimitConfig batas request per grup route memakai token bucket
+type RateLimitConfig struct {
+	// API seluruh /api/v1 per api key atau user, per ip apabila tanpa token
+	API RateLimitRule `yaml:"api"`
+	// Merchant gabungan seluruh user dan api key dalam satu merchant
+	Merchant RateLimitRule `yaml:"merchant"`
+	// Auth endpoint tanpa token seperti login, signup dan lupa password, per ip
+	Auth RateLimitRule `yaml:"auth"`
+}
+
+// RateLimitRule Requests token diisi ulang setiap Per dengan kapasitas Burst, Requests 0 mematikan batas
+type RateLimitRule struct {
+	Requests int           `yaml:"requests"`
+	Per      time.Duration `yaml:"per"`
+	Burst    int           `yaml:"burst"`
+}
+
+// Default nilai awal sebelum sumber lain dibaca
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Addr:          ":3500",
+			CORSOrigins:   []string{"*"},
+			BodyLimit:     4 * 1024 * 1024,
+			ShutdownDelay: 5 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Host:            "localhost",
+			Port:            5432,
+			MaxConns:        10,
+			MinConns:        0,
+			MaxConnLifetime: time.Hour,
+			MaxConnIdleTime: 30 * time.Minute,
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Output: "stdout",
+		},
+		Auth: AuthConfig{
+			KeyDir:              "keys",
+			AccessTokenTTL:      time.Hour,
+			RefreshTokenTTL:     15 * 24 * time.Hour,
+			ImpersonateTokenTTL: 15 * time.Minute,
+			ChallengeTokenTTL:   5 * time.Minute,
+		},
+		Upload: UploadConfig{
+			MaxImageSize: 2 * 1024 * 1024,
+		},
+		Storage: StorageConfig{
+			ImageDir: "static/image",
+		},
+		Mail: MailConfig{
+			Port:    "587",
+			Timeout: 10 * time.Second,
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			Endpoint:    "http://localhost:4318/v1/traces",
+			ServiceName: "mini_pos",
+			SampleRatio: 1,
+		},
+		Limit: RateLimitConfig{
+			API:      RateLimitRule{Requests: 300, Per: time.Minute, Burst: 60},
+			Merchant: RateLimitRule{Requests: 3000, Per: time.Minute, Burst: 300},
+			Auth:     RateLimitRule{Requests: 20, Per: time.Minute, Burst: 10},
+		},
+	}
+}
+
+// field satu nilai konfigurasi yang dapat diisi melalui environment dan flag
+type field struct {
+	env   string
+	usage string
+	ptr   interface{}
+}
+
+// flagName BA_DB_MAX_CONNS menjadi db-max-conns
+func (f field) flagName() string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimPrefix(f.env, "BA_")), "_", "-")
+}
+
+func (c *Config) fields() []field {
+	return []field{
+		{"BA_LISTEN_ADDR", "alamat listen http server", &c.Server.Addr},
+		{"BA_CORS_ORIGINS", "origin cors dipisah koma", &c.Server.CORSOrigins},
+		{"BA_BODY_LIMIT", "ukuran maksimal body request (byte)", &c.Server.BodyLimit},
+		{"BA_SHUTDOWN_DELAY", "jeda readiness gagal sebelum server berhenti", &c.Server.ShutdownDelay},
+		{"BA_DB_USER", "user database", &c.Database.User},
+		{"BA_DB_PASS", "password database", &c.Database.Password},
+		{"BA_DB_HOST", "host database", &c.Database.Host},
+		{"BA_DB_PORT", "port database", &c.Database.Port},
+		{"BA_DB_NAME", "nama database", &c.Database.Name},
+		{"BA_DB_MAX_CONNS", "jumlah maksimal koneksi pool", &c.Database.MaxConns},
+		{"BA_DB_MIN_CONNS", "jumlah minimal koneksi pool", &c.Database.MinConns},
+		{"BA_DB_MAX_CONN_LIFETIME", "umur maksimal koneksi pool", &c.Database.MaxConnLifetime},
+		{"BA_DB_MAX_CONN_IDLE_TIME", "waktu maksimal koneksi pool menganggur", &c.Database.MaxConnIdleTime},
+		{"BA_LOG_LEVEL", "level log debug|info|error", &c.Log.Level},
+		{"BA_LOG_OUTPUT", "tujuan log, stdout atau path file", &c.Log.Output},
+		{"BA_SECRET_KEY", "secret HS256 untuk token lama tanpa kid", &c.Auth.SecretKey},
+		{"BA_JWT_KEY_DIR", "direktori kunci jwt", &c.Auth.KeyDir},
+		{"BA_ACCESS_TOKEN_TTL", "masa berlaku access token", &c.Auth.AccessTokenTTL},
+		{"BA_REFRESH_TOKEN_TTL", "masa berlaku refresh token", &c.Auth.RefreshTokenTTL},
+		{"BA_IMPERSONATE_TOKEN_TTL", "masa berlaku token impersonate", &c.Auth.ImpersonateTokenTTL},
+		{"BA_CHALLENGE_TOKEN_TTL", "masa berlaku token challenge 2fa", &c.Auth.ChallengeTokenTTL},
+		{"BA_MAX_IMAGE_SIZE", "ukuran maksimal upload gambar (byte)", &c.Upload.MaxImageSize},
+		{"BA_IMAGE_DIR", "direktori penyimpanan gambar", &c.Storage.ImageDir},
+		{"BA_SUPER_EMAIL", "email super user pertama", &c.Super.Email},
+		{"BA_SUPER_NAME", "nama super user pertama", &c.Super.Name},
+		{"BA_SUPER_PASSWORD", "password super user pertama", &c.Super.Password},
+		{"BA_SMTP_HOST", "host smtp, kosong berarti email ditulis ke log", &c.Mail.Host},
+		{"BA_SMTP_PORT", "port smtp", &c.Mail.Port},
+		{"BA_SMTP_USER", "user smtp", &c.Mail.User},
+		{"BA_SMTP_PASS", "password smtp", &c.Mail.Password},
+		{"BA_SMTP_FROM", "alamat pengirim email", &c.Mail.From},
+		{"BA_SMTP_TIMEOUT", "batas waktu pengiriman email smtp", &c.Mail.Timeout},
+		{"BA_SMTP_LOG_BODY", "tulis isi email ke log apabila host smtp kosong, hanya untuk development", &c.Mail.LogBody},
+		{"BA_APP_URL", "url frontend untuk link pada email", &c.AppURL},
+		{"BA_TRACING_EXPORTER", "exporter tracing none|stdout|otlp", &c.Tracing.Exporter},
+		{"BA_TRACING_ENDPOINT", "url OTLP/HTTP collector", &c.Tracing.Endpoint},
+		{"BA_TRACING_SERVICE_NAME", "nama service pada trace", &c.Tracing.ServiceName},
+		{"BA_TRACING_SAMPLE_RATIO", "porsi request yang di trace 0 sampai 1", &c.Tracing.SampleRatio},
+		{"BA_RATE_LIMIT_API_REQUESTS", "jumlah request per user atau api key setiap periode, 0 tanpa batas", &c.Limit.API.Requests},
+		{"BA_RATE_LIMIT_API_PER", "periode rate limit per user atau api key", &c.Limit.API.Per},
+		{"BA_RATE_LIMIT_API_BURST", "kapasitas burst per user atau api key", &c.Limit.API.Burst},
+		{"BA_RATE_LIMIT_MERCHANT_REQUESTS", "jumlah request per merchant setiap periode, 0 tanpa batas", &c.Limit.Merchant.Requests},
+		{"BA_RATE_LIMIT_MERCHANT_PER", "periode rate limit per merchant", &c.Limit.Merchant.Per},
+		{"BA_RATE_LIMIT_MERCHANT_BURST", "kapasitas burst per merchant", &c.Limit.Merchant.Burst},
+		{"BA_RATE_LIMIT_AUTH_REQUESTS", "jumlah request login/signup per ip setiap periode, 0 tanpa batas", &c.Limit.Auth.Requests},
+		{"BA_RATE_LIMIT_AUTH_PER", "periode rate limit login/signup per ip", &c.Limit.Auth.Per},
+		{"BA_RATE_LIMIT_AUTH_BURST", "kapasitas burst login/signup per ip", &c.Limit.Auth.Burst},
+	}
+}
+
+// setValue mengisi ptr dari string sesuai tipenya
+func setValue(ptr interface{}, raw string) error {
+	raw = strings.TrimSpace(raw)
+	switch p := ptr.(type) {
+	case *string:
+		*p = raw
+	case *[]string:
+		var values []string
+		for _, v := range strings.Split(raw, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+		*p = values
+	case *int:
+		v, err := strconv.ParseInt(raw, 10, 0)
+		if err != nil {
+			return fmt.Errorf("%q bukan angka", raw)
+		}
+		*p = int(v)
+	case *int32:
+		v, err := strconv.ParseInt(raw, 10, 32)
+		if err != nil {
+			return fmt.Errorf("%q bukan angka", raw)
+		}
+		*p = int32(v)
+	case *int64:
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q bukan angka", raw)
+		}
+		*p = v
+	case *float64:
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("%q bukan angka", raw)
+		}
+		*p = v
+	case *bool:
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%q bukan true atau false", raw)
+		}
+		*p = v
+	case *time.Duration:
+		v, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("%q bukan durasi, contoh 15m atau 1h", raw)
+		}
+		*p = v
+	default:
+		return fmt.Errorf("tipe %T tidak didukung", ptr)
 	}
+	return nil
+}
 
-	Config = new(configuration)
+// Errors kumpulan error konfigurasi yang dilaporkan sekaligus
+type Errors []string
 
-	Config.DBUSER = os.Getenv("BA_DB_USER")
-	Config.DBPASS = os.Getenv("BA_DB_PASS")
-	Config.DBHOST = os.Getenv("BA_DB_HOST")
-	Config.DBPORT = os.Getenv("BA_DB_PORT")
-	Config.DBNAME = os.Getenv("BA_DB_NAME")
-	Config.LOGLEVEL = os.Getenv("BA_LOG_LEVEL")
-	Config.LOGLEVEL = os.Getenv("BA_LOG_OUTPUT")
-	Config.SECRETKEY = os.Getenv("BA_SECRET_KEY")
+func (e Errors) Error() string {
+	return "konfigurasi tidak valid:\n  - " + strings.Join(e, "\n  - ")
+}
+
+// flagValue menampung nilai flag, nilai baru diterapkan setelah file dan environment dibaca
+type flagValue struct {
+	raw *string
+}
+
+func (f flagValue) String() string {
+	if f.raw == nil {
+		return ""
+	}
+	return *f.raw
+}
+
+func (f flagValue) Set(s string) error {
+	*f.raw = s
+	return nil
+}
+
+// Load membaca konfigurasi dari args (flag sebelum nama perintah), file yaml dan environment.
+// Mengembalikan sisa argumen setelah flag, seluruh kesalahan dikembalikan sekaligus sebagai Errors
+func Load(args []string, lookupEnv func(string) (string, bool)) (*Config, []string, error) {
+	cfg := Default()
+	fields := cfg.fields()
+
+	fs := flag.NewFlagSet("mini_pos", flag.ContinueOnError)
+	configFile := fs.String("config", "", fmt.Sprintf("file konfigurasi yaml (default %s apabila ada, env %s)", DefaultConfigFile, envConfigFile))
+	flagRaw := make([]*string, len(fields))
+	for i, f := range fields {
+		flagRaw[i] = new(string)
+		fs.Var(flagValue{raw: flagRaw[i]}, f.flagName(), fmt.Sprintf("%s (env %s)", f.usage, f.env))
+	}
+	fs.SetOutput(ioutil.Discard)
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil, nil, err
+		}
+		return nil, nil, Errors{err.Error()}
+	}
+	setFlags := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { setFlags[f.Name] = true })
+
+	var errs Errors
+
+	// ----------------------------------------------------------------- file yaml
+	path, explicit := *configFile, *configFile != ""
+	if !explicit {
+		if env, ok := lookupEnv(envConfigFile); ok && env != "" {
+			path, explicit = env, true
+		} else {
+			path = DefaultConfigFile
+		}
+	}
+	content, err := ioutil.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := yaml.UnmarshalStrict(content, cfg); err != nil {
+			errs = append(errs, fmt.Sprintf("file %s: %s", path, err))
+		}
+	case !os.IsNotExist(err) || explicit:
+		errs = append(errs, fmt.Sprintf("file %s: %s", path, err))
+	}
+
+	// ----------------------------------------------------------------- environment
+	for _, f := range fields {
+		if raw, ok := lookupEnv(f.env); ok {
+			if err := setValue(f.ptr, raw); err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %s", f.env, err))
+			}
+		}
+	}
+
+	// ----------------------------------------------------------------- flag
+	for i, f := range fields {
+		if !setFlags[f.flagName()] {
+			continue
+		}
+		if err := setValue(f.ptr, *flagRaw[i]); err != nil {
+			errs = append(errs, fmt.Sprintf("-%s: %s", f.flagName(), err))
+		}
+	}
+
+	errs = append(errs, cfg.validate()...)
+	if len(errs) != 0 {
+		return nil, nil, errs
+	}
+	return cfg, fs.Args(), nil
+}
+
+// LoadFromOS membaca .env apabila ada lalu memanggil Load dengan environment proses.
+// Variabel yang sudah ada di environment tidak ditimpa oleh .env
+func LoadFromOS(args []string) (*Config, []string, error) {
+	if _, err := os.Stat(envFile); err == nil {
+		if err := godotenv.Load(envFile); err != nil {
+			return nil, nil, Errors{fmt.Sprintf("file %s: %s", envFile, err)}
+		}
+	}
+	return Load(args, os.LookupEnv)
+}
+
+// Usage daftar flag konfigurasi beserta environment yang sesuai
+func Usage() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("  -config string\n    \tfile konfigurasi yaml (default %s apabila ada, env %s)\n", DefaultConfigFile, envConfigFile))
+	for _, f := range Default().fields() {
+		b.WriteString(fmt.Sprintf("  -%s\n    \t%s (env %s)\n", f.flagName(), f.usage, f.env))
+	}
+	return b.String()
+}
+
+func (c *Config) validate() Errors {
+	var errs Errors
+	require := func(value string, name string) {
+		if strings.TrimSpace(value) == "" {
+			errs = append(errs, name+" wajib diisi")
+		}
+	}
+	positive := func(value int64, name string) {
+		if value <= 0 {
+			errs = append(errs, name+" harus lebih dari 0")
+		}
+	}
+
+	require(c.Server.Addr, "server.addr")
+	positive(int64(c.Server.BodyLimit), "server.body_limit")
+	if c.Server.ShutdownDelay < 0 {
+		errs = append(errs, "server.shutdown_delay tidak boleh negatif")
+	}
+
+	require(c.Database.User, "database.user")
+	require(c.Database.Host, "database.host")
+	require(c.Database.Name, "database.name")
+	if c.Database.Port < 1 || c.Database.Port > 65535 {
+		errs = append(errs, "database.port harus di antara 1 dan 65535")
+	}
+	positive(int64(c.Database.MaxConns), "database.max_conns")
+	if c.Database.MinConns < 0 || c.Database.MinConns > c.Database.MaxConns {
+		errs = append(errs, "database.min_conns harus di antara 0 dan database.max_conns")
+	}
+
+	switch strings.ToLower(c.Log.Level) {
+	case "debug", "info", "error":
+	default:
+		errs = append(errs, fmt.Sprintf("log.level %q tidak dikenal, gunakan debug, info atau error", c.Log.Level))
+	}
+	require(c.Log.Output, "log.output")
+
+	require(c.Auth.KeyDir, "auth.key_dir")
+	positive(int64(c.Auth.AccessTokenTTL), "auth.access_token_ttl")
+	positive(int64(c.Auth.RefreshTokenTTL), "auth.refresh_token_ttl")
+	positive(int64(c.Auth.ImpersonateTokenTTL), "auth.impersonate_token_ttl")
+	positive(int64(c.Auth.ChallengeTokenTTL), "auth.challenge_token_ttl")
+	if c.Auth.RefreshTokenTTL < c.Auth.AccessTokenTTL {
+		errs = append(errs, "auth.refresh_token_ttl tidak boleh lebih pendek dari auth.access_token_ttl")
+	}
+
+	positive(c.Upload.MaxImageSize, "upload.max_image_size")
+	if c.Upload.MaxImageSize > int64(c.Server.BodyLimit) {
+		errs = append(errs, "upload.max_image_size tidak boleh melebihi server.body_limit")
+	}
+	require(c.Storage.ImageDir, "storage.image_dir")
+
+	if (c.Super.Email == "") != (c.Super.Password == "") {
+		errs = append(errs, "super_user.email dan super_user.password harus diisi bersamaan")
+	}
+	// password super user mengikuti kebijakan password user biasa
+	if n := len([]rune(c.Super.Password)); c.Super.Password != "" && (n < dto.PasswordMinLength || n > dto.PasswordMaxLength) {
+		errs = append(errs, fmt.Sprintf("super_user.password harus %d sampai %d karakter", dto.PasswordMinLength, dto.PasswordMaxLength))
+	}
+	switch c.Tracing.Exporter {
+	case "none", "stdout":
+	case "otlp":
+		if u, err := url.Parse(c.Tracing.Endpoint); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, fmt.Sprintf("tracing.endpoint %q bukan url yang valid", c.Tracing.Endpoint))
+		}
+	default:
+		errs = append(errs, fmt.Sprintf("tracing.exporter %q tidak dikenal, gunakan none, stdout atau otlp", c.Tracing.Exporter))
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		errs = append(errs, "tracing.sample_ratio harus di antara 0 dan 1")
+	}
+
+	rateLimit := func(rule RateLimitRule, name string) {
+		if rule.Requests < 0 || rule.Burst < 0 {
+			errs = append(errs, fmt.Sprintf("rate_limit.%s.requests dan burst tidak boleh negatif", name))
+		}
+		if rule.Requests > 0 && rule.Per <= 0 {
+			errs = append(errs, fmt.Sprintf("rate_limit.%s.per harus lebih dari 0", name))
+		}
+	}
+	rateLimit(c.Limit.API, "api")
+	rateLimit(c.Limit.Merchant, "merchant")
+	rateLimit(c.Limit.Auth, "auth")
+
+	if c.Mail.Host != "" {
+		require(c.Mail.Port, "mail.port")
+		require(c.Mail.From, "mail.from")
+		if c.Mail.Timeout <= 0 {
+			errs = append(errs, "mail.timeout harus lebih dari 0")
+		}
+		if c.Mail.LogBody {
+			errs = append(errs, "mail.log_body hanya dapat dipakai tanpa mail.host (development)")
+		}
+	}
+	return errs
 }
@@ -0,0 +1,80 @@
+package configs
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func envMap(values map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := values[key]
+		return v, ok
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	err := ioutil.WriteFile(file, []byte(`
+server:
+  addr: ":8000"
+database:
+  user: file_user
+  name: minipos
+  max_conns: 20
+auth:
+  access_token_ttl: 30m
+log:
+  level: debug
+`), 0o600)
+	assert.Nil(t, err)
+
+	cfg, rest, err := Load(
+		[]string{"-config", file, "-log-level", "error", "migrate", "up"},
+		envMap(map[string]string{"BA_DB_USER": "env_user", "BA_LOG_LEVEL": "info", "BA_CORS_ORIGINS": "https://a.id, https://b.id"}),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"migrate", "up"}, rest)
+	assert.Equal(t, ":8000", cfg.Server.Addr)
+	assert.Equal(t, "env_user", cfg.Database.User)
+	assert.Equal(t, int32(20), cfg.Database.MaxConns)
+	assert.Equal(t, 5432, cfg.Database.Port)
+	assert.Equal(t, 30*time.Minute, cfg.Auth.AccessTokenTTL)
+	assert.Equal(t, "error", cfg.Log.Level)
+	assert.Equal(t, []string{"https://a.id", "https://b.id"}, cfg.Server.CORSOrigins)
+}
+
+func TestLoadReportsAllErrors(t *testing.T) {
+	_, _, err := Load(
+		[]string{"-config", filepath.Join(t.TempDir(), "missing.yaml")},
+		envMap(map[string]string{"BA_DB_PORT": "abc", "BA_ACCESS_TOKEN_TTL": "1 jam", "BA_LOG_LEVEL": "verbose"}),
+	)
+	errs, ok := err.(Errors)
+	assert.True(t, ok)
+	// file tidak ada, port, ttl, log level, database.user dan database.name
+	assert.Equal(t, 6, len(errs))
+}
+
+func TestSuperPasswordFollowsPasswordPolicy(t *testing.T) {
+	base := map[string]string{"BA_DB_USER": "postgres", "BA_DB_NAME": "minipos", "BA_SUPER_EMAIL": "super@example.com"}
+	load := func(password string) error {
+		env := map[string]string{"BA_SUPER_PASSWORD": password}
+		for k, v := range base {
+			env[k] = v
+		}
+		_, _, err := Load(nil, envMap(env))
+		return err
+	}
+
+	assert.Nil(t, load("rahasia"))
+
+	for _, password := range []string{"ab", "passwordyangterlalupanjang"} {
+		errs, ok := load(password).(Errors)
+		assert.True(t, ok)
+		assert.Equal(t, 1, len(errs))
+		assert.Contains(t, errs[0], "super_user.password")
+	}
+}
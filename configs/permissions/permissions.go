@@ -0,0 +1,49 @@
+package permissions
+
+const (
+	// All dimiliki role owner bawaan, berarti seluruh permission termasuk yang ditambahkan kemudian
+	All = "*"
+
+	SettingEdit   = "setting.edit"
+	UsageView     = "usage.view"
+	UserManage    = "user.manage"
+	RoleManage    = "role.manage"
+	DeviceManage  = "device.manage"
+	OutletManage  = "outlet.manage"
+	ProductCreate = "product.create"
+	ProductEdit   = "product.edit"
+	ProductDelete = "product.delete"
+	PriceSet      = "price.set"
+	TaxManage     = "tax.manage"
+	APIKeyManage  = "apikey.manage"
+	AuditView     = "audit.view"
+)
+
+// GetPermissionsAvailable permission yang dapat dipilih ketika membuat custom role
+func GetPermissionsAvailable() []string {
+	return []string{
+		SettingEdit,
+		UsageView,
+		UserManage,
+		RoleManage,
+		DeviceManage,
+		OutletManage,
+		ProductCreate,
+		ProductEdit,
+		ProductDelete,
+		PriceSet,
+		TaxManage,
+		APIKeyManage,
+		AuditView,
+	}
+}
+
+// Has return true jika daftar granted memuat permission yang diminta atau All
+func Has(granted []string, permission string) bool {
+	for _, p := range granted {
+		if p == All || p == permission {
+			return true
+		}
+	}
+	return false
+}
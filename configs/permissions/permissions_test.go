@@ -0,0 +1,14 @@
+package permissions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHas(t *testing.T) {
+	assert.True(t, Has([]string{All}, ProductEdit))
+	assert.True(t, Has([]string{ProductEdit, PriceSet}, PriceSet))
+	assert.False(t, Has([]string{ProductEdit}, ProductDelete))
+	assert.False(t, Has(nil, ProductEdit))
+}
@@ -1,11 +1,14 @@
 package roles
 
 const (
+	RoleSuper    = "super" // pengelola instance, tidak terikat dengan merchant manapun
 	RoleOwner    = "owner"
 	RoleEmployee = "employee"
 	RoleCustomer = "customer"
 )
 
+// GetRolesAvailable role yang dapat diberikan kepada user dibawah merchant,
+// role super tidak termasuk karena hanya dapat dibuat melalui bootstrap
 func GetRolesAvailable() []string {
 	return []string{RoleOwner, RoleEmployee, RoleCustomer}
 }
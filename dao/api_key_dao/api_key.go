@@ -0,0 +1,224 @@
+package api_key_dao
+
+import (
+	"context"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/muchlist/mini_pos/dao"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/audit"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/utils/sql_err"
+)
+
+const (
+	keyAPIKeyTable = "api_keys"
+	keyID          = "id"
+	keyMerchantID  = "merchant_id"
+	keyOutletID    = "outlet_id"
+	keyName        = "name"
+	keyPrefix      = "prefix"
+	keySecretHash  = "secret_hash"
+	keyScopes      = "scopes"
+	keyCreatedBy   = "created_by"
+	keyCreatedAt   = "created_at"
+	keyExpiredAt   = "expired_at"
+	keyLastUsedAt  = "last_used_at"
+	keyRevokedAt   = "revoked_at"
+
+	// lastUsedInterval last_used_at hanya diperbarui setiap interval (detik) agar setiap request
+	// tidak selalu menulis ke database
+	lastUsedInterval = 60
+)
+
+type apiKeyDao struct {
+	db *dao.Pool
+	sb squirrel.StatementBuilderType
+}
+
+func New(db *pgxpool.Pool) APIKeyDaoAssumer {
+	return &apiKeyDao{
+		db: dao.NewPool(db),
+		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (a *apiKeyDao) Insert(ctx context.Context, input dto.APIKeyModel) (int, rest_err.APIError) {
+	trx, err := a.db.Begin(ctx)
+	if err != nil {
+		return 0, rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	// ------------------------------------------------------------- insert api key
+	sqlStatement, args, err := a.sb.Insert(keyAPIKeyTable).
+		Columns(keyMerchantID, keyOutletID, keyName, keyPrefix, keySecretHash, keyScopes, keyCreatedBy, keyCreatedAt, keyExpiredAt).
+		Values(input.MerchantID, input.OutletID, input.Name, input.Prefix, input.SecretHash, input.Scopes, input.CreatedBy, input.CreatedAt, input.ExpiredAt).
+		Suffix(dao.Returning(keyID)).
+		ToSql()
+	if err != nil {
+		return 0, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var createdID int
+	err = trx.QueryRow(ctx, sqlStatement, args...).Scan(&createdID)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat queryRow api key (Insert:0)", err)
+		return 0, sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- audit
+	input.ID = createdID
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		MerchantID: input.MerchantID,
+		EntityType: audit.EntityAPIKey,
+		EntityID:   createdID,
+		Action:     audit.ActionCreate,
+		After:      input,
+	}); err != nil {
+		return 0, err
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return 0, rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
+	return createdID, nil
+}
+
+// Revoke mencabut api key, kunci yang sudah dicabut langsung ditolak pada request berikutnya
+func (a *apiKeyDao) Revoke(ctx context.Context, id int, filterMerchant int, revokedAt int64) rest_err.APIError {
+	trx, err := a.db.Begin(ctx)
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	// ------------------------------------------------------------- cabut api key
+	sqlStatement, args, err := a.sb.Update(keyAPIKeyTable).
+		Set(keyRevokedAt, revokedAt).
+		Where(squirrel.And{
+			squirrel.Eq{keyID: id},
+			squirrel.Eq{keyMerchantID: filterMerchant},
+			squirrel.Eq{keyRevokedAt: 0},
+		}).
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	res, err := trx.Exec(ctx, sqlStatement, args...)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat trx exec api key (Revoke:0)", err)
+		return sql_err.ParseError(err)
+	}
+	if res.RowsAffected() == 0 {
+		return rest_err.NewBadRequestError(fmt.Sprintf("Api key dengan id %d tidak ditemukan", id))
+	}
+
+	// ------------------------------------------------------------- audit
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		MerchantID: filterMerchant,
+		EntityType: audit.EntityAPIKey,
+		EntityID:   id,
+		Action:     audit.ActionRevoke,
+		Before:     map[string]int64{keyRevokedAt: 0},
+		After:      map[string]int64{keyRevokedAt: revokedAt},
+	}); err != nil {
+		return err
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
+	return nil
+}
+
+func (a *apiKeyDao) SetLastUsed(ctx context.Context, id int, lastUsedAt int64) rest_err.APIError {
+	sqlStatement, args, err := a.sb.Update(keyAPIKeyTable).
+		Set(keyLastUsedAt, lastUsedAt).
+		Where(squirrel.And{
+			squirrel.Eq{keyID: id},
+			squirrel.Lt{keyLastUsedAt: lastUsedAt - lastUsedInterval},
+		}).
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	if _, err := a.db.Exec(ctx, sqlStatement, args...); err != nil {
+		logger.ErrorCtx(ctx, "error saat exec api key (SetLastUsed:0)", err)
+		return sql_err.ParseError(err)
+	}
+
+	return nil
+}
+
+// GetByPrefix mendapatkan api key yang belum dicabut berdasarkan prefix, pengecekan secret dan
+// masa berlaku dilakukan oleh service
+func (a *apiKeyDao) GetByPrefix(ctx context.Context, prefix string) (*dto.APIKeyModel, rest_err.APIError) {
+	sqlStatement, args, err := a.sb.Select(keyID, keyMerchantID, keyOutletID, keyName, keyPrefix, keySecretHash, keyScopes, keyCreatedBy, keyCreatedAt, keyExpiredAt, keyLastUsedAt, keyRevokedAt).
+		From(keyAPIKeyTable).
+		Where(squirrel.And{
+			squirrel.Eq{keyPrefix: prefix},
+			squirrel.Eq{keyRevokedAt: 0},
+		}).
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var key dto.APIKeyModel
+	err = a.db.QueryRow(ctx, sqlStatement, args...).
+		Scan(&key.ID, &key.MerchantID, &key.OutletID, &key.Name, &key.Prefix, &key.SecretHash, &key.Scopes, &key.CreatedBy, &key.CreatedAt, &key.ExpiredAt, &key.LastUsedAt, &key.RevokedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, rest_err.NewUnauthorizedError("Api key tidak valid atau sudah dicabut")
+		}
+		logger.ErrorCtx(ctx, "error saat queryRow api key (GetByPrefix:0)", err)
+		return nil, sql_err.ParseError(err)
+	}
+
+	return &key, nil
+}
+
+// Find menampilkan seluruh api key milik merchant termasuk yang sudah dicabut
+func (a *apiKeyDao) Find(ctx context.Context, merchantID int) ([]dto.APIKeyModel, rest_err.APIError) {
+	sqlStatement, args, err := a.sb.Select(keyID, keyMerchantID, keyOutletID, keyName, keyPrefix, keyScopes, keyCreatedBy, keyCreatedAt, keyExpiredAt, keyLastUsedAt, keyRevokedAt).
+		From(keyAPIKeyTable).
+		Where(squirrel.Eq{keyMerchantID: merchantID}).
+		OrderBy(keyID + " DESC").
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	rows, err := a.db.Query(ctx, sqlStatement, args...)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat query api key (Find:0)", err)
+		return nil, rest_err.NewInternalServerError("gagal mendapatkan daftar api key", err)
+	}
+	defer rows.Close()
+
+	keys := make([]dto.APIKeyModel, 0)
+	for rows.Next() {
+		key := dto.APIKeyModel{}
+		err := rows.Scan(&key.ID, &key.MerchantID, &key.OutletID, &key.Name, &key.Prefix, &key.Scopes, &key.CreatedBy, &key.CreatedAt, &key.ExpiredAt, &key.LastUsedAt, &key.RevokedAt)
+		if err != nil {
+			return nil, sql_err.ParseError(err)
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
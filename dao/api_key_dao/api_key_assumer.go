@@ -0,0 +1,23 @@
+package api_key_dao
+
+import (
+	"context"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+)
+
+type APIKeyDaoAssumer interface {
+	APIKeySaver
+	APIKeyLoader
+}
+
+type APIKeySaver interface {
+	Insert(ctx context.Context, input dto.APIKeyModel) (int, rest_err.APIError)
+	Revoke(ctx context.Context, id int, filterMerchant int, revokedAt int64) rest_err.APIError
+	SetLastUsed(ctx context.Context, id int, lastUsedAt int64) rest_err.APIError
+}
+
+type APIKeyLoader interface {
+	GetByPrefix(ctx context.Context, prefix string) (*dto.APIKeyModel, rest_err.APIError)
+	Find(ctx context.Context, merchantID int) ([]dto.APIKeyModel, rest_err.APIError)
+}
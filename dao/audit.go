@@ -0,0 +1,79 @@
+package dao
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"github.com/muchlist/mini_pos/utils/audit"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/utils/sql_err"
+	"time"
+)
+
+const (
+	keyAuditTable       = "audit_logs"
+	keyAuditMerchantID  = "merchant_id"
+	keyAuditActorID     = "actor_id"
+	keyAuditImpersonate = "impersonator_id"
+	keyAuditAPIKeyID    = "api_key_id"
+	keyAuditEntityType  = "entity_type"
+	keyAuditEntityID    = "entity_id"
+	keyAuditAction      = "action"
+	keyAuditChanges     = "changes"
+	keyAuditIP          = "ip"
+	keyAuditRequestID   = "request_id"
+	keyAuditCreatedAt   = "created_at"
+)
+
+var auditBuilder = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+// AuditEntry perubahan entitas yang dicatat, Before nil pada create dan After nil pada delete.
+// MerchantID 0 akan diisi merchant pelaku
+type AuditEntry struct {
+	MerchantID int
+	EntityType string
+	EntityID   int
+	Action     string
+	Before     interface{}
+	After      interface{}
+}
+
+// WriteAudit mencatat perubahan pada transaksi yang sama dengan perubahan tersebut sehingga catatan
+// hanya tersimpan apabila perubahan berhasil di commit. Pelaku, ip dan request id dibaca dari ctx.
+// Edit tanpa perubahan field tidak dicatat
+func WriteAudit(ctx context.Context, trx pgx.Tx, entry AuditEntry) rest_err.APIError {
+	changes, err := audit.Diff(entry.Before, entry.After)
+	if err != nil {
+		return rest_err.NewInternalServerError("gagal membuat diff audit", err)
+	}
+	if entry.Action == audit.ActionEdit && len(changes) == 0 {
+		return nil
+	}
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return rest_err.NewInternalServerError("gagal membuat diff audit", err)
+	}
+
+	actor := audit.ActorFromContext(ctx)
+	if entry.MerchantID == 0 {
+		entry.MerchantID = actor.Merchant
+	}
+
+	sqlStatement, args, err := auditBuilder.Insert(keyAuditTable).
+		Columns(keyAuditMerchantID, keyAuditActorID, keyAuditImpersonate, keyAuditAPIKeyID, keyAuditEntityType,
+			keyAuditEntityID, keyAuditAction, keyAuditChanges, keyAuditIP, keyAuditRequestID, keyAuditCreatedAt).
+		Values(entry.MerchantID, actor.UserID, actor.Impersonator, actor.APIKey, entry.EntityType,
+			entry.EntityID, entry.Action, string(changesJSON), actor.IP, actor.RequestID, time.Now().Unix()).
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(ErrSqlBuilder, err)
+	}
+
+	if _, err := trx.Exec(ctx, sqlStatement, args...); err != nil {
+		logger.ErrorCtx(ctx, "error saat trx exec audit log (WriteAudit:0)", err)
+		return sql_err.ParseError(err)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package audit_dao
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/muchlist/mini_pos/dao"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/utils/sql_err"
+)
+
+const (
+	keyAuditTable     = "audit_logs"
+	keyID             = "id"
+	keyMerchantID     = "merchant_id"
+	keyActorID        = "actor_id"
+	keyImpersonatorID = "impersonator_id"
+	keyAPIKeyID       = "api_key_id"
+	keyEntityType     = "entity_type"
+	keyEntityID       = "entity_id"
+	keyAction         = "action"
+	keyChanges        = "changes"
+	keyIP             = "ip"
+	keyRequestID      = "request_id"
+	keyCreatedAt      = "created_at"
+)
+
+type auditDao struct {
+	db *dao.Pool
+	sb squirrel.StatementBuilderType
+}
+
+func New(db *pgxpool.Pool) AuditDaoAssumer {
+	return &auditDao{
+		db: dao.NewPool(db),
+		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// FindParams filter bernilai kosong atau 0 diabaikan, From dan To dalam unix second
+type FindParams struct {
+	MerchantID int
+	EntityType string
+	EntityID   int
+	ActorID    int
+	Action     string
+	From       int64
+	To         int64
+	Limit      int
+	Offset     int
+}
+
+// FindWithPagination example : ?entity_type=product&entity_id=1&actor=2&action=edit&from=1631341964&limit=10&offset=10
+func (a *auditDao) FindWithPagination(ctx context.Context, opt FindParams) ([]dto.AuditLogModel, rest_err.APIError) {
+	where := squirrel.And{squirrel.Eq{keyMerchantID: opt.MerchantID}}
+	if opt.EntityType != "" {
+		where = append(where, squirrel.Eq{keyEntityType: opt.EntityType})
+	}
+	if opt.EntityID != 0 {
+		where = append(where, squirrel.Eq{keyEntityID: opt.EntityID})
+	}
+	if opt.ActorID != 0 {
+		where = append(where, squirrel.Eq{keyActorID: opt.ActorID})
+	}
+	if opt.Action != "" {
+		where = append(where, squirrel.Eq{keyAction: opt.Action})
+	}
+	if opt.From != 0 {
+		where = append(where, squirrel.GtOrEq{keyCreatedAt: opt.From})
+	}
+	if opt.To != 0 {
+		where = append(where, squirrel.LtOrEq{keyCreatedAt: opt.To})
+	}
+
+	sqlStatement, args, err := a.sb.Select(
+		keyID,
+		keyMerchantID,
+		keyActorID,
+		keyImpersonatorID,
+		keyAPIKeyID,
+		keyEntityType,
+		keyEntityID,
+		keyAction,
+		keyChanges,
+		keyIP,
+		keyRequestID,
+		keyCreatedAt).
+		From(keyAuditTable).
+		Where(where).
+		OrderBy(keyID + " DESC").
+		Limit(uint64(opt.Limit)).
+		Offset(uint64(opt.Offset)).
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	rows, err := a.db.Query(ctx, sqlStatement, args...)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat query audit log(FindWithPagination:0)", err)
+		return nil, rest_err.NewInternalServerError("gagal mendapatkan daftar audit log", err)
+	}
+	defer rows.Close()
+
+	auditLogs := make([]dto.AuditLogModel, 0)
+	for rows.Next() {
+		auditLog := dto.AuditLogModel{}
+		var changes []byte
+		err := rows.Scan(
+			&auditLog.ID,
+			&auditLog.MerchantID,
+			&auditLog.ActorID,
+			&auditLog.ImpersonatorID,
+			&auditLog.APIKeyID,
+			&auditLog.EntityType,
+			&auditLog.EntityID,
+			&auditLog.Action,
+			&changes,
+			&auditLog.IP,
+			&auditLog.RequestID,
+			&auditLog.CreatedAt)
+		if err != nil {
+			logger.ErrorCtx(ctx, "error saat parsing audit log(FindWithPagination:1)", err)
+			return nil, sql_err.ParseError(err)
+		}
+		auditLog.Changes = json.RawMessage(changes)
+		auditLogs = append(auditLogs, auditLog)
+	}
+
+	return auditLogs, nil
+}
@@ -0,0 +1,17 @@
+package audit_dao
+
+import (
+	"context"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+)
+
+// AuditDaoAssumer audit log hanya dibaca melalui dao ini, penulisan dilakukan dao.WriteAudit
+// pada transaksi perubahan entitas
+type AuditDaoAssumer interface {
+	AuditLoader
+}
+
+type AuditLoader interface {
+	FindWithPagination(ctx context.Context, opt FindParams) ([]dto.AuditLogModel, rest_err.APIError)
+}
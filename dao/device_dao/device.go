@@ -0,0 +1,287 @@
+package device_dao
+
+import (
+	"context"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/muchlist/mini_pos/configs/roles"
+	"github.com/muchlist/mini_pos/dao"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/audit"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/utils/sql_err"
+	"time"
+)
+
+const (
+	keyDeviceTable = "devices"
+	keyID          = "id"
+	keyMerchantID  = "merchant_id"
+	keyOutletID    = "outlet_id"
+	keyName        = "name"
+	keyTokenHash   = "token_hash"
+	keyCreatedBy   = "created_by"
+	keyCreatedAt   = "created_at"
+	keyLastUsedAt  = "last_used_at"
+	keyRevokedAt   = "revoked_at"
+
+	keySessionTable    = "sessions"
+	keySessionDeviceID = "device_id"
+
+	keyUserTable      = "users"
+	keyUserID         = "id"
+	keyUserName       = "name"
+	keyUserMerchantID = "merchant_id"
+	keyUserRole       = "role"
+	keyUserPin        = "pin"
+
+	keyUserOutletTable    = "user_outlets"
+	keyUserOutletUserID   = "user_id"
+	keyUserOutletOutletID = "outlet_id"
+)
+
+type deviceDao struct {
+	db *dao.Pool
+	sb squirrel.StatementBuilderType
+}
+
+func New(db *pgxpool.Pool) DeviceDaoAssumer {
+	return &deviceDao{
+		db: dao.NewPool(db),
+		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (d *deviceDao) Insert(ctx context.Context, input dto.DeviceModel) (int, rest_err.APIError) {
+	trx, err := d.db.Begin(ctx)
+	if err != nil {
+		return 0, rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	// ------------------------------------------------------------- insert device
+	sqlStatement, args, err := d.sb.Insert(keyDeviceTable).
+		Columns(keyMerchantID, keyOutletID, keyName, keyTokenHash, keyCreatedBy, keyCreatedAt).
+		Values(input.MerchantID, input.OutletID, input.Name, input.TokenHash, input.CreatedBy, input.CreatedAt).
+		Suffix(dao.Returning(keyID)).
+		ToSql()
+	if err != nil {
+		return 0, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var createdID int
+	err = trx.QueryRow(ctx, sqlStatement, args...).Scan(&createdID)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat queryRow device (Insert:0)", err)
+		return 0, sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- audit
+	input.ID = createdID
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		MerchantID: input.MerchantID,
+		EntityType: audit.EntityDevice,
+		EntityID:   createdID,
+		Action:     audit.ActionCreate,
+		After:      input,
+	}); err != nil {
+		return 0, err
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return 0, rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
+	return createdID, nil
+}
+
+// Revoke mencabut perangkat beserta seluruh sesi login PIN yang dibuat melalui perangkat tersebut
+func (d *deviceDao) Revoke(ctx context.Context, id int, filterMerchant int) rest_err.APIError {
+	trx, err := d.db.Begin(ctx)
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	timeNow := time.Now().Unix()
+
+	// ------------------------------------------------------------- cabut device
+	sqlStatement, args, err := d.sb.Update(keyDeviceTable).
+		Set(keyRevokedAt, timeNow).
+		Where(squirrel.And{
+			squirrel.Eq{keyID: id},
+			squirrel.Eq{keyMerchantID: filterMerchant},
+			squirrel.Eq{keyRevokedAt: 0},
+		}).
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	res, err := trx.Exec(ctx, sqlStatement, args...)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat trx exec device (Revoke:0)", err)
+		return sql_err.ParseError(err)
+	}
+	if res.RowsAffected() == 0 {
+		return rest_err.NewBadRequestError(fmt.Sprintf("Device dengan id %d tidak ditemukan", id))
+	}
+
+	// ------------------------------------------------------------- audit
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		MerchantID: filterMerchant,
+		EntityType: audit.EntityDevice,
+		EntityID:   id,
+		Action:     audit.ActionRevoke,
+		Before:     map[string]int64{keyRevokedAt: 0},
+		After:      map[string]int64{keyRevokedAt: timeNow},
+	}); err != nil {
+		return err
+	}
+
+	// ------------------------------------------------------------- akhiri sesi dari device
+	sqlStatement, args, err = d.sb.Update(keySessionTable).
+		Set(keyRevokedAt, timeNow).
+		Where(squirrel.And{
+			squirrel.Eq{keySessionDeviceID: id},
+			squirrel.Eq{keyRevokedAt: 0},
+		}).
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	if _, err := trx.Exec(ctx, sqlStatement, args...); err != nil {
+		logger.ErrorCtx(ctx, "error saat trx exec session (Revoke:1)", err)
+		return sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
+	return nil
+}
+
+func (d *deviceDao) SetLastUsed(ctx context.Context, id int, lastUsedAt int64) rest_err.APIError {
+	sqlStatement, args, err := d.sb.Update(keyDeviceTable).
+		Set(keyLastUsedAt, lastUsedAt).
+		Where(squirrel.Eq{keyID: id}).
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	if _, err := d.db.Exec(ctx, sqlStatement, args...); err != nil {
+		logger.ErrorCtx(ctx, "error saat exec device (SetLastUsed:0)", err)
+		return sql_err.ParseError(err)
+	}
+
+	return nil
+}
+
+// GetByTokenHash mendapatkan device aktif berdasarkan hash device token
+func (d *deviceDao) GetByTokenHash(ctx context.Context, tokenHash string) (*dto.DeviceModel, rest_err.APIError) {
+	sqlStatement, args, err := d.sb.Select(keyID, keyMerchantID, keyOutletID, keyName, keyCreatedBy, keyCreatedAt, keyLastUsedAt).
+		From(keyDeviceTable).
+		Where(squirrel.And{
+			squirrel.Eq{keyTokenHash: tokenHash},
+			squirrel.Eq{keyRevokedAt: 0},
+		}).
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var device dto.DeviceModel
+	err = d.db.QueryRow(ctx, sqlStatement, args...).
+		Scan(&device.ID, &device.MerchantID, &device.OutletID, &device.Name, &device.CreatedBy, &device.CreatedAt, &device.LastUsedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, rest_err.NewUnauthorizedError("Device tidak terdaftar atau sudah dicabut")
+		}
+		logger.ErrorCtx(ctx, "error saat queryRow device (GetByTokenHash:0)", err)
+		return nil, sql_err.ParseError(err)
+	}
+
+	return &device, nil
+}
+
+// Find menampilkan device aktif milik merchant
+func (d *deviceDao) Find(ctx context.Context, merchantID int) ([]dto.DeviceModel, rest_err.APIError) {
+	sqlStatement, args, err := d.sb.Select(keyID, keyMerchantID, keyOutletID, keyName, keyCreatedBy, keyCreatedAt, keyLastUsedAt).
+		From(keyDeviceTable).
+		Where(squirrel.And{
+			squirrel.Eq{keyMerchantID: merchantID},
+			squirrel.Eq{keyRevokedAt: 0},
+		}).
+		OrderBy(keyID + " ASC").
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	rows, err := d.db.Query(ctx, sqlStatement, args...)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat query device (Find:0)", err)
+		return nil, rest_err.NewInternalServerError("gagal mendapatkan daftar device", err)
+	}
+	defer rows.Close()
+
+	devices := make([]dto.DeviceModel, 0)
+	for rows.Next() {
+		device := dto.DeviceModel{}
+		err := rows.Scan(&device.ID, &device.MerchantID, &device.OutletID, &device.Name, &device.CreatedBy, &device.CreatedAt, &device.LastUsedAt)
+		if err != nil {
+			return nil, sql_err.ParseError(err)
+		}
+		devices = append(devices, device)
+	}
+
+	return devices, nil
+}
+
+// FindUsers menampilkan employee yang ditugaskan pada outlet dan sudah mengatur PIN
+func (d *deviceDao) FindUsers(ctx context.Context, merchantID int, outletID int) ([]dto.DeviceUserModel, rest_err.APIError) {
+	sqlStatement, args, err := d.sb.Select(dao.A(keyUserID), dao.A(keyUserName)).
+		From(keyUserTable + " A").
+		Join(fmt.Sprintf("%s B ON %s = %s", keyUserOutletTable, dao.B(keyUserOutletUserID), dao.A(keyUserID))).
+		Where(squirrel.And{
+			squirrel.Eq{dao.A(keyUserMerchantID): merchantID},
+			squirrel.Eq{dao.B(keyUserOutletOutletID): outletID},
+			squirrel.Eq{dao.A(keyUserRole): roles.RoleEmployee},
+			squirrel.NotEq{dao.A(keyUserPin): ""},
+		}).
+		OrderBy(dao.A(keyUserName) + " ASC").
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	rows, err := d.db.Query(ctx, sqlStatement, args...)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat query users (FindUsers:0)", err)
+		return nil, rest_err.NewInternalServerError("gagal mendapatkan daftar user device", err)
+	}
+	defer rows.Close()
+
+	users := make([]dto.DeviceUserModel, 0)
+	for rows.Next() {
+		user := dto.DeviceUserModel{}
+		if err := rows.Scan(&user.ID, &user.Name); err != nil {
+			return nil, sql_err.ParseError(err)
+		}
+		users = append(users, user)
+	}
+
+	return users, nil
+}
@@ -0,0 +1,24 @@
+package device_dao
+
+import (
+	"context"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+)
+
+type DeviceDaoAssumer interface {
+	DeviceSaver
+	DeviceLoader
+}
+
+type DeviceSaver interface {
+	Insert(ctx context.Context, input dto.DeviceModel) (int, rest_err.APIError)
+	Revoke(ctx context.Context, id int, filterMerchant int) rest_err.APIError
+	SetLastUsed(ctx context.Context, id int, lastUsedAt int64) rest_err.APIError
+}
+
+type DeviceLoader interface {
+	GetByTokenHash(ctx context.Context, tokenHash string) (*dto.DeviceModel, rest_err.APIError)
+	Find(ctx context.Context, merchantID int) ([]dto.DeviceModel, rest_err.APIError)
+	FindUsers(ctx context.Context, merchantID int, outletID int) ([]dto.DeviceUserModel, rest_err.APIError)
+}
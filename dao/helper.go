@@ -9,6 +9,7 @@ const (
 	ErrSqlBuilder = "kesalahan pada sql builder"
 	ErrRollback   = "gagal ketika roleback"
 	ErrCommit     = "gagal ketika commit"
+	ErrBeginTrx   = "gagal memulai transaksi"
 )
 
 // Returning return for helping build query
@@ -23,6 +24,17 @@ func Returning(columns ...string) string {
 	return strings.TrimSuffix(sb.String(), ", ")
 }
 
+// ExcludedSet return for helping build upsert query
+// input : name, updated_at
+// output : "name = EXCLUDED.name, updated_at = EXCLUDED.updated_at"
+func ExcludedSet(columns ...string) string {
+	sb := strings.Builder{}
+	for _, key := range columns {
+		sb.WriteString(fmt.Sprintf("%s = EXCLUDED.%s, ", key, key))
+	}
+	return strings.TrimSuffix(sb.String(), ", ")
+}
+
 // A return A.text for helping join query
 // input : updated_at
 // output : "A.updated_at"
@@ -51,6 +63,14 @@ func Dot(table, column string) string {
 	return fmt.Sprintf("%s.%s", table, column)
 }
 
+// NullIfZero return nil untuk nilai 0 agar tersimpan sebagai NULL pada kolom foreign key
+func NullIfZero(value int) interface{} {
+	if value == 0 {
+		return nil
+	}
+	return value
+}
+
 // CoalesceInt Coalesce(null,default)
 func CoalesceInt(text string, def int) string {
 	return fmt.Sprintf("Coalesce(%s,%d)", text, def)
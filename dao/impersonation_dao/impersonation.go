@@ -0,0 +1,99 @@
+package impersonation_dao
+
+import (
+	"context"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/muchlist/mini_pos/dao"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/utils/sql_err"
+)
+
+const (
+	keyImpersonationTable = "impersonations"
+	keyID                 = "id"
+	keySuperID            = "super_id"
+	keyMerchantID         = "merchant_id"
+	keyOwnerID            = "owner_id"
+	keyReason             = "reason"
+	keyCreatedAt          = "created_at"
+	keyExpiredAt          = "expired_at"
+)
+
+type impersonationDao struct {
+	db *dao.Pool
+	sb squirrel.StatementBuilderType
+}
+
+func New(db *pgxpool.Pool) ImpersonationDaoAssumer {
+	return &impersonationDao{
+		db: dao.NewPool(db),
+		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (i *impersonationDao) Insert(ctx context.Context, input dto.ImpersonationModel) (int, rest_err.APIError) {
+	sqlStatement, args, err := i.sb.Insert(keyImpersonationTable).
+		Columns(keySuperID, keyMerchantID, keyOwnerID, keyReason, keyCreatedAt, keyExpiredAt).
+		Values(input.SuperID, input.MerchantID, input.OwnerID, input.Reason, input.CreatedAt, input.ExpiredAt).
+		Suffix(dao.Returning(keyID)).
+		ToSql()
+	if err != nil {
+		return 0, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var createdID int
+	err = i.db.QueryRow(ctx, sqlStatement, args...).Scan(&createdID)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat queryRow impersonation (Insert:0)", err)
+		return 0, sql_err.ParseError(err)
+	}
+
+	return createdID, nil
+}
+
+type FindParams struct {
+	MerchantID int // 0 berarti semua merchant
+	Limit      int
+	Offset     int
+}
+
+// FindWithPagination example : ?merchant=1&limit=10&offset=10
+func (i *impersonationDao) FindWithPagination(ctx context.Context, opt FindParams) ([]dto.ImpersonationModel, rest_err.APIError) {
+	sqlFrom := i.sb.Select(keyID, keySuperID, keyMerchantID, keyOwnerID, keyReason, keyCreatedAt, keyExpiredAt).
+		From(keyImpersonationTable)
+
+	if opt.MerchantID != 0 {
+		sqlFrom = sqlFrom.Where(squirrel.Eq{keyMerchantID: opt.MerchantID})
+	}
+
+	sqlStatement, args, err := sqlFrom.OrderBy(keyID + " DESC").
+		Limit(uint64(opt.Limit)).
+		Offset(uint64(opt.Offset)).
+		ToSql()
+
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+	rows, err := i.db.Query(ctx, sqlStatement, args...)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat query impersonation(FindWithPagination:0)", err)
+		return nil, rest_err.NewInternalServerError("gagal mendapatkan daftar impersonation", err)
+	}
+	defer rows.Close()
+
+	impersonations := make([]dto.ImpersonationModel, 0)
+	for rows.Next() {
+		imp := dto.ImpersonationModel{}
+		err := rows.Scan(&imp.ID, &imp.SuperID, &imp.MerchantID, &imp.OwnerID, &imp.Reason, &imp.CreatedAt, &imp.ExpiredAt)
+		if err != nil {
+			logger.ErrorCtx(ctx, "error saat parsing impersonation(FindWithPagination:1)", err)
+			return nil, sql_err.ParseError(err)
+		}
+		impersonations = append(impersonations, imp)
+	}
+
+	return impersonations, nil
+}
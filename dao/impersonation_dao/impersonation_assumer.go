@@ -0,0 +1,20 @@
+package impersonation_dao
+
+import (
+	"context"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+)
+
+type ImpersonationDaoAssumer interface {
+	ImpersonationSaver
+	ImpersonationLoader
+}
+
+type ImpersonationSaver interface {
+	Insert(ctx context.Context, input dto.ImpersonationModel) (int, rest_err.APIError)
+}
+
+type ImpersonationLoader interface {
+	FindWithPagination(ctx context.Context, opt FindParams) ([]dto.ImpersonationModel, rest_err.APIError)
+}
@@ -7,8 +7,8 @@ import (
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/muchlist/mini_pos/dao"
-	"github.com/muchlist/mini_pos/db"
 	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/audit"
 	"github.com/muchlist/mini_pos/utils/logger"
 	"github.com/muchlist/mini_pos/utils/rest_err"
 	"github.com/muchlist/mini_pos/utils/sql_err"
@@ -30,16 +30,30 @@ const (
 	keyUserEmail      = "email"
 	keyUserPassword   = "password"
 	keyUserRole       = "role"
+
+	keySettingTable         = "merchant_settings"
+	keySettingMerchantID    = "merchant_id"
+	keySettingCurrency      = "currency"
+	keySettingMinorUnit     = "minor_unit"
+	keySettingTimezone      = "timezone"
+	keySettingCashRounding  = "cash_rounding"
+	keySettingReceiptHeader = "receipt_header"
+	keySettingReceiptFooter = "receipt_footer"
+	keySettingLogo          = "logo"
+	keySettingRequire2FA    = "require_owner_2fa"
+
+	keyProductTable      = "products"
+	keyProductMerchantID = "merchant_id"
 )
 
 type merchantDao struct {
-	db *pgxpool.Pool
+	db *dao.Pool
 	sb squirrel.StatementBuilderType
 }
 
 func New(db *pgxpool.Pool) MerchantDaoAssumer {
 	return &merchantDao{
-		db: db,
+		db: dao.NewPool(db),
 		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
 	}
 }
@@ -67,7 +81,7 @@ func (m *merchantDao) Insert(ctx context.Context, input dto.MerchantCreateReq) (
 
 	err = trx.QueryRow(ctx, sqlStatement, args...).Scan(&response.MerchantID, &response.MerchantName)
 	if err != nil {
-		logger.Error("error saat trx query merchant (Insert:0)", err)
+		logger.ErrorCtx(ctx, "error saat trx query merchant (Insert:0)", err)
 		return nil, sql_err.ParseError(err)
 	}
 
@@ -90,10 +104,21 @@ func (m *merchantDao) Insert(ctx context.Context, input dto.MerchantCreateReq) (
 
 	err = trx.QueryRow(ctx, sqlStatement, args...).Scan(&response.OwnerEmail, &response.OwnerName)
 	if err != nil {
-		logger.Error("error saat trx query users(Insert:1)", err)
+		logger.ErrorCtx(ctx, "error saat trx query users(Insert:1)", err)
 		return nil, rest_err.NewBadRequestError("Email tidak tersedia")
 	}
 
+	// ------------------------------------------------------------- audit
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		MerchantID: response.MerchantID,
+		EntityType: audit.EntityMerchant,
+		EntityID:   response.MerchantID,
+		Action:     audit.ActionCreate,
+		After:      response,
+	}); err != nil {
+		return nil, err
+	}
+
 	// ------------------------------------------------------------- commit
 	if err := trx.Commit(ctx); err != nil {
 		return nil, rest_err.NewInternalServerError(dao.ErrCommit, err)
@@ -103,8 +128,35 @@ func (m *merchantDao) Insert(ctx context.Context, input dto.MerchantCreateReq) (
 }
 
 func (m *merchantDao) Edit(ctx context.Context, input dto.Merchant) (*dto.Merchant, rest_err.APIError) {
+
+	// ------------------------------------------------------------- begin
+	trx, err := m.db.Begin(ctx)
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	// ------------------------------------------------------------- data sebelum diubah
+	sqlStatement, args, err := m.sb.Select(keyID, keyMerchantName, keyCreatedAt, keyUpdatedAt).
+		From(keyMerchantTable).
+		Where(squirrel.Eq{keyID: input.Id}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var before dto.Merchant
+	err = trx.QueryRow(ctx, sqlStatement, args...).Scan(&before.Id, &before.MerchantName, &before.CreatedAt, &before.UpdatedAt)
+	if err != nil {
+		return nil, sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- edit merchant
 	timeNow := time.Now().Unix()
-	sqlStatement, args, err := m.sb.Update(keyMerchantTable).
+	sqlStatement, args, err = m.sb.Update(keyMerchantTable).
 		SetMap(squirrel.Eq{
 			keyMerchantName: input.MerchantName,
 			keyUpdatedAt:    timeNow,
@@ -116,35 +168,80 @@ func (m *merchantDao) Edit(ctx context.Context, input dto.Merchant) (*dto.Mercha
 		ToSql()
 
 	if err != nil {
-		logger.Error("error saat edit merchant(Edit:0)", err)
+		logger.ErrorCtx(ctx, "error saat edit merchant(Edit:0)", err)
 		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
 	}
 
 	var res dto.Merchant
-	err = m.db.QueryRow(ctx, sqlStatement, args...).Scan(&res.Id, &res.MerchantName, &res.CreatedAt, &res.UpdatedAt)
+	err = trx.QueryRow(ctx, sqlStatement, args...).Scan(&res.Id, &res.MerchantName, &res.CreatedAt, &res.UpdatedAt)
 	if err != nil {
 		return nil, sql_err.ParseError(err)
 	}
 
+	// ------------------------------------------------------------- audit
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		MerchantID: res.Id,
+		EntityType: audit.EntityMerchant,
+		EntityID:   res.Id,
+		Action:     audit.ActionEdit,
+		Before:     before,
+		After:      res,
+	}); err != nil {
+		return nil, err
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
 	return &res, nil
 }
 
 func (m *merchantDao) Delete(ctx context.Context, id int) rest_err.APIError {
+
+	// ------------------------------------------------------------- begin
+	trx, err := m.db.Begin(ctx)
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	// ------------------------------------------------------------- hapus merchant
 	sqlStatement, args, err := m.sb.Delete(keyMerchantTable).
 		Where(squirrel.Eq{keyID: id}).
+		Suffix(dao.Returning(keyID, keyMerchantName, keyCreatedAt, keyUpdatedAt)).
 		ToSql()
 	if err != nil {
 		return rest_err.NewInternalServerError("kesalahan pada sql builder", err)
 	}
 
-	res, err := db.DB.Exec(ctx, sqlStatement, args...)
+	var before dto.Merchant
+	err = trx.QueryRow(ctx, sqlStatement, args...).Scan(&before.Id, &before.MerchantName, &before.CreatedAt, &before.UpdatedAt)
 	if err != nil {
-		logger.Error("error saat delete merchant(Delete:0)", err)
+		if err == pgx.ErrNoRows {
+			return rest_err.NewBadRequestError(fmt.Sprintf("Merchant dengan id %d tidak ditemukan", id))
+		}
+		logger.ErrorCtx(ctx, "error saat delete merchant(Delete:0)", err)
 		return rest_err.NewInternalServerError("gagal saat penghapusan merchant", err)
 	}
 
-	if res.RowsAffected() == 0 {
-		return rest_err.NewBadRequestError(fmt.Sprintf("Merchant dengan id %d tidak ditemukan", id))
+	// ------------------------------------------------------------- audit
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		MerchantID: before.Id,
+		EntityType: audit.EntityMerchant,
+		EntityID:   before.Id,
+		Action:     audit.ActionDelete,
+		Before:     before,
+	}); err != nil {
+		return err
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return rest_err.NewInternalServerError(dao.ErrCommit, err)
 	}
 
 	return nil
@@ -162,7 +259,7 @@ func (m *merchantDao) Get(ctx context.Context, id int) (*dto.Merchant, rest_err.
 	}
 
 	var res dto.Merchant
-	err = db.DB.QueryRow(ctx, sqlStatement, args...).Scan(&res.Id, &res.MerchantName, &res.CreatedAt, &res.UpdatedAt)
+	err = m.db.QueryRow(ctx, sqlStatement, args...).Scan(&res.Id, &res.MerchantName, &res.CreatedAt, &res.UpdatedAt)
 	if err != nil {
 		return nil, rest_err.NewInternalServerError("gagal mendapatkan data merchant", err)
 	}
@@ -202,7 +299,7 @@ func (m *merchantDao) FindWithCursor(ctx context.Context, opt FindParams) ([]dto
 	if err != nil {
 		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
 	}
-	rows, err := db.DB.Query(ctx, sqlStatement, args...)
+	rows, err := m.db.Query(ctx, sqlStatement, args...)
 	if err != nil {
 		return nil, rest_err.NewInternalServerError("gagal mendapatkan daftar merchant", err)
 	}
@@ -220,3 +317,197 @@ func (m *merchantDao) FindWithCursor(ctx context.Context, opt FindParams) ([]dto
 
 	return merchants, nil
 }
+
+// GetSetting mengembalikan pengaturan merchant, apabila belum pernah disimpan maka
+// nilai default yang dikembalikan
+func (m *merchantDao) GetSetting(ctx context.Context, merchantID int) (*dto.MerchantSetting, rest_err.APIError) {
+	return m.getSetting(ctx, m.db, merchantID)
+}
+
+// rowQuerier dipenuhi oleh pool maupun transaksi
+type rowQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+func (m *merchantDao) getSetting(ctx context.Context, q rowQuerier, merchantID int) (*dto.MerchantSetting, rest_err.APIError) {
+	sqlStatement, args, err := m.sb.Select(
+		keySettingMerchantID,
+		keySettingCurrency,
+		keySettingMinorUnit,
+		keySettingTimezone,
+		keySettingCashRounding,
+		keySettingReceiptHeader,
+		keySettingReceiptFooter,
+		keySettingLogo,
+		keySettingRequire2FA,
+		keyUpdatedAt,
+	).
+		From(keySettingTable).
+		Where(squirrel.Eq{keySettingMerchantID: merchantID}).
+		ToSql()
+
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var res dto.MerchantSetting
+	err = q.QueryRow(ctx, sqlStatement, args...).Scan(
+		&res.MerchantID,
+		&res.Currency,
+		&res.MinorUnit,
+		&res.Timezone,
+		&res.CashRounding,
+		&res.ReceiptHeader,
+		&res.ReceiptFooter,
+		&res.Logo,
+		&res.RequireOwner2FA,
+		&res.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			defSetting := dto.DefaultMerchantSetting(merchantID)
+			return &defSetting, nil
+		}
+		logger.ErrorCtx(ctx, "error saat get merchant setting(GetSetting:0)", err)
+		return nil, sql_err.ParseError(err)
+	}
+
+	return &res, nil
+}
+
+// UpsertSetting menyimpan pengaturan merchant, logo tidak diubah melalui fungsi ini
+func (m *merchantDao) UpsertSetting(ctx context.Context, input dto.MerchantSetting) (*dto.MerchantSetting, rest_err.APIError) {
+	timeNow := time.Now().Unix()
+	sqlStatement, args, err := m.sb.Insert(keySettingTable).
+		Columns(
+			keySettingMerchantID,
+			keySettingCurrency,
+			keySettingMinorUnit,
+			keySettingTimezone,
+			keySettingCashRounding,
+			keySettingReceiptHeader,
+			keySettingReceiptFooter,
+			keySettingRequire2FA,
+			keyUpdatedAt).
+		Values(
+			input.MerchantID,
+			input.Currency,
+			input.MinorUnit,
+			input.Timezone,
+			input.CashRounding,
+			input.ReceiptHeader,
+			input.ReceiptFooter,
+			input.RequireOwner2FA,
+			timeNow).
+		Suffix(fmt.Sprintf("ON CONFLICT (%s) DO UPDATE SET %s", keySettingMerchantID, dao.ExcludedSet(
+			keySettingCurrency,
+			keySettingMinorUnit,
+			keySettingTimezone,
+			keySettingCashRounding,
+			keySettingReceiptHeader,
+			keySettingReceiptFooter,
+			keySettingRequire2FA,
+			keyUpdatedAt,
+		))).
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	return m.execSetting(ctx, input.MerchantID, sqlStatement, args, "UpsertSetting")
+}
+
+// SetLogo menyimpan path logo merchant
+func (m *merchantDao) SetLogo(ctx context.Context, merchantID int, path string) (*dto.MerchantSetting, rest_err.APIError) {
+	defSetting := dto.DefaultMerchantSetting(merchantID)
+	timeNow := time.Now().Unix()
+	sqlStatement, args, err := m.sb.Insert(keySettingTable).
+		Columns(
+			keySettingMerchantID,
+			keySettingCurrency,
+			keySettingMinorUnit,
+			keySettingTimezone,
+			keySettingLogo,
+			keyUpdatedAt).
+		Values(
+			merchantID,
+			defSetting.Currency,
+			defSetting.MinorUnit,
+			defSetting.Timezone,
+			path,
+			timeNow).
+		Suffix(fmt.Sprintf("ON CONFLICT (%s) DO UPDATE SET %s", keySettingMerchantID, dao.ExcludedSet(keySettingLogo, keyUpdatedAt))).
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	return m.execSetting(ctx, merchantID, sqlStatement, args, "SetLogo")
+}
+
+// execSetting menjalankan upsert pengaturan merchant dan mencatat perubahannya pada audit log.
+// Harga product disimpan dalam minor unit sehingga minor unit tidak dapat diubah setelah merchant memiliki product
+func (m *merchantDao) execSetting(ctx context.Context, merchantID int, sqlStatement string, args []interface{}, funcName string) (*dto.MerchantSetting, rest_err.APIError) {
+	// ------------------------------------------------------------- begin
+	trx, err := m.db.Begin(ctx)
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	before, apiErr := m.getSetting(ctx, trx, merchantID)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	// ------------------------------------------------------------- upsert pengaturan
+	if _, err := trx.Exec(ctx, sqlStatement, args...); err != nil {
+		logger.ErrorCtx(ctx, fmt.Sprintf("error saat upsert merchant setting(%s:0)", funcName), err)
+		return nil, sql_err.ParseError(err)
+	}
+
+	after, apiErr := m.getSetting(ctx, trx, merchantID)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	// ------------------------------------------------------------- minor unit terkunci oleh product
+	if after.MinorUnit != before.MinorUnit {
+		sqlStatement, args, err := m.sb.Select("COUNT(*)").
+			From(keyProductTable).
+			Where(squirrel.Eq{keyProductMerchantID: merchantID}).
+			ToSql()
+		if err != nil {
+			return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+		}
+
+		var productCount int
+		if err := trx.QueryRow(ctx, sqlStatement, args...).Scan(&productCount); err != nil {
+			logger.ErrorCtx(ctx, fmt.Sprintf("error saat trx query products(%s:1)", funcName), err)
+			return nil, sql_err.ParseError(err)
+		}
+		if productCount > 0 {
+			return nil, rest_err.NewBadRequestError(fmt.Sprintf("Minor unit tidak dapat diubah dari %d menjadi %d karena merchant sudah memiliki product, harga tersimpan dalam minor unit lama", before.MinorUnit, after.MinorUnit))
+		}
+	}
+
+	// ------------------------------------------------------------- audit
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		MerchantID: merchantID,
+		EntityType: audit.EntityMerchantSetting,
+		EntityID:   merchantID,
+		Action:     audit.ActionEdit,
+		Before:     before,
+		After:      after,
+	}); err != nil {
+		return nil, err
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
+	return after, nil
+}
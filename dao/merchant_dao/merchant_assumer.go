@@ -9,6 +9,8 @@ import (
 type MerchantDaoAssumer interface {
 	MerchantSaver
 	MerchantLoader
+	MerchantSettingSaver
+	MerchantSettingLoader
 }
 
 type MerchantSaver interface {
@@ -21,3 +23,12 @@ type MerchantLoader interface {
 	Get(ctx context.Context, id int) (*dto.Merchant, rest_err.APIError)
 	FindWithCursor(ctx context.Context, opt FindParams) ([]dto.Merchant, rest_err.APIError)
 }
+
+type MerchantSettingSaver interface {
+	UpsertSetting(ctx context.Context, input dto.MerchantSetting) (*dto.MerchantSetting, rest_err.APIError)
+	SetLogo(ctx context.Context, merchantID int, path string) (*dto.MerchantSetting, rest_err.APIError)
+}
+
+type MerchantSettingLoader interface {
+	GetSetting(ctx context.Context, merchantID int) (*dto.MerchantSetting, rest_err.APIError)
+}
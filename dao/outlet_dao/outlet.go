@@ -4,10 +4,11 @@ import (
 	"context"
 	"fmt"
 	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/muchlist/mini_pos/dao"
-	"github.com/muchlist/mini_pos/db"
 	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/audit"
 	"github.com/muchlist/mini_pos/utils/logger"
 	"github.com/muchlist/mini_pos/utils/rest_err"
 	"github.com/muchlist/mini_pos/utils/sql_err"
@@ -22,21 +23,33 @@ const (
 	keyAddress     = "address"
 	keyCreatedAt   = "created_at"
 	keyUpdatedAt   = "updated_at"
+
+	keyUserOutletTable    = "user_outlets"
+	keyUserOutletUserID   = "user_id"
+	keyUserOutletOutletID = "outlet_id"
 )
 
 type outletDao struct {
-	db *pgxpool.Pool
+	db *dao.Pool
 	sb squirrel.StatementBuilderType
 }
 
 func New(db *pgxpool.Pool) OutletDaoAssumer {
 	return &outletDao{
-		db: db,
+		db: dao.NewPool(db),
 		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
 	}
 }
 
 func (o *outletDao) Insert(ctx context.Context, input dto.OutletModel) (int, rest_err.APIError) {
+	trx, err := o.db.Begin(ctx)
+	if err != nil {
+		return 0, rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
 	timeNow := time.Now().Unix()
 
 	// -------------------------------------------------------------- insert merchant data
@@ -50,18 +63,64 @@ func (o *outletDao) Insert(ctx context.Context, input dto.OutletModel) (int, res
 	}
 
 	var createdID int
-	err = o.db.QueryRow(ctx, sqlStatement, args...).Scan(&createdID)
+	err = trx.QueryRow(ctx, sqlStatement, args...).Scan(&createdID)
 	if err != nil {
-		logger.Error("error saat query outlet (Insert:0)", err)
+		logger.ErrorCtx(ctx, "error saat query outlet (Insert:0)", err)
 		return 0, sql_err.ParseError(err)
 	}
 
+	// -------------------------------------------------------------- audit
+	input.ID = createdID
+	input.CreatedAt = timeNow
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		MerchantID: input.MerchantID,
+		EntityType: audit.EntityOutlet,
+		EntityID:   createdID,
+		Action:     audit.ActionCreate,
+		After:      input,
+	}); err != nil {
+		return 0, err
+	}
+
+	// -------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return 0, rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
 	return createdID, nil
 }
 
 func (o *outletDao) Edit(ctx context.Context, input dto.OutletEditModel) (*dto.OutletModel, rest_err.APIError) {
+	trx, err := o.db.Begin(ctx)
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	// ------------------------------------------------------------- data sebelum perubahan
+	sqlStatement, args, err := o.sb.Select(keyID, keyMerchantID, keyOutletName, keyAddress, keyCreatedAt, keyUpdatedAt).
+		From(keyOutletTable).
+		Where(squirrel.And{
+			squirrel.Eq{keyID: input.WhereID},
+			squirrel.Eq{keyMerchantID: input.WhereMerchantID}}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var before dto.OutletModel
+	err = trx.QueryRow(ctx, sqlStatement, args...).
+		Scan(&before.ID, &before.MerchantID, &before.OutletName, &before.Address, &before.CreatedAt, &before.UpdatedAt)
+	if err != nil {
+		return nil, sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- edit outlet
 	timeNow := time.Now().Unix()
-	sqlStatement, args, err := o.sb.Update(keyOutletTable).
+	sqlStatement, args, err = o.sb.Update(keyOutletTable).
 		SetMap(squirrel.Eq{
 			keyOutletName: input.OutletName,
 			keyUpdatedAt:  timeNow,
@@ -73,39 +132,83 @@ func (o *outletDao) Edit(ctx context.Context, input dto.OutletEditModel) (*dto.O
 		ToSql()
 
 	if err != nil {
-		logger.Error("error saat edit outlet(Edit:0)", err)
+		logger.ErrorCtx(ctx, "error saat edit outlet(Edit:0)", err)
 		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
 	}
 
 	var res dto.OutletModel
-	err = o.db.QueryRow(ctx, sqlStatement, args...).
+	err = trx.QueryRow(ctx, sqlStatement, args...).
 		Scan(&res.ID, &res.MerchantID, &res.OutletName, &res.Address, &res.CreatedAt, &res.UpdatedAt)
 	if err != nil {
 		return nil, sql_err.ParseError(err)
 	}
 
+	// ------------------------------------------------------------- audit
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		MerchantID: res.MerchantID,
+		EntityType: audit.EntityOutlet,
+		EntityID:   res.ID,
+		Action:     audit.ActionEdit,
+		Before:     before,
+		After:      res,
+	}); err != nil {
+		return nil, err
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
 	return &res, nil
 }
 
 func (o *outletDao) Delete(ctx context.Context, id int, filterMerchant int) rest_err.APIError {
+	trx, err := o.db.Begin(ctx)
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	// ------------------------------------------------------------- hapus outlet
 	sqlStatement, args, err := o.sb.Delete(keyOutletTable).
 		Where(squirrel.And{
 			squirrel.Eq{keyID: id},
 			squirrel.Eq{keyMerchantID: filterMerchant},
 		}).
+		Suffix(dao.Returning(keyID, keyMerchantID, keyOutletName, keyAddress, keyCreatedAt, keyUpdatedAt)).
 		ToSql()
 	if err != nil {
 		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
 	}
 
-	res, err := db.DB.Exec(ctx, sqlStatement, args...)
+	var deleted dto.OutletModel
+	err = trx.QueryRow(ctx, sqlStatement, args...).
+		Scan(&deleted.ID, &deleted.MerchantID, &deleted.OutletName, &deleted.Address, &deleted.CreatedAt, &deleted.UpdatedAt)
 	if err != nil {
-		logger.Error("error saat delete outlet(Delete:0)", err)
+		if err == pgx.ErrNoRows {
+			return rest_err.NewBadRequestError(fmt.Sprintf("Outlet dengan id %d tidak ditemukan", id))
+		}
+		logger.ErrorCtx(ctx, "error saat delete outlet(Delete:0)", err)
 		return sql_err.ParseError(err)
 	}
 
-	if res.RowsAffected() == 0 {
-		return rest_err.NewBadRequestError(fmt.Sprintf("Outlet dengan id %d tidak ditemukan", id))
+	// ------------------------------------------------------------- audit
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		MerchantID: filterMerchant,
+		EntityType: audit.EntityOutlet,
+		EntityID:   id,
+		Action:     audit.ActionDelete,
+		Before:     deleted,
+	}); err != nil {
+		return err
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return rest_err.NewInternalServerError(dao.ErrCommit, err)
 	}
 
 	return nil
@@ -124,10 +227,10 @@ func (o *outletDao) Get(ctx context.Context, id int, merchantIDifSpecific int) (
 	}
 
 	var res dto.OutletModel
-	err = db.DB.QueryRow(ctx, sqlStatement, args...).
+	err = o.db.QueryRow(ctx, sqlStatement, args...).
 		Scan(&res.ID, &res.MerchantID, &res.OutletName, &res.Address, &res.CreatedAt, &res.UpdatedAt)
 	if err != nil {
-		logger.Error("error saat query outlet(Get:0)", err)
+		logger.ErrorCtx(ctx, "error saat query outlet(Get:0)", err)
 		return nil, sql_err.ParseError(err)
 	}
 
@@ -138,6 +241,8 @@ type FindParams struct {
 	Search string
 	Limit  int
 	Offset int
+	// AssignedUser apabila diisi hanya menampilkan outlet yang ditugaskan kepada user tersebut
+	AssignedUser int
 }
 
 // FindWithPagination example : ?limit=10&offset=10
@@ -157,6 +262,11 @@ func (o *outletDao) FindWithPagination(ctx context.Context, opt FindParams, merc
 	} else {
 		sqlFrom = sqlFrom.Where(squirrel.Eq{keyMerchantID: merchantFilter})
 	}
+	if opt.AssignedUser != 0 {
+		sqlFrom = sqlFrom.Where(squirrel.Expr(
+			fmt.Sprintf("%s IN (SELECT %s FROM %s WHERE %s = ?)", keyID, keyUserOutletOutletID, keyUserOutletTable, keyUserOutletUserID),
+			opt.AssignedUser))
+	}
 
 	sqlStatement, args, err := sqlFrom.OrderBy(keyID + " ASC").
 		Limit(uint64(opt.Limit)).
@@ -166,9 +276,9 @@ func (o *outletDao) FindWithPagination(ctx context.Context, opt FindParams, merc
 	if err != nil {
 		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
 	}
-	rows, err := db.DB.Query(ctx, sqlStatement, args...)
+	rows, err := o.db.Query(ctx, sqlStatement, args...)
 	if err != nil {
-		logger.Error("error saat query outlet(FindWithPagination:0)", err)
+		logger.ErrorCtx(ctx, "error saat query outlet(FindWithPagination:0)", err)
 		return nil, rest_err.NewInternalServerError("gagal mendapatkan daftar outlet", err)
 	}
 	defer rows.Close()
@@ -178,7 +288,7 @@ func (o *outletDao) FindWithPagination(ctx context.Context, opt FindParams, merc
 		outlet := dto.OutletModel{}
 		err := rows.Scan(&outlet.ID, &outlet.MerchantID, &outlet.OutletName, &outlet.Address, &outlet.CreatedAt, &outlet.UpdatedAt)
 		if err != nil {
-			logger.Error("error saat parsing outlet(FindWithPagination:1)", err)
+			logger.ErrorCtx(ctx, "error saat parsing outlet(FindWithPagination:1)", err)
 			return nil, sql_err.ParseError(err)
 		}
 		outlets = append(outlets, outlet)
@@ -0,0 +1,169 @@
+package password_reset_dao
+
+import (
+	"context"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/muchlist/mini_pos/dao"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/utils/sql_err"
+	"time"
+)
+
+const (
+	keyResetTable      = "password_resets"
+	keyID              = "id"
+	keyUserID          = "user_id"
+	keyTokenHash       = "token_hash"
+	keyRequestedBy     = "requested_by"
+	keyCreatedAt       = "created_at"
+	keyExpiredAt       = "expired_at"
+	keyUsedAt          = "used_at"
+	keyUserTable       = "users"
+	keyUserPassword    = "password"
+	keyUserFailedLogin = "failed_login"
+	keyUserLockedUntil = "locked_until"
+	keyUpdatedAt       = "updated_at"
+
+	keyRefreshTable     = "refresh_tokens"
+	keySessionTable     = "sessions"
+	keyRefreshRevokedAt = "revoked_at"
+)
+
+type passwordResetDao struct {
+	db *dao.Pool
+	sb squirrel.StatementBuilderType
+}
+
+func New(db *pgxpool.Pool) PasswordResetDaoAssumer {
+	return &passwordResetDao{
+		db: dao.NewPool(db),
+		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (p *passwordResetDao) Insert(ctx context.Context, input dto.PasswordResetModel) (int, rest_err.APIError) {
+	sqlStatement, args, err := p.sb.Insert(keyResetTable).
+		Columns(keyUserID, keyTokenHash, keyRequestedBy, keyCreatedAt, keyExpiredAt).
+		Values(input.UserID, input.TokenHash, input.RequestedBy, input.CreatedAt, input.ExpiredAt).
+		Suffix(dao.Returning(keyID)).
+		ToSql()
+	if err != nil {
+		return 0, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var createdID int
+	err = p.db.QueryRow(ctx, sqlStatement, args...).Scan(&createdID)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat queryRow password reset (Insert:0)", err)
+		return 0, sql_err.ParseError(err)
+	}
+
+	return createdID, nil
+}
+
+// Consume memakai token reset yang belum dipakai dan belum kadaluarsa untuk mengganti password user.
+// Semua token reset lain dan refresh token milik user yang sama ikut dinonaktifkan. Mengembalikan id user
+func (p *passwordResetDao) Consume(ctx context.Context, tokenHash string, hashedPassword string) (int, rest_err.APIError) {
+
+	// ------------------------------------------------------------- begin
+	trx, err := p.db.Begin(ctx)
+	if err != nil {
+		return 0, rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	timeNow := time.Now().Unix()
+
+	// ------------------------------------------------------------- tandai token terpakai
+	sqlStatement, args, err := p.sb.Update(keyResetTable).
+		Set(keyUsedAt, timeNow).
+		Where(squirrel.And{
+			squirrel.Eq{keyTokenHash: tokenHash},
+			squirrel.Eq{keyUsedAt: 0},
+			squirrel.Gt{keyExpiredAt: timeNow},
+		}).
+		Suffix(dao.Returning(keyUserID)).
+		ToSql()
+	if err != nil {
+		return 0, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var userID int
+	err = trx.QueryRow(ctx, sqlStatement, args...).Scan(&userID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, rest_err.NewBadRequestError("Token reset password tidak valid atau sudah kadaluarsa")
+		}
+		logger.ErrorCtx(ctx, "error saat trx query password reset (Consume:0)", err)
+		return 0, sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- nonaktifkan token lain
+	sqlStatement, args, err = p.sb.Update(keyResetTable).
+		Set(keyUsedAt, timeNow).
+		Where(squirrel.And{
+			squirrel.Eq{keyUserID: userID},
+			squirrel.Eq{keyUsedAt: 0},
+		}).
+		ToSql()
+	if err != nil {
+		return 0, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	if _, err := trx.Exec(ctx, sqlStatement, args...); err != nil {
+		logger.ErrorCtx(ctx, "error saat trx exec password reset (Consume:1)", err)
+		return 0, sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- ganti password dan buka kunci akun
+	sqlStatement, args, err = p.sb.Update(keyUserTable).
+		SetMap(squirrel.Eq{
+			keyUserPassword:    hashedPassword,
+			keyUserFailedLogin: 0,
+			keyUserLockedUntil: 0,
+			keyUpdatedAt:       timeNow,
+		}).
+		Where(squirrel.Eq{keyID: userID}).
+		ToSql()
+	if err != nil {
+		return 0, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	if _, err := trx.Exec(ctx, sqlStatement, args...); err != nil {
+		logger.ErrorCtx(ctx, "error saat trx exec users (Consume:2)", err)
+		return 0, sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- cabut semua refresh token dan sesi user
+	for _, table := range []string{keyRefreshTable, keySessionTable} {
+		sqlStatement, args, err = p.sb.Update(table).
+			Set(keyRefreshRevokedAt, timeNow).
+			Where(squirrel.And{
+				squirrel.Eq{keyUserID: userID},
+				squirrel.Eq{keyRefreshRevokedAt: 0},
+			}).
+			ToSql()
+		if err != nil {
+			return 0, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+		}
+
+		if _, err := trx.Exec(ctx, sqlStatement, args...); err != nil {
+			logger.ErrorCtx(ctx, fmt.Sprintf("error saat trx exec %s (Consume:3)", table), err)
+			return 0, sql_err.ParseError(err)
+		}
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return 0, rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,16 @@
+package password_reset_dao
+
+import (
+	"context"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+)
+
+type PasswordResetDaoAssumer interface {
+	PasswordResetSaver
+}
+
+type PasswordResetSaver interface {
+	Insert(ctx context.Context, input dto.PasswordResetModel) (int, rest_err.APIError)
+	Consume(ctx context.Context, tokenHash string, hashedPassword string) (int, rest_err.APIError)
+}
@@ -0,0 +1,348 @@
+package plan_dao
+
+import (
+	"context"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/muchlist/mini_pos/dao"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/audit"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/utils/sql_err"
+	"time"
+)
+
+const (
+	keyPlanTable     = "plans"
+	keyID            = "id"
+	keyName          = "name"
+	keyMaxOutlets    = "max_outlets"
+	keyMaxUsers      = "max_users"
+	keyMaxProducts   = "max_products"
+	keyMaxImageBytes = "max_image_bytes"
+	keyCreatedAt     = "created_at"
+	keyUpdatedAt     = "updated_at"
+
+	keyMerchantTable  = "merchant"
+	keyMerchantPlanID = "plan_id"
+	keyMerchantID     = "merchant_id"
+
+	keyOutletTable    = "outlets"
+	keyUserTable      = "users"
+	keyProductTable   = "products"
+	keyProductImgSize = "image_size"
+)
+
+type planDao struct {
+	db *dao.Pool
+	sb squirrel.StatementBuilderType
+}
+
+func New(db *pgxpool.Pool) PlanDaoAssumer {
+	return &planDao{
+		db: dao.NewPool(db),
+		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (p *planDao) Insert(ctx context.Context, input dto.PlanModel) (int, rest_err.APIError) {
+
+	// ------------------------------------------------------------- begin
+	trx, err := p.db.Begin(ctx)
+	if err != nil {
+		return 0, rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	// ------------------------------------------------------------- insert plan
+	timeNow := time.Now().Unix()
+	sqlStatement, args, err := p.sb.Insert(keyPlanTable).
+		Columns(keyName, keyMaxOutlets, keyMaxUsers, keyMaxProducts, keyMaxImageBytes, keyCreatedAt, keyUpdatedAt).
+		Values(input.Name, input.MaxOutlets, input.MaxUsers, input.MaxProducts, input.MaxImageBytes, timeNow, timeNow).
+		Suffix(dao.Returning(keyID)).
+		ToSql()
+	if err != nil {
+		return 0, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var createdID int
+	err = trx.QueryRow(ctx, sqlStatement, args...).Scan(&createdID)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat queryRow plan (Insert:0)", err)
+		return 0, sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- audit
+	input.ID = createdID
+	input.CreatedAt = timeNow
+	input.UpdatedAt = timeNow
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		EntityType: audit.EntityPlan,
+		EntityID:   createdID,
+		Action:     audit.ActionCreate,
+		After:      input,
+	}); err != nil {
+		return 0, err
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return 0, rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
+	return createdID, nil
+}
+
+func (p *planDao) Edit(ctx context.Context, input dto.PlanModel) (*dto.PlanModel, rest_err.APIError) {
+
+	// ------------------------------------------------------------- begin
+	trx, err := p.db.Begin(ctx)
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	// ------------------------------------------------------------- data sebelum diubah
+	sqlStatement, args, err := p.sb.Select(keyID, keyName, keyMaxOutlets, keyMaxUsers, keyMaxProducts, keyMaxImageBytes, keyCreatedAt, keyUpdatedAt).
+		From(keyPlanTable).
+		Where(squirrel.Eq{keyID: input.ID}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var before dto.PlanModel
+	err = trx.QueryRow(ctx, sqlStatement, args...).
+		Scan(&before.ID, &before.Name, &before.MaxOutlets, &before.MaxUsers, &before.MaxProducts, &before.MaxImageBytes, &before.CreatedAt, &before.UpdatedAt)
+	if err != nil {
+		return nil, sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- edit plan
+	sqlStatement, args, err = p.sb.Update(keyPlanTable).
+		SetMap(squirrel.Eq{
+			keyName:          input.Name,
+			keyMaxOutlets:    input.MaxOutlets,
+			keyMaxUsers:      input.MaxUsers,
+			keyMaxProducts:   input.MaxProducts,
+			keyMaxImageBytes: input.MaxImageBytes,
+			keyUpdatedAt:     time.Now().Unix(),
+		}).
+		Where(squirrel.Eq{keyID: input.ID}).
+		Suffix(dao.Returning(keyID, keyName, keyMaxOutlets, keyMaxUsers, keyMaxProducts, keyMaxImageBytes, keyCreatedAt, keyUpdatedAt)).
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var res dto.PlanModel
+	err = trx.QueryRow(ctx, sqlStatement, args...).
+		Scan(&res.ID, &res.Name, &res.MaxOutlets, &res.MaxUsers, &res.MaxProducts, &res.MaxImageBytes, &res.CreatedAt, &res.UpdatedAt)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat edit plan (Edit:0)", err)
+		return nil, sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- audit
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		EntityType: audit.EntityPlan,
+		EntityID:   res.ID,
+		Action:     audit.ActionEdit,
+		Before:     before,
+		After:      res,
+	}); err != nil {
+		return nil, err
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
+	return &res, nil
+}
+
+// SetMerchantPlan memindahkan merchant ke paket lain
+func (p *planDao) SetMerchantPlan(ctx context.Context, merchantID int, planID int) rest_err.APIError {
+
+	// ------------------------------------------------------------- begin
+	trx, err := p.db.Begin(ctx)
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	// ------------------------------------------------------------- paket sebelum diubah
+	sqlStatement, args, err := p.sb.Select(keyMerchantPlanID).
+		From(keyMerchantTable).
+		Where(squirrel.Eq{keyID: merchantID}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var beforePlanID int
+	err = trx.QueryRow(ctx, sqlStatement, args...).Scan(&beforePlanID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return rest_err.NewBadRequestError(fmt.Sprintf("Merchant dengan id %d tidak ditemukan", merchantID))
+		}
+		logger.ErrorCtx(ctx, "error saat query merchant plan (SetMerchantPlan:0)", err)
+		return sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- ubah paket
+	sqlStatement, args, err = p.sb.Update(keyMerchantTable).
+		SetMap(squirrel.Eq{
+			keyMerchantPlanID: planID,
+			keyUpdatedAt:      time.Now().Unix(),
+		}).
+		Where(squirrel.Eq{keyID: merchantID}).
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	if _, err := trx.Exec(ctx, sqlStatement, args...); err != nil {
+		logger.ErrorCtx(ctx, "error saat set merchant plan (SetMerchantPlan:1)", err)
+		return sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- audit
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		MerchantID: merchantID,
+		EntityType: audit.EntityMerchantPlan,
+		EntityID:   merchantID,
+		Action:     audit.ActionEdit,
+		Before:     map[string]int{keyMerchantPlanID: beforePlanID},
+		After:      map[string]int{keyMerchantPlanID: planID},
+	}); err != nil {
+		return err
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
+	return nil
+}
+
+func (p *planDao) Get(ctx context.Context, id int) (*dto.PlanModel, rest_err.APIError) {
+	sqlStatement, args, err := p.sb.Select(keyID, keyName, keyMaxOutlets, keyMaxUsers, keyMaxProducts, keyMaxImageBytes, keyCreatedAt, keyUpdatedAt).
+		From(keyPlanTable).
+		Where(squirrel.Eq{keyID: id}).
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var res dto.PlanModel
+	err = p.db.QueryRow(ctx, sqlStatement, args...).
+		Scan(&res.ID, &res.Name, &res.MaxOutlets, &res.MaxUsers, &res.MaxProducts, &res.MaxImageBytes, &res.CreatedAt, &res.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, rest_err.NewNotFoundError(fmt.Sprintf("Paket dengan id %d tidak ditemukan", id))
+		}
+		logger.ErrorCtx(ctx, "error saat get plan (Get:0)", err)
+		return nil, sql_err.ParseError(err)
+	}
+
+	return &res, nil
+}
+
+func (p *planDao) Find(ctx context.Context) ([]dto.PlanModel, rest_err.APIError) {
+	sqlStatement, args, err := p.sb.Select(keyID, keyName, keyMaxOutlets, keyMaxUsers, keyMaxProducts, keyMaxImageBytes, keyCreatedAt, keyUpdatedAt).
+		From(keyPlanTable).
+		OrderBy(keyID + " ASC").
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	rows, err := p.db.Query(ctx, sqlStatement, args...)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat find plan (Find:0)", err)
+		return nil, rest_err.NewInternalServerError("gagal mendapatkan daftar paket", err)
+	}
+	defer rows.Close()
+
+	plans := make([]dto.PlanModel, 0)
+	for rows.Next() {
+		plan := dto.PlanModel{}
+		err := rows.Scan(&plan.ID, &plan.Name, &plan.MaxOutlets, &plan.MaxUsers, &plan.MaxProducts, &plan.MaxImageBytes, &plan.CreatedAt, &plan.UpdatedAt)
+		if err != nil {
+			return nil, sql_err.ParseError(err)
+		}
+		plans = append(plans, plan)
+	}
+
+	return plans, nil
+}
+
+// GetUsage menghitung jumlah outlet, user, product dan ukuran gambar milik merchant beserta paketnya
+func (p *planDao) GetUsage(ctx context.Context, merchantID int) (*dto.MerchantUsage, rest_err.APIError) {
+	countOf := func(table string) string {
+		return fmt.Sprintf("(SELECT COUNT(*) FROM %s WHERE %s = %s)", table, keyMerchantID, dao.A(keyID))
+	}
+
+	sqlStatement, args, err := p.sb.Select(
+		dao.A(keyID),
+		dao.B(keyID),
+		dao.B(keyName),
+		dao.B(keyMaxOutlets),
+		dao.B(keyMaxUsers),
+		dao.B(keyMaxProducts),
+		dao.B(keyMaxImageBytes),
+		dao.B(keyCreatedAt),
+		dao.B(keyUpdatedAt),
+		countOf(keyOutletTable),
+		countOf(keyUserTable),
+		countOf(keyProductTable),
+		fmt.Sprintf("(SELECT COALESCE(SUM(%s), 0) FROM %s WHERE %s = %s)", keyProductImgSize, keyProductTable, keyMerchantID, dao.A(keyID)),
+	).
+		From(keyMerchantTable + " A").
+		Join(fmt.Sprintf("%s B ON %s = %s", keyPlanTable, dao.B(keyID), dao.A(keyMerchantPlanID))).
+		Where(squirrel.Eq{dao.A(keyID): merchantID}).
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var res dto.MerchantUsage
+	err = p.db.QueryRow(ctx, sqlStatement, args...).Scan(
+		&res.MerchantID,
+		&res.Plan.ID,
+		&res.Plan.Name,
+		&res.Plan.MaxOutlets,
+		&res.Plan.MaxUsers,
+		&res.Plan.MaxProducts,
+		&res.Plan.MaxImageBytes,
+		&res.Plan.CreatedAt,
+		&res.Plan.UpdatedAt,
+		&res.Outlets,
+		&res.Users,
+		&res.Products,
+		&res.ImageBytes,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, rest_err.NewNotFoundError(fmt.Sprintf("Merchant dengan id %d tidak ditemukan", merchantID))
+		}
+		logger.ErrorCtx(ctx, "error saat get usage merchant (GetUsage:0)", err)
+		return nil, sql_err.ParseError(err)
+	}
+
+	return &res, nil
+}
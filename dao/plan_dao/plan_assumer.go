@@ -0,0 +1,24 @@
+package plan_dao
+
+import (
+	"context"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+)
+
+type PlanDaoAssumer interface {
+	PlanSaver
+	PlanLoader
+}
+
+type PlanSaver interface {
+	Insert(ctx context.Context, input dto.PlanModel) (int, rest_err.APIError)
+	Edit(ctx context.Context, input dto.PlanModel) (*dto.PlanModel, rest_err.APIError)
+	SetMerchantPlan(ctx context.Context, merchantID int, planID int) rest_err.APIError
+}
+
+type PlanLoader interface {
+	Get(ctx context.Context, id int) (*dto.PlanModel, rest_err.APIError)
+	Find(ctx context.Context) ([]dto.PlanModel, rest_err.APIError)
+	GetUsage(ctx context.Context, merchantID int) (*dto.MerchantUsage, rest_err.APIError)
+}
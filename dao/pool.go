@@ -0,0 +1,198 @@
+package dao
+
+import (
+	"context"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"runtime"
+	"strings"
+)
+
+const tracerName = "github.com/muchlist/mini_pos/dao"
+
+// Pool membungkus pgxpool.Pool agar setiap query tercatat sebagai span.
+// Nama span diambil dari method dao pemanggil, contoh product_dao.Insert
+type Pool struct {
+	pool   *pgxpool.Pool
+	tracer trace.Tracer
+}
+
+func NewPool(pool *pgxpool.Pool) *Pool {
+	return &Pool{
+		pool:   pool,
+		tracer: otel.Tracer(tracerName),
+	}
+}
+
+func (p *Pool) Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error) {
+	ctx, span := startSpan(ctx, p.tracer, sql)
+	tag, err := p.pool.Exec(ctx, sql, arguments...)
+	endSpan(span, err)
+	return tag, err
+}
+
+func (p *Pool) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	ctx, span := startSpan(ctx, p.tracer, sql)
+	rows, err := p.pool.Query(ctx, sql, args...)
+	if err != nil {
+		endSpan(span, err)
+		return rows, err
+	}
+	return &tracedRows{Rows: rows, span: span}, nil
+}
+
+func (p *Pool) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	ctx, span := startSpan(ctx, p.tracer, sql)
+	return &tracedRow{row: p.pool.QueryRow(ctx, sql, args...), span: span}
+}
+
+// Begin span transaksi dimulai di sini dan berakhir saat Commit atau Rollback,
+// query di dalam transaksi menjadi child span
+func (p *Pool) Begin(ctx context.Context) (pgx.Tx, error) {
+	ctx, span := p.tracer.Start(ctx, spanName(2)+" tx", trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("db.system", "postgresql")))
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		endSpan(span, err)
+		return nil, err
+	}
+	return &tracedTx{Tx: tx, tracer: p.tracer, span: span}, nil
+}
+
+// tracedTx pgx.Tx yang mencatat query, method lain diteruskan ke transaksi asli
+type tracedTx struct {
+	pgx.Tx
+	tracer trace.Tracer
+	span   trace.Span
+}
+
+func (t *tracedTx) Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error) {
+	ctx, span := startSpan(t.parent(ctx), t.tracer, sql)
+	tag, err := t.Tx.Exec(ctx, sql, arguments...)
+	endSpan(span, err)
+	return tag, err
+}
+
+func (t *tracedTx) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	ctx, span := startSpan(t.parent(ctx), t.tracer, sql)
+	rows, err := t.Tx.Query(ctx, sql, args...)
+	if err != nil {
+		endSpan(span, err)
+		return rows, err
+	}
+	return &tracedRows{Rows: rows, span: span}, nil
+}
+
+func (t *tracedTx) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	ctx, span := startSpan(t.parent(ctx), t.tracer, sql)
+	return &tracedRow{row: t.Tx.QueryRow(ctx, sql, args...), span: span}
+}
+
+func (t *tracedTx) Commit(ctx context.Context) error {
+	err := t.Tx.Commit(ctx)
+	endSpan(t.span, err)
+	return err
+}
+
+// Rollback umumnya dipanggil melalui defer setelah Commit, End kedua kalinya diabaikan otel
+func (t *tracedTx) Rollback(ctx context.Context) error {
+	err := t.Tx.Rollback(ctx)
+	if err == pgx.ErrTxClosed {
+		t.span.End()
+		return err
+	}
+	endSpan(t.span, err)
+	return err
+}
+
+// parent menjadikan span transaksi sebagai parent query, value lain pada ctx tetap dipertahankan
+func (t *tracedTx) parent(ctx context.Context) context.Context {
+	return trace.ContextWithSpan(ctx, t.span)
+}
+
+// tracedRow span berakhir ketika Scan dipanggil
+type tracedRow struct {
+	row  pgx.Row
+	span trace.Span
+}
+
+func (r *tracedRow) Scan(dest ...interface{}) error {
+	err := r.row.Scan(dest...)
+	if err == pgx.ErrNoRows {
+		// tidak ada baris bukan kegagalan database
+		r.span.End()
+		return err
+	}
+	endSpan(r.span, err)
+	return err
+}
+
+// tracedRows span berakhir ketika Close dipanggil, termasuk Close otomatis setelah Next false
+type tracedRows struct {
+	pgx.Rows
+	span trace.Span
+}
+
+func (r *tracedRows) Next() bool {
+	if r.Rows.Next() {
+		return true
+	}
+	endSpan(r.span, r.Rows.Err())
+	return false
+}
+
+func (r *tracedRows) Close() {
+	r.Rows.Close()
+	endSpan(r.span, r.Rows.Err())
+}
+
+func startSpan(ctx context.Context, tracer trace.Tracer, sql string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, spanName(3), trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.statement", sql),
+		))
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// spanName mengambil nama method pemanggil, skip dihitung dari spanName
+func spanName(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip)
+	if !ok {
+		return "query"
+	}
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return "query"
+	}
+	return shortFuncName(fn.Name())
+}
+
+// shortFuncName github.com/muchlist/mini_pos/dao/product_dao.(*productDao).Insert menjadi product_dao.Insert
+func shortFuncName(name string) string {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	parts := strings.Split(name, ".")
+	if len(parts) < 2 {
+		return name
+	}
+	method := parts[len(parts)-1]
+	// closure seperti Insert.func1 memakai nama method induknya
+	for i := len(parts) - 1; i > 0 && strings.HasPrefix(parts[i], "func"); i-- {
+		method = parts[i-1]
+	}
+	return parts[0] + "." + method
+}
@@ -4,10 +4,11 @@ import (
 	"context"
 	"fmt"
 	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/muchlist/mini_pos/dao"
-	"github.com/muchlist/mini_pos/db"
 	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/audit"
 	"github.com/muchlist/mini_pos/utils/logger"
 	"github.com/muchlist/mini_pos/utils/rest_err"
 	"github.com/muchlist/mini_pos/utils/sql_err"
@@ -23,6 +24,8 @@ const (
 	keyProDefBuy    = "def_buy_price"
 	keyProDefSell   = "def_sell_price"
 	keyProImage     = "image"
+	keyProTaxID     = "tax_id"
+	keyProImageSize = "image_size"
 	keyCreatedAt    = "created_at"
 	keyUpdatedAt    = "updated_at"
 
@@ -32,26 +35,41 @@ const (
 	keyProductPriceBuy       = "buy_price"
 	keyProductPriceSell      = "sell_price"
 	keyProductPriceOutletID  = "outlet_id"
+
+	keyMerchantTable     = "merchant"
+	keyMerchantID        = "id"
+	keyMerchantPlanID    = "plan_id"
+	keyPlanTable         = "plans"
+	keyPlanID            = "id"
+	keyPlanMaxImageBytes = "max_image_bytes"
 )
 
 type productDao struct {
-	db *pgxpool.Pool
+	db *dao.Pool
 	sb squirrel.StatementBuilderType
 }
 
 func New(db *pgxpool.Pool) ProductDaoAssumer {
 	return &productDao{
-		db: db,
+		db: dao.NewPool(db),
 		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
 	}
 }
 
 func (p *productDao) Insert(ctx context.Context, input dto.ProductModel) (int, rest_err.APIError) {
+	trx, err := p.db.Begin(ctx)
+	if err != nil {
+		return 0, rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
 	timeNow := time.Now().Unix()
 	// -------------------------------------------------------------- insert merchant data
 	sqlStatement, args, err := p.sb.Insert(keyProductTable).
-		Columns(keyProMerchID, keyProCode, keyProName, keyProDefBuy, keyProDefSell, keyProImage, keyCreatedAt, keyUpdatedAt).
-		Values(input.MerchantID, input.Code, input.Name, input.MasterBuyPrice, input.MasterSellPrice, input.Image, timeNow, timeNow).
+		Columns(keyProMerchID, keyProCode, keyProName, keyProDefBuy, keyProDefSell, keyProImage, keyProTaxID, keyCreatedAt, keyUpdatedAt).
+		Values(input.MerchantID, input.Code, input.Name, input.MasterBuyPrice, input.MasterSellPrice, input.Image, input.TaxID, timeNow, timeNow).
 		Suffix(dao.Returning(keyProID)).
 		ToSql()
 	if err != nil {
@@ -59,16 +77,49 @@ func (p *productDao) Insert(ctx context.Context, input dto.ProductModel) (int, r
 	}
 
 	var createdID int
-	err = p.db.QueryRow(ctx, sqlStatement, args...).Scan(&createdID)
+	err = trx.QueryRow(ctx, sqlStatement, args...).Scan(&createdID)
 	if err != nil {
-		logger.Error("error saat queryRow product (Insert:0)", err)
+		logger.ErrorCtx(ctx, "error saat queryRow product (Insert:0)", err)
 		return 0, sql_err.ParseError(err)
 	}
 
+	// -------------------------------------------------------------- audit
+	input.ID = createdID
+	input.CreatedAt = timeNow
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		MerchantID: input.MerchantID,
+		EntityType: audit.EntityProduct,
+		EntityID:   createdID,
+		Action:     audit.ActionCreate,
+		After:      newProductAudit(input),
+	}); err != nil {
+		return 0, err
+	}
+
+	// -------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return 0, rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
 	return createdID, nil
 }
 
 func (p *productDao) Edit(ctx context.Context, input dto.ProductEditModel) (*dto.ProductModel, rest_err.APIError) {
+	trx, err := p.db.Begin(ctx)
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	// ------------------------------------------------------------- data sebelum perubahan
+	before, apiErr := p.getForUpdate(ctx, trx, input.WhereID, input.WhereMerchantID)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	// ------------------------------------------------------------- edit product
 	timeNow := time.Now().Unix()
 	sqlStatement, args, err := p.sb.Update(keyProductTable).
 		SetMap(squirrel.Eq{
@@ -76,25 +127,44 @@ func (p *productDao) Edit(ctx context.Context, input dto.ProductEditModel) (*dto
 			keyProName:    input.Name,
 			keyProDefBuy:  input.MasterBuyPrice,
 			keyProDefSell: input.MasterSellPrice,
+			keyProTaxID:   input.TaxID,
 			keyUpdatedAt:  timeNow,
 		}).
 		Where(squirrel.And{
 			squirrel.Eq{keyProID: input.WhereID},
 			squirrel.Eq{keyProMerchID: input.WhereMerchantID}}).
-		Suffix(dao.Returning(keyProID, keyProMerchID, keyProCode, keyProName, keyProDefBuy, keyProDefSell, keyProImage, keyCreatedAt, keyUpdatedAt)).
+		Suffix(dao.Returning(keyProID, keyProMerchID, keyProCode, keyProName, keyProDefBuy, keyProDefSell, keyProImage, keyProTaxID, keyCreatedAt, keyUpdatedAt)).
 		ToSql()
 
 	if err != nil {
-		logger.Error("error saat edit product(Edit:0)", err)
+		logger.ErrorCtx(ctx, "error saat edit product(Edit:0)", err)
 		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
 	}
 
 	var res dto.ProductModel
-	err = p.db.QueryRow(ctx, sqlStatement, args...).
-		Scan(&res.ID, &res.MerchantID, &res.Code, &res.Name, &res.MasterBuyPrice, &res.MasterSellPrice, &res.Image, &res.CreatedAt, &res.UpdatedAt)
+	err = trx.QueryRow(ctx, sqlStatement, args...).
+		Scan(&res.ID, &res.MerchantID, &res.Code, &res.Name, &res.MasterBuyPrice, &res.MasterSellPrice, &res.Image, &res.TaxID, &res.CreatedAt, &res.UpdatedAt)
 	if err != nil {
 		return nil, sql_err.ParseError(err)
 	}
+
+	// ------------------------------------------------------------- audit
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		MerchantID: res.MerchantID,
+		EntityType: audit.EntityProduct,
+		EntityID:   res.ID,
+		Action:     audit.ActionEdit,
+		Before:     newProductAudit(*before),
+		After:      newProductAudit(res),
+	}); err != nil {
+		return nil, err
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
 	// set price to master if 0
 	if res.BuyPrice == 0 {
 		res.BuyPrice = res.MasterBuyPrice
@@ -106,52 +176,144 @@ func (p *productDao) Edit(ctx context.Context, input dto.ProductEditModel) (*dto
 	return &res, nil
 }
 
-func (p *productDao) Delete(ctx context.Context, id int, filterMerchant int) rest_err.APIError {
+// Delete menghapus product dan mengembalikan path gambarnya agar file dapat dihapus setelah commit
+func (p *productDao) Delete(ctx context.Context, id int, filterMerchant int) (string, rest_err.APIError) {
+	trx, err := p.db.Begin(ctx)
+	if err != nil {
+		return "", rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	// ------------------------------------------------------------- hapus product
 	sqlStatement, args, err := p.sb.Delete(keyProductTable).
 		Where(squirrel.And{
 			squirrel.Eq{keyProID: id},
 			squirrel.Eq{keyProMerchID: filterMerchant},
 		}).
+		Suffix(dao.Returning(keyProID, keyProMerchID, keyProCode, keyProName, keyProDefBuy, keyProDefSell, keyProImage, keyProTaxID, keyCreatedAt, keyUpdatedAt)).
 		ToSql()
 	if err != nil {
-		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+		return "", rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
 	}
 
-	res, err := db.DB.Exec(ctx, sqlStatement, args...)
+	var deleted dto.ProductModel
+	err = trx.QueryRow(ctx, sqlStatement, args...).
+		Scan(&deleted.ID, &deleted.MerchantID, &deleted.Code, &deleted.Name, &deleted.MasterBuyPrice, &deleted.MasterSellPrice, &deleted.Image, &deleted.TaxID, &deleted.CreatedAt, &deleted.UpdatedAt)
 	if err != nil {
-		logger.Error("error saat delete product(Delete:0)", err)
-		return sql_err.ParseError(err)
+		if err == pgx.ErrNoRows {
+			return "", rest_err.NewBadRequestError(fmt.Sprintf("Product dengan id %d tidak ditemukan", id))
+		}
+		logger.ErrorCtx(ctx, "error saat delete product(Delete:0)", err)
+		return "", sql_err.ParseError(err)
 	}
 
-	if res.RowsAffected() == 0 {
-		return rest_err.NewBadRequestError(fmt.Sprintf("Product dengan id %d tidak ditemukan", id))
+	// ------------------------------------------------------------- audit
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		MerchantID: filterMerchant,
+		EntityType: audit.EntityProduct,
+		EntityID:   id,
+		Action:     audit.ActionDelete,
+		Before:     newProductAudit(deleted),
+	}); err != nil {
+		return "", err
 	}
 
-	return nil
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return "", rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
+	return deleted.Image, nil
 }
 
-func (p *productDao) SetImagePath(ctx context.Context, productID int, path string) (*dto.ProductModel, rest_err.APIError) {
+// SetImagePath mengganti gambar product dan mengembalikan path gambar sebelumnya agar file lama dapat
+// dihapus setelah commit. Baris merchant dikunci selama transaksi dan kuota penyimpanan diperiksa pada
+// kondisi update sehingga upload bersamaan pada merchant yang sama tidak dapat melewati batas paket
+func (p *productDao) SetImagePath(ctx context.Context, productID int, merchantID int, path string, size int64) (*dto.ProductModel, string, rest_err.APIError) {
+	trx, err := p.db.Begin(ctx)
+	if err != nil {
+		return nil, "", rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	// ------------------------------------------------------------- kunci merchant dan ambil batas paket
+	sqlStatement, args, err := p.sb.Select(dao.B(keyPlanMaxImageBytes)).
+		From(keyMerchantTable + " A").
+		Join(fmt.Sprintf("%s B ON %s = %s", keyPlanTable, dao.B(keyPlanID), dao.A(keyMerchantPlanID))).
+		Where(squirrel.Eq{dao.A(keyMerchantID): merchantID}).
+		Suffix("FOR UPDATE OF A").
+		ToSql()
+	if err != nil {
+		return nil, "", rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var limit int64
+	if err := trx.QueryRow(ctx, sqlStatement, args...).Scan(&limit); err != nil {
+		logger.ErrorCtx(ctx, "error saat lock merchant(SetImagePath:0)", err)
+		return nil, "", sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- data sebelum perubahan
+	before, apiErr := p.getForUpdate(ctx, trx, productID, merchantID)
+	if apiErr != nil {
+		return nil, "", apiErr
+	}
+
+	// ------------------------------------------------------------- ganti gambar
+	where := squirrel.And{squirrel.Eq{keyProID: productID}}
+	if limit != 0 {
+		// gambar lama product yang sama tidak dihitung karena diganti
+		where = append(where, squirrel.Expr(
+			fmt.Sprintf("(SELECT COALESCE(SUM(%s), 0) FROM %s WHERE %s = ? AND %s <> ?) + ? <= ?",
+				keyProImageSize, keyProductTable, keyProMerchID, keyProID),
+			merchantID, productID, size, limit))
+	}
 	timeNow := time.Now().Unix()
-	sqlStatement, args, err := p.sb.Update(keyProductTable).
+	sqlStatement, args, err = p.sb.Update(keyProductTable).
 		SetMap(squirrel.Eq{
-			keyProImage:  path,
-			keyUpdatedAt: timeNow,
+			keyProImage:     path,
+			keyProImageSize: size,
+			keyUpdatedAt:    timeNow,
 		}).
-		Where(squirrel.Eq{keyProID: productID}).
-		Suffix(dao.Returning(keyProID, keyProMerchID, keyProCode, keyProName, keyProDefBuy, keyProDefSell, keyProImage, keyCreatedAt, keyUpdatedAt)).
+		Where(where).
+		Suffix(dao.Returning(keyProID, keyProMerchID, keyProCode, keyProName, keyProDefBuy, keyProDefSell, keyProImage, keyProTaxID, keyCreatedAt, keyUpdatedAt)).
 		ToSql()
 
 	if err != nil {
-		logger.Error("error saat edit product(SetImagePath:0)", err)
-		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+		logger.ErrorCtx(ctx, "error saat edit product(SetImagePath:1)", err)
+		return nil, "", rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
 	}
 
 	var res dto.ProductModel
-	err = p.db.QueryRow(ctx, sqlStatement, args...).
-		Scan(&res.ID, &res.MerchantID, &res.Code, &res.Name, &res.MasterBuyPrice, &res.MasterSellPrice, &res.Image, &res.CreatedAt, &res.UpdatedAt)
+	err = trx.QueryRow(ctx, sqlStatement, args...).
+		Scan(&res.ID, &res.MerchantID, &res.Code, &res.Name, &res.MasterBuyPrice, &res.MasterSellPrice, &res.Image, &res.TaxID, &res.CreatedAt, &res.UpdatedAt)
 	if err != nil {
-		return nil, sql_err.ParseError(err)
+		if err == pgx.ErrNoRows {
+			return nil, "", rest_err.NewQuotaExceededError(fmt.Sprintf("kuota %s paket sudah habis, silahkan upgrade paket", dto.QuotaImage))
+		}
+		return nil, "", sql_err.ParseError(err)
+	}
+	// ------------------------------------------------------------- audit
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		MerchantID: res.MerchantID,
+		EntityType: audit.EntityProduct,
+		EntityID:   res.ID,
+		Action:     audit.ActionEdit,
+		Before:     newProductAudit(*before),
+		After:      newProductAudit(res),
+	}); err != nil {
+		return nil, "", err
 	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return nil, "", rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
 	// set price to master if 0
 	if res.BuyPrice == 0 {
 		res.BuyPrice = res.MasterBuyPrice
@@ -160,7 +322,30 @@ func (p *productDao) SetImagePath(ctx context.Context, productID int, path strin
 		res.SellPrice = res.MasterSellPrice
 	}
 
-	return &res, nil
+	return &res, before.Image, nil
+}
+
+// GetImageSize ukuran gambar product dalam byte, dipakai untuk menghitung kuota saat gambar diganti
+func (p *productDao) GetImageSize(ctx context.Context, id int, merchantFilter int) (int64, rest_err.APIError) {
+	sqlStatement, args, err := p.sb.Select(keyProImageSize).
+		From(keyProductTable).
+		Where(squirrel.And{
+			squirrel.Eq{keyProID: id},
+			squirrel.Eq{keyProMerchID: merchantFilter},
+		}).
+		ToSql()
+	if err != nil {
+		return 0, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var size int64
+	err = p.db.QueryRow(ctx, sqlStatement, args...).Scan(&size)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat get image size product(GetImageSize:0)", err)
+		return 0, sql_err.ParseError(err)
+	}
+
+	return size, nil
 }
 
 func (p *productDao) Get(ctx context.Context, id int, merchantFilter int) (*dto.ProductModel, rest_err.APIError) {
@@ -172,6 +357,7 @@ func (p *productDao) Get(ctx context.Context, id int, merchantFilter int) (*dto.
 		keyProDefBuy,
 		keyProDefSell,
 		keyProImage,
+		keyProTaxID,
 		keyCreatedAt,
 		keyUpdatedAt,
 	).
@@ -187,10 +373,10 @@ func (p *productDao) Get(ctx context.Context, id int, merchantFilter int) (*dto.
 	}
 
 	var res dto.ProductModel
-	err = db.DB.QueryRow(ctx, sqlStatement, args...).
-		Scan(&res.ID, &res.MerchantID, &res.Code, &res.Name, &res.MasterBuyPrice, &res.MasterSellPrice, &res.Image, &res.CreatedAt, &res.UpdatedAt)
+	err = p.db.QueryRow(ctx, sqlStatement, args...).
+		Scan(&res.ID, &res.MerchantID, &res.Code, &res.Name, &res.MasterBuyPrice, &res.MasterSellPrice, &res.Image, &res.TaxID, &res.CreatedAt, &res.UpdatedAt)
 	if err != nil {
-		logger.Error("error saat get product(Get:0)", err)
+		logger.ErrorCtx(ctx, "error saat get product(Get:0)", err)
 		return nil, sql_err.ParseError(err)
 	}
 
@@ -214,6 +400,7 @@ func (p *productDao) GetWithCustomPriceOutlet(ctx context.Context, id int, outle
 		dao.A(keyProDefBuy),
 		dao.A(keyProDefSell),
 		dao.A(keyProImage),
+		dao.A(keyProTaxID),
 		dao.A(keyCreatedAt),
 		dao.A(keyUpdatedAt),
 		dao.CoalesceInt(dao.B(keyProductPriceBuy), 0),
@@ -232,10 +419,10 @@ func (p *productDao) GetWithCustomPriceOutlet(ctx context.Context, id int, outle
 	}
 
 	var res dto.ProductModel
-	err = db.DB.QueryRow(ctx, sqlStatement, args...).
-		Scan(&res.ID, &res.MerchantID, &res.Code, &res.Name, &res.MasterBuyPrice, &res.MasterSellPrice, &res.Image, &res.CreatedAt, &res.UpdatedAt, &res.BuyPrice, &res.SellPrice)
+	err = p.db.QueryRow(ctx, sqlStatement, args...).
+		Scan(&res.ID, &res.MerchantID, &res.Code, &res.Name, &res.MasterBuyPrice, &res.MasterSellPrice, &res.Image, &res.TaxID, &res.CreatedAt, &res.UpdatedAt, &res.BuyPrice, &res.SellPrice)
 	if err != nil {
-		logger.Error("error saat get product(GetWithCustomPriceOutlet:0)", err)
+		logger.ErrorCtx(ctx, "error saat get product(GetWithCustomPriceOutlet:0)", err)
 		return nil, sql_err.ParseError(err)
 	}
 
@@ -268,6 +455,7 @@ func (p *productDao) FindWithPagination(ctx context.Context, opt FindParams, mer
 		keyProDefBuy,
 		keyProDefSell,
 		keyProImage,
+		keyProTaxID,
 		keyCreatedAt,
 		keyUpdatedAt).
 		From(keyProductTable)
@@ -291,9 +479,9 @@ func (p *productDao) FindWithPagination(ctx context.Context, opt FindParams, mer
 	if err != nil {
 		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
 	}
-	rows, err := db.DB.Query(ctx, sqlStatement, args...)
+	rows, err := p.db.Query(ctx, sqlStatement, args...)
 	if err != nil {
-		logger.Error("error saat query product(FindWithPagination:0)", err)
+		logger.ErrorCtx(ctx, "error saat query product(FindWithPagination:0)", err)
 		return nil, rest_err.NewInternalServerError("gagal mendapatkan daftar product", err)
 	}
 	defer rows.Close()
@@ -301,9 +489,9 @@ func (p *productDao) FindWithPagination(ctx context.Context, opt FindParams, mer
 	products := make([]dto.ProductModel, 0)
 	for rows.Next() {
 		product := dto.ProductModel{}
-		err := rows.Scan(&product.ID, &product.MerchantID, &product.Code, &product.Name, &product.MasterBuyPrice, &product.MasterSellPrice, &product.Image, &product.CreatedAt, &product.UpdatedAt)
+		err := rows.Scan(&product.ID, &product.MerchantID, &product.Code, &product.Name, &product.MasterBuyPrice, &product.MasterSellPrice, &product.Image, &product.TaxID, &product.CreatedAt, &product.UpdatedAt)
 		if err != nil {
-			logger.Error("error saat parsing product(FindWithPagination:1)", err)
+			logger.ErrorCtx(ctx, "error saat parsing product(FindWithPagination:1)", err)
 			return nil, sql_err.ParseError(err)
 		}
 
@@ -321,6 +509,14 @@ func (p *productDao) FindWithPagination(ctx context.Context, opt FindParams, mer
 }
 
 func (p *productDao) InsertCustomPrice(ctx context.Context, input dto.ProductPriceModel) (*dto.ProductModel, rest_err.APIError) {
+	trx, err := p.db.Begin(ctx)
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
 	timeNow := time.Now().Unix()
 	// -------------------------------------------------------------- insert merchant data
 	sqlStatement, args, err := p.sb.Insert(keyProductPriceTable).
@@ -333,15 +529,31 @@ func (p *productDao) InsertCustomPrice(ctx context.Context, input dto.ProductPri
 	}
 
 	var createdID string
-	err = p.db.QueryRow(ctx, sqlStatement, args...).Scan(&createdID)
+	err = trx.QueryRow(ctx, sqlStatement, args...).Scan(&createdID)
 	if err != nil {
-		logger.Error("error saat queryRow product (InsertCustomPrice:0)", err)
+		logger.ErrorCtx(ctx, "error saat queryRow product (InsertCustomPrice:0)", err)
 		return nil, sql_err.ParseError(err)
 	}
 
+	// -------------------------------------------------------------- audit
+	input.UpdatedAt = timeNow
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		EntityType: audit.EntityProductPrice,
+		EntityID:   input.ProductID,
+		Action:     audit.ActionCreate,
+		After:      input,
+	}); err != nil {
+		return nil, err
+	}
+
+	// -------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
 	res, apiErr := p.GetWithCustomPriceOutlet(ctx, input.ProductID, input.OutletID)
-	if err != nil {
-		logger.Error("error saat GetWithCustomPriceOutlet (InsertCustomPrice:1)", err)
+	if apiErr != nil {
+		logger.ErrorCtx(ctx, "error saat GetWithCustomPriceOutlet (InsertCustomPrice:1)", apiErr)
 		return nil, apiErr
 	}
 
@@ -349,32 +561,76 @@ func (p *productDao) InsertCustomPrice(ctx context.Context, input dto.ProductPri
 }
 
 func (p *productDao) EditCustomPrice(ctx context.Context, input dto.ProductPriceModel) (*dto.ProductModel, rest_err.APIError) {
+	trx, err := p.db.Begin(ctx)
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	// ------------------------------------------------------------- harga sebelum perubahan
+	sqlStatement, args, err := p.sb.Select(keyProductPriceID, keyProductPriceProductID, keyProductPriceOutletID, keyProductPriceBuy, keyProductPriceSell, keyUpdatedAt).
+		From(keyProductPriceTable).
+		Where(squirrel.Eq{keyProductPriceID: input.ID}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var before dto.ProductPriceModel
+	err = trx.QueryRow(ctx, sqlStatement, args...).
+		Scan(&before.ID, &before.ProductID, &before.OutletID, &before.BuyPrice, &before.SellPrice, &before.UpdatedAt)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat queryRow product price (EditCustomPrice:0)", err)
+		return nil, sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- edit harga
 	timeNow := time.Now().Unix()
-	sqlStatement, args, err := p.sb.Update(keyProductPriceTable).
+	sqlStatement, args, err = p.sb.Update(keyProductPriceTable).
 		SetMap(squirrel.Eq{
 			keyProductPriceBuy:  input.BuyPrice,
 			keyProductPriceSell: input.SellPrice,
 			keyUpdatedAt:        timeNow,
 		}).
 		Where(squirrel.Eq{keyProductPriceID: input.ID}).
-		Suffix(dao.Returning(keyProductPriceID)).
+		Suffix(dao.Returning(keyProductPriceID, keyProductPriceProductID, keyProductPriceOutletID, keyProductPriceBuy, keyProductPriceSell, keyUpdatedAt)).
 		ToSql()
 
 	if err != nil {
-		logger.Error("error saat edit product price(EditCustomPrice:0)", err)
+		logger.ErrorCtx(ctx, "error saat edit product price(EditCustomPrice:1)", err)
 		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
 	}
 
-	var createdID string
-	err = p.db.QueryRow(ctx, sqlStatement, args...).Scan(&createdID)
+	var after dto.ProductPriceModel
+	err = trx.QueryRow(ctx, sqlStatement, args...).
+		Scan(&after.ID, &after.ProductID, &after.OutletID, &after.BuyPrice, &after.SellPrice, &after.UpdatedAt)
 	if err != nil {
-		logger.Error("error saat queryRow product (EditCustomPrice:1)", err)
+		logger.ErrorCtx(ctx, "error saat queryRow product (EditCustomPrice:2)", err)
 		return nil, sql_err.ParseError(err)
 	}
 
+	// ------------------------------------------------------------- audit
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		EntityType: audit.EntityProductPrice,
+		EntityID:   after.ProductID,
+		Action:     audit.ActionEdit,
+		Before:     before,
+		After:      after,
+	}); err != nil {
+		return nil, err
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
 	res, apiErr := p.GetWithCustomPriceOutlet(ctx, input.ProductID, input.OutletID)
-	if err != nil {
-		logger.Error("error saat GetWithCustomPriceOutlet (EditCustomPrice:2)", err)
+	if apiErr != nil {
+		logger.ErrorCtx(ctx, "error saat GetWithCustomPriceOutlet (EditCustomPrice:3)", apiErr)
 		return nil, apiErr
 	}
 
@@ -399,10 +655,10 @@ func (p *productDao) GetPriceDataWithID(ctx context.Context, priceID string) (*d
 	}
 
 	var res dto.ProductPriceModel
-	err = db.DB.QueryRow(ctx, sqlStatement, args...).
+	err = p.db.QueryRow(ctx, sqlStatement, args...).
 		Scan(&res.ID, &res.ProductID, &res.OutletID, &res.BuyPrice, &res.SellPrice, &res.UpdatedAt)
 	if err != nil {
-		logger.Error("error saat queryRow(GetPriceWithID:0)", err)
+		logger.ErrorCtx(ctx, "error saat queryRow(GetPriceWithID:0)", err)
 		return nil, sql_err.ParseError(err)
 	}
 
@@ -428,9 +684,9 @@ func (p *productDao) FindCustomPriceOutlet(ctx context.Context, outletID int) ([
 		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
 	}
 
-	rows, err := db.DB.Query(ctx, sqlStatement, args...)
+	rows, err := p.db.Query(ctx, sqlStatement, args...)
 	if err != nil {
-		logger.Error("error saat query custom price(FindCustomPriceOutlet:0)", err)
+		logger.ErrorCtx(ctx, "error saat query custom price(FindCustomPriceOutlet:0)", err)
 		return nil, rest_err.NewInternalServerError("gagal mendapatkan daftar custom price", err)
 	}
 	defer rows.Close()
@@ -440,7 +696,7 @@ func (p *productDao) FindCustomPriceOutlet(ctx context.Context, outletID int) ([
 		price := dto.ProductPriceModel{}
 		err := rows.Scan(&price.ID, &price.ProductID, &price.OutletID, &price.BuyPrice, &price.SellPrice, &price.UpdatedAt)
 		if err != nil {
-			logger.Error("error saat parsing product(FindCustomPriceOutlet:1)", err)
+			logger.ErrorCtx(ctx, "error saat parsing product(FindCustomPriceOutlet:1)", err)
 			return nil, sql_err.ParseError(err)
 		}
 
@@ -0,0 +1,64 @@
+package product_dao
+
+import (
+	"context"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"github.com/muchlist/mini_pos/dao"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/utils/sql_err"
+)
+
+// productAudit kolom product yang dicatat pada audit log, field turunan seperti harga setelah pajak tidak ikut
+type productAudit struct {
+	ID              int                 `json:"id"`
+	MerchantID      int                 `json:"merchant_id"`
+	Code            dto.UppercaseString `json:"code"`
+	Name            dto.UppercaseString `json:"name"`
+	MasterBuyPrice  int                 `json:"master_buy_price"`
+	MasterSellPrice int                 `json:"master_sell_price"`
+	TaxID           int                 `json:"tax_id"`
+	Image           string              `json:"image"`
+}
+
+func newProductAudit(p dto.ProductModel) productAudit {
+	return productAudit{
+		ID:              p.ID,
+		MerchantID:      p.MerchantID,
+		Code:            p.Code,
+		Name:            p.Name,
+		MasterBuyPrice:  p.MasterBuyPrice,
+		MasterSellPrice: p.MasterSellPrice,
+		TaxID:           p.TaxID,
+		Image:           p.Image,
+	}
+}
+
+// getForUpdate membaca dan mengunci product di dalam transaksi, merchantFilter 0 berarti tanpa filter merchant
+func (p *productDao) getForUpdate(ctx context.Context, trx pgx.Tx, id int, merchantFilter int) (*dto.ProductModel, rest_err.APIError) {
+	where := squirrel.And{squirrel.Eq{keyProID: id}}
+	if merchantFilter != 0 {
+		where = append(where, squirrel.Eq{keyProMerchID: merchantFilter})
+	}
+	sqlStatement, args, err := p.sb.Select(keyProID, keyProMerchID, keyProCode, keyProName, keyProDefBuy, keyProDefSell, keyProImage, keyProTaxID, keyCreatedAt, keyUpdatedAt).
+		From(keyProductTable).
+		Where(where).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var res dto.ProductModel
+	err = trx.QueryRow(ctx, sqlStatement, args...).
+		Scan(&res.ID, &res.MerchantID, &res.Code, &res.Name, &res.MasterBuyPrice, &res.MasterSellPrice, &res.Image, &res.TaxID, &res.CreatedAt, &res.UpdatedAt)
+	if err != nil {
+		if err != pgx.ErrNoRows {
+			logger.ErrorCtx(ctx, "error saat get product for update(getForUpdate:0)", err)
+		}
+		return nil, sql_err.ParseError(err)
+	}
+	return &res, nil
+}
@@ -0,0 +1,210 @@
+package refresh_token_dao
+
+import (
+	"context"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/muchlist/mini_pos/dao"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/utils/sql_err"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+const (
+	keyRefreshTable = "refresh_tokens"
+	keySessionTable = "sessions"
+	keyID           = "id"
+	keyTokenID      = "jti"
+	keyFamilyID     = "family_id"
+	keyUserID       = "user_id"
+	keyTokenHash    = "token_hash"
+	keyCreatedAt    = "created_at"
+	keyExpiredAt    = "expired_at"
+	keyUsedAt       = "used_at"
+	keyRevokedAt    = "revoked_at"
+)
+
+type refreshTokenDao struct {
+	db *dao.Pool
+	sb squirrel.StatementBuilderType
+}
+
+func New(db *pgxpool.Pool) RefreshTokenDaoAssumer {
+	return &refreshTokenDao{
+		db: dao.NewPool(db),
+		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func invalidRefreshErr(cause string) rest_err.APIError {
+	return rest_err.NewAPIError("Refresh token tidak valid, silahkan login kembali", http.StatusUnauthorized, "unauthorized", []interface{}{cause})
+}
+
+func (r *refreshTokenDao) Insert(ctx context.Context, input dto.RefreshTokenModel) rest_err.APIError {
+	sqlStatement, args, err := r.insertBuilder(input).ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	if _, err := r.db.Exec(ctx, sqlStatement, args...); err != nil {
+		logger.ErrorCtx(ctx, "error saat exec refresh token (Insert:0)", err)
+		return sql_err.ParseError(err)
+	}
+
+	return nil
+}
+
+// Rotate menandai refresh token sebagai terpakai lalu menyimpan penggantinya pada family yang sama.
+// Apabila token yang dikirim sudah pernah dirotasi (reuse), seluruh family dicabut karena
+// kemungkinan token telah dicuri
+func (r *refreshTokenDao) Rotate(ctx context.Context, tokenID string, tokenHash string, next dto.RefreshTokenModel) rest_err.APIError {
+
+	// ------------------------------------------------------------- begin
+	trx, err := r.db.Begin(ctx)
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	timeNow := time.Now().Unix()
+
+	// ------------------------------------------------------------- kunci token lama
+	sqlStatement, args, err := r.sb.Select(keyFamilyID, keyUserID, keyTokenHash, keyExpiredAt, keyUsedAt, keyRevokedAt).
+		From(keyRefreshTable).
+		Where(squirrel.Eq{keyTokenID: tokenID}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var current dto.RefreshTokenModel
+	err = trx.QueryRow(ctx, sqlStatement, args...).
+		Scan(&current.FamilyID, &current.UserID, &current.TokenHash, &current.ExpiredAt, &current.UsedAt, &current.RevokedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return invalidRefreshErr("token not found")
+		}
+		logger.ErrorCtx(ctx, "error saat trx query refresh token (Rotate:0)", err)
+		return sql_err.ParseError(err)
+	}
+
+	if current.TokenHash != tokenHash || current.UserID != next.UserID || current.FamilyID != next.FamilyID {
+		return invalidRefreshErr("token mismatch")
+	}
+	if current.RevokedAt != 0 {
+		return invalidRefreshErr("token revoked")
+	}
+	if current.ExpiredAt <= timeNow {
+		return invalidRefreshErr("token expired")
+	}
+
+	// ------------------------------------------------------------- reuse terdeteksi
+	if current.UsedAt != 0 {
+		if err := r.revoke(ctx, trx, squirrel.Eq{keyFamilyID: current.FamilyID}, timeNow); err != nil {
+			return err
+		}
+		// sesi dengan id yang sama dengan family ikut diakhiri agar access token tidak diterima lagi
+		sqlStatement, args, err = r.sb.Update(keySessionTable).
+			Set(keyRevokedAt, timeNow).
+			Where(squirrel.And{
+				squirrel.Eq{keyID: current.FamilyID},
+				squirrel.Eq{keyRevokedAt: 0},
+			}).
+			ToSql()
+		if err != nil {
+			return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+		}
+		if _, err := trx.Exec(ctx, sqlStatement, args...); err != nil {
+			logger.ErrorCtx(ctx, "error saat trx exec session (Rotate:3)", err)
+			return sql_err.ParseError(err)
+		}
+		if err := trx.Commit(ctx); err != nil {
+			return rest_err.NewInternalServerError(dao.ErrCommit, err)
+		}
+		logger.WarnCtx(ctx, "refresh token reuse terdeteksi, family dan sesi dicabut",
+			zap.Int("user_id", current.UserID),
+			zap.String("family_id", current.FamilyID))
+		return invalidRefreshErr("token reused")
+	}
+
+	// ------------------------------------------------------------- tandai token lama terpakai
+	sqlStatement, args, err = r.sb.Update(keyRefreshTable).
+		Set(keyUsedAt, timeNow).
+		Where(squirrel.Eq{keyTokenID: tokenID}).
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	if _, err := trx.Exec(ctx, sqlStatement, args...); err != nil {
+		logger.ErrorCtx(ctx, "error saat trx exec refresh token (Rotate:1)", err)
+		return sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- simpan token pengganti
+	sqlStatement, args, err = r.insertBuilder(next).ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	if _, err := trx.Exec(ctx, sqlStatement, args...); err != nil {
+		logger.ErrorCtx(ctx, "error saat trx exec refresh token (Rotate:2)", err)
+		return sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
+	return nil
+}
+
+// RevokeFamily mencabut seluruh refresh token pada satu family (logout satu perangkat)
+func (r *refreshTokenDao) RevokeFamily(ctx context.Context, userID int, familyID string) rest_err.APIError {
+	return r.revoke(ctx, r.db, squirrel.And{
+		squirrel.Eq{keyUserID: userID},
+		squirrel.Eq{keyFamilyID: familyID},
+	}, time.Now().Unix())
+}
+
+// RevokeAllByUser mencabut seluruh refresh token milik user (logout semua perangkat)
+func (r *refreshTokenDao) RevokeAllByUser(ctx context.Context, userID int) rest_err.APIError {
+	return r.revoke(ctx, r.db, squirrel.Eq{keyUserID: userID}, time.Now().Unix())
+}
+
+// execer dipenuhi oleh pgxpool.Pool maupun pgx.Tx
+type execer interface {
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+}
+
+func (r *refreshTokenDao) revoke(ctx context.Context, exec execer, where squirrel.Sqlizer, timeNow int64) rest_err.APIError {
+	sqlStatement, args, err := r.sb.Update(keyRefreshTable).
+		Set(keyRevokedAt, timeNow).
+		Where(squirrel.And{where, squirrel.Eq{keyRevokedAt: 0}}).
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	if _, err := exec.Exec(ctx, sqlStatement, args...); err != nil {
+		logger.ErrorCtx(ctx, "error saat exec revoke refresh token (revoke:0)", err)
+		return sql_err.ParseError(err)
+	}
+	return nil
+}
+
+func (r *refreshTokenDao) insertBuilder(input dto.RefreshTokenModel) squirrel.InsertBuilder {
+	return r.sb.Insert(keyRefreshTable).
+		Columns(keyTokenID, keyFamilyID, keyUserID, keyTokenHash, keyCreatedAt, keyExpiredAt).
+		Values(input.TokenID, input.FamilyID, input.UserID, input.TokenHash, input.CreatedAt, input.ExpiredAt)
+}
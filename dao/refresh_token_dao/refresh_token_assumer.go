@@ -0,0 +1,18 @@
+package refresh_token_dao
+
+import (
+	"context"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+)
+
+type RefreshTokenDaoAssumer interface {
+	RefreshTokenSaver
+}
+
+type RefreshTokenSaver interface {
+	Insert(ctx context.Context, input dto.RefreshTokenModel) rest_err.APIError
+	Rotate(ctx context.Context, tokenID string, tokenHash string, next dto.RefreshTokenModel) rest_err.APIError
+	RevokeFamily(ctx context.Context, userID int, familyID string) rest_err.APIError
+	RevokeAllByUser(ctx context.Context, userID int) rest_err.APIError
+}
@@ -0,0 +1,327 @@
+package role_dao
+
+import (
+	"context"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/muchlist/mini_pos/dao"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/audit"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/utils/sql_err"
+	"time"
+)
+
+const (
+	keyRoleTable   = "merchant_roles"
+	keyID          = "id"
+	keyMerchantID  = "merchant_id"
+	keyName        = "name"
+	keyPermissions = "permissions"
+	keyCreatedAt   = "created_at"
+	keyUpdatedAt   = "updated_at"
+
+	keyUserTable        = "users"
+	keyUserRole         = "role"
+	keyUserCustomRoleID = "custom_role_id"
+
+	// systemMerchant merchant_id untuk role bawaan
+	systemMerchant = 0
+)
+
+type roleDao struct {
+	db *dao.Pool
+	sb squirrel.StatementBuilderType
+}
+
+func New(db *pgxpool.Pool) RoleDaoAssumer {
+	return &roleDao{
+		db: dao.NewPool(db),
+		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *roleDao) Insert(ctx context.Context, input dto.RoleModel) (int, rest_err.APIError) {
+	trx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	timeNow := time.Now().Unix()
+
+	// ------------------------------------------------------------- insert role
+	sqlStatement, args, err := r.sb.Insert(keyRoleTable).
+		Columns(keyMerchantID, keyName, keyPermissions, keyCreatedAt, keyUpdatedAt).
+		Values(input.MerchantID, input.Name, input.Permissions, timeNow, timeNow).
+		Suffix(dao.Returning(keyID)).
+		ToSql()
+	if err != nil {
+		return 0, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var createdID int
+	err = trx.QueryRow(ctx, sqlStatement, args...).Scan(&createdID)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat queryRow role(Insert:0)", err)
+		return 0, sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- audit
+	input.ID = createdID
+	input.CreatedAt = timeNow
+	input.UpdatedAt = timeNow
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		MerchantID: input.MerchantID,
+		EntityType: audit.EntityRole,
+		EntityID:   createdID,
+		Action:     audit.ActionCreate,
+		After:      input,
+	}); err != nil {
+		return 0, err
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return 0, rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
+	return createdID, nil
+}
+
+// Edit mengubah custom role, role bawaan tidak dapat diubah karena memiliki merchant_id 0
+func (r *roleDao) Edit(ctx context.Context, input dto.RoleEditModel) (*dto.RoleModel, rest_err.APIError) {
+	trx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	// ------------------------------------------------------------- data sebelum perubahan
+	sqlStatement, args, err := r.sb.Select(keyID, keyMerchantID, keyName, keyPermissions, keyCreatedAt, keyUpdatedAt).
+		From(keyRoleTable).
+		Where(squirrel.And{
+			squirrel.Eq{keyID: input.WhereID},
+			squirrel.Eq{keyMerchantID: input.WhereMerchantID}}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var before dto.RoleModel
+	err = trx.QueryRow(ctx, sqlStatement, args...).
+		Scan(&before.ID, &before.MerchantID, &before.Name, &before.Permissions, &before.CreatedAt, &before.UpdatedAt)
+	if err != nil {
+		return nil, sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- edit role
+	sqlStatement, args, err = r.sb.Update(keyRoleTable).
+		SetMap(squirrel.Eq{
+			keyName:        input.Name,
+			keyPermissions: input.Permissions,
+			keyUpdatedAt:   input.UpdatedAt,
+		}).
+		Where(squirrel.And{
+			squirrel.Eq{keyID: input.WhereID},
+			squirrel.Eq{keyMerchantID: input.WhereMerchantID}}).
+		Suffix(dao.Returning(keyID, keyMerchantID, keyName, keyPermissions, keyCreatedAt, keyUpdatedAt)).
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var res dto.RoleModel
+	err = trx.QueryRow(ctx, sqlStatement, args...).
+		Scan(&res.ID, &res.MerchantID, &res.Name, &res.Permissions, &res.CreatedAt, &res.UpdatedAt)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat queryRow role(Edit:0)", err)
+		return nil, sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- audit
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		MerchantID: res.MerchantID,
+		EntityType: audit.EntityRole,
+		EntityID:   res.ID,
+		Action:     audit.ActionEdit,
+		Before:     before,
+		After:      res,
+	}); err != nil {
+		return nil, err
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
+	return &res, nil
+}
+
+// Delete menghapus custom role, user yang memakai role tersebut kembali memakai permission role bawaan
+func (r *roleDao) Delete(ctx context.Context, id int, filterMerchant int) rest_err.APIError {
+
+	// ------------------------------------------------------------- begin
+	trx, err := r.db.Begin(ctx)
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	// ------------------------------------------------------------- reset custom role user
+	sqlStatement, args, err := r.sb.Update(keyUserTable).
+		Set(keyUserCustomRoleID, 0).
+		Where(squirrel.And{
+			squirrel.Eq{keyUserCustomRoleID: id},
+			squirrel.Eq{keyMerchantID: filterMerchant},
+		}).
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	if _, err := trx.Exec(ctx, sqlStatement, args...); err != nil {
+		logger.ErrorCtx(ctx, "error saat trx exec role(Delete:0)", err)
+		return sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- delete role
+	sqlStatement, args, err = r.sb.Delete(keyRoleTable).
+		Where(squirrel.And{
+			squirrel.Eq{keyID: id},
+			squirrel.Eq{keyMerchantID: filterMerchant},
+		}).
+		Suffix(dao.Returning(keyID, keyMerchantID, keyName, keyPermissions, keyCreatedAt, keyUpdatedAt)).
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var deleted dto.RoleModel
+	err = trx.QueryRow(ctx, sqlStatement, args...).
+		Scan(&deleted.ID, &deleted.MerchantID, &deleted.Name, &deleted.Permissions, &deleted.CreatedAt, &deleted.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return rest_err.NewBadRequestError(fmt.Sprintf("Role dengan id %d tidak ditemukan", id))
+		}
+		logger.ErrorCtx(ctx, "error saat trx exec role(Delete:1)", err)
+		return sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- audit
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		MerchantID: filterMerchant,
+		EntityType: audit.EntityRole,
+		EntityID:   id,
+		Action:     audit.ActionDelete,
+		Before:     deleted,
+	}); err != nil {
+		return err
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
+	return nil
+}
+
+func (r *roleDao) Get(ctx context.Context, id int, merchantFilter int) (*dto.RoleModel, rest_err.APIError) {
+	sqlStatement, args, err := r.sb.Select(keyID, keyMerchantID, keyName, keyPermissions, keyCreatedAt, keyUpdatedAt).
+		From(keyRoleTable).
+		Where(squirrel.And{
+			squirrel.Eq{keyID: id},
+			squirrel.Eq{keyMerchantID: merchantFilter},
+		}).
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var res dto.RoleModel
+	err = r.db.QueryRow(ctx, sqlStatement, args...).
+		Scan(&res.ID, &res.MerchantID, &res.Name, &res.Permissions, &res.CreatedAt, &res.UpdatedAt)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat get role(Get:0)", err)
+		return nil, sql_err.ParseError(err)
+	}
+
+	return &res, nil
+}
+
+// FindByMerchant mengembalikan role bawaan diikuti custom role milik merchant
+func (r *roleDao) FindByMerchant(ctx context.Context, merchantFilter int) ([]dto.RoleModel, rest_err.APIError) {
+	sqlStatement, args, err := r.sb.Select(keyID, keyMerchantID, keyName, keyPermissions, keyCreatedAt, keyUpdatedAt).
+		From(keyRoleTable).
+		Where(squirrel.Eq{keyMerchantID: []int{systemMerchant, merchantFilter}}).
+		OrderBy(keyMerchantID+" ASC", keyName+" ASC").
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	rows, err := r.db.Query(ctx, sqlStatement, args...)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat query role(FindByMerchant:0)", err)
+		return nil, rest_err.NewInternalServerError("gagal mendapatkan daftar role", err)
+	}
+	defer rows.Close()
+
+	roleList := make([]dto.RoleModel, 0)
+	for rows.Next() {
+		role := dto.RoleModel{}
+		err := rows.Scan(&role.ID, &role.MerchantID, &role.Name, &role.Permissions, &role.CreatedAt, &role.UpdatedAt)
+		if err != nil {
+			logger.ErrorCtx(ctx, "error saat parsing role(FindByMerchant:1)", err)
+			return nil, sql_err.ParseError(err)
+		}
+		roleList = append(roleList, role)
+	}
+
+	return roleList, nil
+}
+
+// GetUserPermissions mengembalikan permission dari custom role user apabila ada,
+// jika tidak memakai role bawaan sesuai kolom role user. User tanpa role yang cocok
+// (misalnya super) mendapatkan daftar kosong
+func (r *roleDao) GetUserPermissions(ctx context.Context, userID int) ([]string, rest_err.APIError) {
+	sqlStatement, args, err := r.sb.Select(dao.B(keyPermissions)).
+		From(keyUserTable + " A").
+		Join(fmt.Sprintf("%s B ON (%s = %s AND %s = %s) OR (%s = %d AND %s = %s::text)",
+			keyRoleTable,
+			dao.B(keyID), dao.A(keyUserCustomRoleID),
+			dao.B(keyMerchantID), dao.A(keyMerchantID),
+			dao.B(keyMerchantID), systemMerchant,
+			dao.B(keyName), dao.A(keyUserRole))).
+		Where(squirrel.Eq{dao.A(keyID): userID}).
+		OrderBy(dao.B(keyMerchantID) + " DESC").
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var perms []string
+	err = r.db.QueryRow(ctx, sqlStatement, args...).Scan(&perms)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return []string{}, nil
+		}
+		logger.ErrorCtx(ctx, "error saat queryRow role(GetUserPermissions:0)", err)
+		return nil, sql_err.ParseError(err)
+	}
+
+	return perms, nil
+}
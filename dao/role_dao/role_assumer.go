@@ -0,0 +1,24 @@
+package role_dao
+
+import (
+	"context"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+)
+
+type RoleDaoAssumer interface {
+	RoleSaver
+	RoleLoader
+}
+
+type RoleSaver interface {
+	Insert(ctx context.Context, input dto.RoleModel) (int, rest_err.APIError)
+	Edit(ctx context.Context, input dto.RoleEditModel) (*dto.RoleModel, rest_err.APIError)
+	Delete(ctx context.Context, id int, filterMerchant int) rest_err.APIError
+}
+
+type RoleLoader interface {
+	Get(ctx context.Context, id int, merchantFilter int) (*dto.RoleModel, rest_err.APIError)
+	FindByMerchant(ctx context.Context, merchantFilter int) ([]dto.RoleModel, rest_err.APIError)
+	GetUserPermissions(ctx context.Context, userID int) ([]string, rest_err.APIError)
+}
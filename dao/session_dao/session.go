@@ -0,0 +1,222 @@
+package session_dao
+
+import (
+	"context"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/muchlist/mini_pos/dao"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/utils/sql_err"
+	"time"
+)
+
+const (
+	keySessionTable = "sessions"
+	keyID           = "id"
+	keyUserID       = "user_id"
+	keyUserAgent    = "user_agent"
+	keyIP           = "ip"
+	keyOutletID     = "outlet_id"
+	keyDeviceID     = "device_id"
+	keyCreatedAt    = "created_at"
+	keyLastSeenAt   = "last_seen_at"
+	keyExpiredAt    = "expired_at"
+	keyRevokedAt    = "revoked_at"
+)
+
+type sessionDao struct {
+	db *dao.Pool
+	sb squirrel.StatementBuilderType
+}
+
+func New(db *pgxpool.Pool) SessionDaoAssumer {
+	return &sessionDao{
+		db: dao.NewPool(db),
+		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (s *sessionDao) Insert(ctx context.Context, input dto.SessionModel) rest_err.APIError {
+	sqlStatement, args, err := s.sb.Insert(keySessionTable).
+		Columns(keyID, keyUserID, keyUserAgent, keyIP, keyOutletID, keyDeviceID, keyCreatedAt, keyLastSeenAt, keyExpiredAt).
+		Values(input.ID, input.UserID, input.UserAgent, input.IP, input.OutletID, input.DeviceID, input.CreatedAt, input.LastSeenAt, input.ExpiredAt).
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	if _, err := s.db.Exec(ctx, sqlStatement, args...); err != nil {
+		logger.ErrorCtx(ctx, "error saat exec session (Insert:0)", err)
+		return sql_err.ParseError(err)
+	}
+
+	return nil
+}
+
+// Touch memperbarui waktu terakhir sesi dipakai ketika refresh token dirotasi,
+// sesi yang sudah diakhiri tidak dapat diperbarui. Mengembalikan outlet aktif sesi
+func (s *sessionDao) Touch(ctx context.Context, sessionID string, lastSeenAt int64, expiredAt int64) (int, rest_err.APIError) {
+	sqlStatement, args, err := s.sb.Update(keySessionTable).
+		SetMap(squirrel.Eq{
+			keyLastSeenAt: lastSeenAt,
+			keyExpiredAt:  expiredAt,
+		}).
+		Where(squirrel.And{
+			squirrel.Eq{keyID: sessionID},
+			squirrel.Eq{keyRevokedAt: 0},
+		}).
+		Suffix(dao.Returning(keyOutletID)).
+		ToSql()
+	if err != nil {
+		return 0, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var outletID int
+	err = s.db.QueryRow(ctx, sqlStatement, args...).Scan(&outletID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, rest_err.NewUnauthorizedError("Sesi sudah berakhir, silahkan login kembali")
+		}
+		logger.ErrorCtx(ctx, "error saat queryRow session (Touch:0)", err)
+		return 0, sql_err.ParseError(err)
+	}
+
+	return outletID, nil
+}
+
+// SetOutlet mengganti outlet aktif sesi milik user, refresh berikutnya memakai outlet ini
+func (s *sessionDao) SetOutlet(ctx context.Context, userID int, sessionID string, outletID int) rest_err.APIError {
+	sqlStatement, args, err := s.sb.Update(keySessionTable).
+		Set(keyOutletID, outletID).
+		Where(squirrel.And{
+			squirrel.Eq{keyID: sessionID},
+			squirrel.Eq{keyUserID: userID},
+			squirrel.Eq{keyRevokedAt: 0},
+		}).
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	res, err := s.db.Exec(ctx, sqlStatement, args...)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat exec session (SetOutlet:0)", err)
+		return sql_err.ParseError(err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return rest_err.NewUnauthorizedError("Sesi sudah berakhir, silahkan login kembali")
+	}
+
+	return nil
+}
+
+// Revoke mengakhiri satu sesi milik user
+func (s *sessionDao) Revoke(ctx context.Context, userID int, sessionID string) rest_err.APIError {
+	sqlStatement, args, err := s.sb.Update(keySessionTable).
+		Set(keyRevokedAt, time.Now().Unix()).
+		Where(squirrel.And{
+			squirrel.Eq{keyID: sessionID},
+			squirrel.Eq{keyUserID: userID},
+			squirrel.Eq{keyRevokedAt: 0},
+		}).
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	res, err := s.db.Exec(ctx, sqlStatement, args...)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat exec session (Revoke:0)", err)
+		return sql_err.ParseError(err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return rest_err.NewBadRequestError(fmt.Sprintf("Sesi %s tidak ditemukan atau sudah berakhir", sessionID))
+	}
+
+	return nil
+}
+
+// RevokeAllByUser mengakhiri seluruh sesi milik user
+func (s *sessionDao) RevokeAllByUser(ctx context.Context, userID int) rest_err.APIError {
+	sqlStatement, args, err := s.sb.Update(keySessionTable).
+		Set(keyRevokedAt, time.Now().Unix()).
+		Where(squirrel.And{
+			squirrel.Eq{keyUserID: userID},
+			squirrel.Eq{keyRevokedAt: 0},
+		}).
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	if _, err := s.db.Exec(ctx, sqlStatement, args...); err != nil {
+		logger.ErrorCtx(ctx, "error saat exec session (RevokeAllByUser:0)", err)
+		return sql_err.ParseError(err)
+	}
+
+	return nil
+}
+
+// FindActiveByUser daftar sesi user yang belum diakhiri dan belum kadaluarsa
+func (s *sessionDao) FindActiveByUser(ctx context.Context, userID int) ([]dto.SessionModel, rest_err.APIError) {
+	sqlStatement, args, err := s.sb.Select(keyID, keyUserID, keyUserAgent, keyIP, keyOutletID, keyDeviceID, keyCreatedAt, keyLastSeenAt, keyExpiredAt).
+		From(keySessionTable).
+		Where(squirrel.And{
+			squirrel.Eq{keyUserID: userID},
+			squirrel.Eq{keyRevokedAt: 0},
+			squirrel.Gt{keyExpiredAt: time.Now().Unix()},
+		}).
+		OrderBy(keyLastSeenAt + " DESC").
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	rows, err := s.db.Query(ctx, sqlStatement, args...)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat query session (FindActiveByUser:0)", err)
+		return nil, rest_err.NewInternalServerError("gagal mendapatkan daftar sesi", err)
+	}
+	defer rows.Close()
+
+	sessions := make([]dto.SessionModel, 0)
+	for rows.Next() {
+		session := dto.SessionModel{}
+		err := rows.Scan(&session.ID, &session.UserID, &session.UserAgent, &session.IP, &session.OutletID, &session.DeviceID, &session.CreatedAt, &session.LastSeenAt, &session.ExpiredAt)
+		if err != nil {
+			return nil, sql_err.ParseError(err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+// IsActive dipakai middleware untuk menolak access token dari sesi yang sudah diakhiri
+func (s *sessionDao) IsActive(ctx context.Context, sessionID string) (bool, rest_err.APIError) {
+	sqlStatement, args, err := s.sb.Select(keyRevokedAt).
+		From(keySessionTable).
+		Where(squirrel.Eq{keyID: sessionID}).
+		ToSql()
+	if err != nil {
+		return false, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var revokedAt int64
+	err = s.db.QueryRow(ctx, sqlStatement, args...).Scan(&revokedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return false, nil
+		}
+		logger.ErrorCtx(ctx, "error saat query session (IsActive:0)", err)
+		return false, sql_err.ParseError(err)
+	}
+
+	return revokedAt == 0, nil
+}
@@ -0,0 +1,25 @@
+package session_dao
+
+import (
+	"context"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+)
+
+type SessionDaoAssumer interface {
+	SessionSaver
+	SessionLoader
+}
+
+type SessionSaver interface {
+	Insert(ctx context.Context, input dto.SessionModel) rest_err.APIError
+	Touch(ctx context.Context, sessionID string, lastSeenAt int64, expiredAt int64) (int, rest_err.APIError)
+	SetOutlet(ctx context.Context, userID int, sessionID string, outletID int) rest_err.APIError
+	Revoke(ctx context.Context, userID int, sessionID string) rest_err.APIError
+	RevokeAllByUser(ctx context.Context, userID int) rest_err.APIError
+}
+
+type SessionLoader interface {
+	FindActiveByUser(ctx context.Context, userID int) ([]dto.SessionModel, rest_err.APIError)
+	IsActive(ctx context.Context, sessionID string) (bool, rest_err.APIError)
+}
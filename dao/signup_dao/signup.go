@@ -0,0 +1,245 @@
+package signup_dao
+
+import (
+	"context"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/muchlist/mini_pos/configs/roles"
+	"github.com/muchlist/mini_pos/dao"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/audit"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/utils/sql_err"
+	"time"
+)
+
+const (
+	keySignupTable    = "merchant_signups"
+	keyID             = "id"
+	keyMerchantName   = "merchant_name"
+	keyDescription    = "description"
+	keyOwnerName      = "owner_name"
+	keyOwnerEmail     = "owner_email"
+	keyTokenHash      = "token_hash"
+	keyCreatedAt      = "created_at"
+	keyUpdatedAt      = "updated_at"
+	keyExpiredAt      = "expired_at"
+	keyVerifiedAt     = "verified_at"
+	keyMerchantTable  = "merchant"
+	keyOutletTable    = "outlets"
+	keyOutletMerchant = "merchant_id"
+	keyOutletName     = "outlet_name"
+	keyOutletAddress  = "address"
+	keyUserTable      = "users"
+	keyUserMerchantID = "merchant_id"
+	keyUserDefOutlet  = "def_outlet"
+	keyUserName       = "name"
+	keyUserEmail      = "email"
+	keyUserPassword   = "password"
+	keyUserRole       = "role"
+
+	keyUserOutletTable    = "user_outlets"
+	keyUserOutletUserID   = "user_id"
+	keyUserOutletOutletID = "outlet_id"
+)
+
+type signupDao struct {
+	db *dao.Pool
+	sb squirrel.StatementBuilderType
+}
+
+func New(db *pgxpool.Pool) SignupDaoAssumer {
+	return &signupDao{
+		db: dao.NewPool(db),
+		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// Insert menyimpan pendaftaran merchant yang belum terverifikasi
+func (s *signupDao) Insert(ctx context.Context, input dto.MerchantSignup) (int, rest_err.APIError) {
+	sqlStatement, args, err := s.sb.Insert(keySignupTable).
+		Columns(keyMerchantName, keyDescription, keyOwnerName, keyOwnerEmail, keyTokenHash, keyCreatedAt, keyExpiredAt).
+		Values(input.MerchantName, input.Description, input.OwnerName, input.OwnerEmail, input.TokenHash, input.CreatedAt, input.ExpiredAt).
+		Suffix(dao.Returning(keyID)).
+		ToSql()
+	if err != nil {
+		return 0, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var createdID int
+	err = s.db.QueryRow(ctx, sqlStatement, args...).Scan(&createdID)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat queryRow signup (Insert:0)", err)
+		return 0, sql_err.ParseError(err)
+	}
+
+	return createdID, nil
+}
+
+// Activate memverifikasi token pendaftaran lalu membuat merchant, outlet pertama dan owner
+// dalam satu transaksi. Outlet pertama ditugaskan kepada owner dan ketiganya dicatat pada audit log
+// seperti pembuatan melalui super user. Token hanya dapat dipakai sekali dan sebelum kadaluarsa
+func (s *signupDao) Activate(ctx context.Context, input dto.SignupActivation) (*dto.MerchantCreateRes, rest_err.APIError) {
+
+	// ------------------------------------------------------------- begin
+	trx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	timeNow := time.Now().Unix()
+
+	// ------------------------------------------------------------- tandai signup terverifikasi
+	sqlStatement, args, err := s.sb.Update(keySignupTable).
+		Set(keyVerifiedAt, timeNow).
+		Where(squirrel.And{
+			squirrel.Eq{keyTokenHash: input.TokenHash},
+			squirrel.Eq{keyVerifiedAt: 0},
+			squirrel.Gt{keyExpiredAt: timeNow},
+		}).
+		Suffix(dao.Returning(keyMerchantName, keyDescription, keyOwnerName, keyOwnerEmail)).
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var signup dto.MerchantSignup
+	err = trx.QueryRow(ctx, sqlStatement, args...).Scan(&signup.MerchantName, &signup.Description, &signup.OwnerName, &signup.OwnerEmail)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, rest_err.NewBadRequestError("Token verifikasi tidak valid atau sudah kadaluarsa")
+		}
+		logger.ErrorCtx(ctx, "error saat trx query signup (Activate:0)", err)
+		return nil, sql_err.ParseError(err)
+	}
+
+	response := dto.MerchantCreateRes{}
+
+	// ------------------------------------------------------------- insert merchant
+	sqlStatement, args, err = s.sb.Insert(keyMerchantTable).
+		Columns(keyMerchantName, keyDescription, keyCreatedAt, keyUpdatedAt).
+		Values(signup.MerchantName, signup.Description, timeNow, timeNow).
+		Suffix(dao.Returning(keyID, keyMerchantName)).
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	err = trx.QueryRow(ctx, sqlStatement, args...).Scan(&response.MerchantID, &response.MerchantName)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat trx query merchant (Activate:1)", err)
+		return nil, sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- insert outlet pertama
+	sqlStatement, args, err = s.sb.Insert(keyOutletTable).
+		Columns(keyOutletMerchant, keyOutletName, keyOutletAddress, keyCreatedAt, keyUpdatedAt).
+		Values(response.MerchantID, input.OutletName, input.OutletAddress, timeNow, timeNow).
+		Suffix(dao.Returning(keyID)).
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var outletID int
+	err = trx.QueryRow(ctx, sqlStatement, args...).Scan(&outletID)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat trx query outlet (Activate:2)", err)
+		return nil, sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- insert owner
+	sqlStatement, args, err = s.sb.Insert(keyUserTable).Columns(
+		keyUserMerchantID,
+		keyUserDefOutlet,
+		keyUserName,
+		keyUserEmail,
+		keyUserPassword,
+		keyCreatedAt,
+		keyUpdatedAt,
+		keyUserRole).
+		Values(response.MerchantID, outletID, signup.OwnerName, signup.OwnerEmail, input.Password, timeNow, timeNow, roles.RoleOwner).
+		Suffix(dao.Returning(keyID, keyUserEmail, keyUserName)).ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var ownerID int
+	err = trx.QueryRow(ctx, sqlStatement, args...).Scan(&ownerID, &response.OwnerEmail, &response.OwnerName)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat trx query users (Activate:3)", err)
+		return nil, rest_err.NewBadRequestError("Email tidak tersedia")
+	}
+
+	// ------------------------------------------------------------- tugaskan outlet pertama kepada owner
+	sqlStatement, args, err = s.sb.Insert(keyUserOutletTable).
+		Columns(keyUserOutletUserID, keyUserOutletOutletID, keyCreatedAt).
+		Values(ownerID, outletID, timeNow).
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	if _, err := trx.Exec(ctx, sqlStatement, args...); err != nil {
+		logger.ErrorCtx(ctx, "error saat trx exec user outlets (Activate:4)", err)
+		return nil, sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- audit
+	entries := []dao.AuditEntry{
+		{
+			MerchantID: response.MerchantID,
+			EntityType: audit.EntityMerchant,
+			EntityID:   response.MerchantID,
+			Action:     audit.ActionCreate,
+			After:      response,
+		},
+		{
+			MerchantID: response.MerchantID,
+			EntityType: audit.EntityOutlet,
+			EntityID:   outletID,
+			Action:     audit.ActionCreate,
+			After: dto.OutletModel{
+				ID:         outletID,
+				MerchantID: response.MerchantID,
+				OutletName: dto.UppercaseString(input.OutletName),
+				Address:    input.OutletAddress,
+				CreatedAt:  timeNow,
+				UpdatedAt:  timeNow,
+			},
+		},
+		{
+			MerchantID: response.MerchantID,
+			EntityType: audit.EntityUser,
+			EntityID:   ownerID,
+			Action:     audit.ActionCreate,
+			After: dto.UserModel{
+				ID:         ownerID,
+				Email:      dto.LowercaseString(response.OwnerEmail),
+				Name:       dto.UppercaseString(response.OwnerName),
+				Role:       roles.RoleOwner,
+				CreatedAt:  timeNow,
+				UpdatedAt:  timeNow,
+				MerchantID: response.MerchantID,
+				DefOutlet:  outletID,
+			},
+		},
+	}
+	for _, entry := range entries {
+		if err := dao.WriteAudit(ctx, trx, entry); err != nil {
+			return nil, err
+		}
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
+	return &response, nil
+}
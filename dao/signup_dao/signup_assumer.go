@@ -0,0 +1,16 @@
+package signup_dao
+
+import (
+	"context"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+)
+
+type SignupDaoAssumer interface {
+	SignupSaver
+}
+
+type SignupSaver interface {
+	Insert(ctx context.Context, input dto.MerchantSignup) (int, rest_err.APIError)
+	Activate(ctx context.Context, input dto.SignupActivation) (*dto.MerchantCreateRes, rest_err.APIError)
+}
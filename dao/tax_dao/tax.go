@@ -0,0 +1,386 @@
+package tax_dao
+
+import (
+	"context"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/muchlist/mini_pos/dao"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/audit"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/utils/sql_err"
+	"time"
+)
+
+const (
+	keyTaxTable   = "taxes"
+	keyID         = "id"
+	keyMerchantID = "merchant_id"
+	keyName       = "name"
+	keyRate       = "rate"
+	keyCreatedAt  = "created_at"
+	keyUpdatedAt  = "updated_at"
+
+	keyMerchantTable        = "merchant"
+	keyMerchantTaxInclusive = "tax_inclusive"
+	keyMerchantTaxRounding  = "tax_rounding"
+
+	keyProductTable = "products"
+	keyProductTaxID = "tax_id"
+)
+
+type taxDao struct {
+	db *dao.Pool
+	sb squirrel.StatementBuilderType
+}
+
+func New(db *pgxpool.Pool) TaxDaoAssumer {
+	return &taxDao{
+		db: dao.NewPool(db),
+		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (t *taxDao) Insert(ctx context.Context, input dto.TaxModel) (int, rest_err.APIError) {
+	trx, err := t.db.Begin(ctx)
+	if err != nil {
+		return 0, rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	timeNow := time.Now().Unix()
+
+	// ------------------------------------------------------------- insert tax
+	sqlStatement, args, err := t.sb.Insert(keyTaxTable).
+		Columns(keyMerchantID, keyName, keyRate, keyCreatedAt, keyUpdatedAt).
+		Values(input.MerchantID, input.Name, input.Rate, timeNow, timeNow).
+		Suffix(dao.Returning(keyID)).
+		ToSql()
+	if err != nil {
+		return 0, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var createdID int
+	err = trx.QueryRow(ctx, sqlStatement, args...).Scan(&createdID)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat queryRow tax (Insert:0)", err)
+		return 0, sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- audit
+	input.ID = createdID
+	input.CreatedAt = timeNow
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		MerchantID: input.MerchantID,
+		EntityType: audit.EntityTax,
+		EntityID:   createdID,
+		Action:     audit.ActionCreate,
+		After:      input,
+	}); err != nil {
+		return 0, err
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return 0, rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
+	return createdID, nil
+}
+
+func (t *taxDao) Edit(ctx context.Context, input dto.TaxEditModel) (*dto.TaxModel, rest_err.APIError) {
+	trx, err := t.db.Begin(ctx)
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	// ------------------------------------------------------------- data sebelum perubahan
+	sqlStatement, args, err := t.sb.Select(keyID, keyMerchantID, keyName, keyRate, keyCreatedAt, keyUpdatedAt).
+		From(keyTaxTable).
+		Where(squirrel.And{
+			squirrel.Eq{keyID: input.WhereID},
+			squirrel.Eq{keyMerchantID: input.WhereMerchantID}}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var before dto.TaxModel
+	err = trx.QueryRow(ctx, sqlStatement, args...).
+		Scan(&before.ID, &before.MerchantID, &before.Name, &before.Rate, &before.CreatedAt, &before.UpdatedAt)
+	if err != nil {
+		return nil, sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- edit tax
+	sqlStatement, args, err = t.sb.Update(keyTaxTable).
+		SetMap(squirrel.Eq{
+			keyName:      input.Name,
+			keyRate:      input.Rate,
+			keyUpdatedAt: input.UpdatedAt,
+		}).
+		Where(squirrel.And{
+			squirrel.Eq{keyID: input.WhereID},
+			squirrel.Eq{keyMerchantID: input.WhereMerchantID}}).
+		Suffix(dao.Returning(keyID, keyMerchantID, keyName, keyRate, keyCreatedAt, keyUpdatedAt)).
+		ToSql()
+
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat edit tax(Edit:0)", err)
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var res dto.TaxModel
+	err = trx.QueryRow(ctx, sqlStatement, args...).
+		Scan(&res.ID, &res.MerchantID, &res.Name, &res.Rate, &res.CreatedAt, &res.UpdatedAt)
+	if err != nil {
+		return nil, sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- audit
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		MerchantID: res.MerchantID,
+		EntityType: audit.EntityTax,
+		EntityID:   res.ID,
+		Action:     audit.ActionEdit,
+		Before:     before,
+		After:      res,
+	}); err != nil {
+		return nil, err
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
+	return &res, nil
+}
+
+// Delete menghapus tax dan mengembalikan product yang memakai tax tersebut menjadi bebas pajak
+func (t *taxDao) Delete(ctx context.Context, id int, filterMerchant int) rest_err.APIError {
+
+	// ------------------------------------------------------------- begin
+	trx, err := t.db.Begin(ctx)
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	// ------------------------------------------------------------- reset product tax
+	sqlStatement, args, err := t.sb.Update(keyProductTable).
+		SetMap(squirrel.Eq{
+			keyProductTaxID: 0,
+		}).
+		Where(squirrel.And{
+			squirrel.Eq{keyProductTaxID: id},
+			squirrel.Eq{keyMerchantID: filterMerchant},
+		}).
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	if _, err := trx.Exec(ctx, sqlStatement, args...); err != nil {
+		logger.ErrorCtx(ctx, "error saat trx exec tax(Delete:0)", err)
+		return sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- delete tax
+	sqlStatement, args, err = t.sb.Delete(keyTaxTable).
+		Where(squirrel.And{
+			squirrel.Eq{keyID: id},
+			squirrel.Eq{keyMerchantID: filterMerchant},
+		}).
+		Suffix(dao.Returning(keyID, keyMerchantID, keyName, keyRate, keyCreatedAt, keyUpdatedAt)).
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var deleted dto.TaxModel
+	err = trx.QueryRow(ctx, sqlStatement, args...).
+		Scan(&deleted.ID, &deleted.MerchantID, &deleted.Name, &deleted.Rate, &deleted.CreatedAt, &deleted.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return rest_err.NewBadRequestError(fmt.Sprintf("Tax dengan id %d tidak ditemukan", id))
+		}
+		logger.ErrorCtx(ctx, "error saat trx exec tax(Delete:1)", err)
+		return sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- audit
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		MerchantID: filterMerchant,
+		EntityType: audit.EntityTax,
+		EntityID:   id,
+		Action:     audit.ActionDelete,
+		Before:     deleted,
+	}); err != nil {
+		return err
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
+	return nil
+}
+
+func (t *taxDao) Get(ctx context.Context, id int, merchantFilter int) (*dto.TaxModel, rest_err.APIError) {
+	sqlStatement, args, err := t.sb.Select(keyID, keyMerchantID, keyName, keyRate, keyCreatedAt, keyUpdatedAt).
+		From(keyTaxTable).
+		Where(squirrel.And{
+			squirrel.Eq{keyID: id},
+			squirrel.Eq{keyMerchantID: merchantFilter},
+		}).
+		ToSql()
+
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var res dto.TaxModel
+	err = t.db.QueryRow(ctx, sqlStatement, args...).
+		Scan(&res.ID, &res.MerchantID, &res.Name, &res.Rate, &res.CreatedAt, &res.UpdatedAt)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat get tax(Get:0)", err)
+		return nil, sql_err.ParseError(err)
+	}
+
+	return &res, nil
+}
+
+// FindByMerchant mengembalikan seluruh tax milik merchant, jumlahnya sedikit sehingga tidak perlu pagination
+func (t *taxDao) FindByMerchant(ctx context.Context, merchantFilter int) ([]dto.TaxModel, rest_err.APIError) {
+	sqlStatement, args, err := t.sb.Select(keyID, keyMerchantID, keyName, keyRate, keyCreatedAt, keyUpdatedAt).
+		From(keyTaxTable).
+		Where(squirrel.Eq{keyMerchantID: merchantFilter}).
+		OrderBy(keyName + " ASC").
+		ToSql()
+
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	rows, err := t.db.Query(ctx, sqlStatement, args...)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat query tax(FindByMerchant:0)", err)
+		return nil, rest_err.NewInternalServerError("gagal mendapatkan daftar tax", err)
+	}
+	defer rows.Close()
+
+	taxes := make([]dto.TaxModel, 0)
+	for rows.Next() {
+		tax := dto.TaxModel{}
+		err := rows.Scan(&tax.ID, &tax.MerchantID, &tax.Name, &tax.Rate, &tax.CreatedAt, &tax.UpdatedAt)
+		if err != nil {
+			logger.ErrorCtx(ctx, "error saat parsing tax(FindByMerchant:1)", err)
+			return nil, sql_err.ParseError(err)
+		}
+		taxes = append(taxes, tax)
+	}
+
+	return taxes, nil
+}
+
+func (t *taxDao) GetSetting(ctx context.Context, merchantID int) (*dto.TaxSetting, rest_err.APIError) {
+	sqlStatement, args, err := t.sb.Select(keyID, keyMerchantTaxInclusive, keyMerchantTaxRounding).
+		From(keyMerchantTable).
+		Where(squirrel.Eq{keyID: merchantID}).
+		ToSql()
+
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var res dto.TaxSetting
+	err = t.db.QueryRow(ctx, sqlStatement, args...).Scan(&res.MerchantID, &res.Inclusive, &res.Rounding)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat get tax setting(GetSetting:0)", err)
+		return nil, sql_err.ParseError(err)
+	}
+
+	return &res, nil
+}
+
+func (t *taxDao) EditSetting(ctx context.Context, input dto.TaxSetting) (*dto.TaxSetting, rest_err.APIError) {
+	trx, err := t.db.Begin(ctx)
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	// ------------------------------------------------------------- data sebelum perubahan
+	sqlStatement, args, err := t.sb.Select(keyID, keyMerchantTaxInclusive, keyMerchantTaxRounding).
+		From(keyMerchantTable).
+		Where(squirrel.Eq{keyID: input.MerchantID}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var before dto.TaxSetting
+	err = trx.QueryRow(ctx, sqlStatement, args...).Scan(&before.MerchantID, &before.Inclusive, &before.Rounding)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat get tax setting(EditSetting:0)", err)
+		return nil, sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- edit setting
+	timeNow := time.Now().Unix()
+	sqlStatement, args, err = t.sb.Update(keyMerchantTable).
+		SetMap(squirrel.Eq{
+			keyMerchantTaxInclusive: input.Inclusive,
+			keyMerchantTaxRounding:  input.Rounding,
+			keyUpdatedAt:            timeNow,
+		}).
+		Where(squirrel.Eq{keyID: input.MerchantID}).
+		Suffix(dao.Returning(keyID, keyMerchantTaxInclusive, keyMerchantTaxRounding)).
+		ToSql()
+
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var res dto.TaxSetting
+	err = trx.QueryRow(ctx, sqlStatement, args...).Scan(&res.MerchantID, &res.Inclusive, &res.Rounding)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat edit tax setting(EditSetting:1)", err)
+		return nil, sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- audit
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		MerchantID: res.MerchantID,
+		EntityType: audit.EntityTaxSetting,
+		EntityID:   res.MerchantID,
+		Action:     audit.ActionEdit,
+		Before:     before,
+		After:      res,
+	}); err != nil {
+		return nil, err
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,25 @@
+package tax_dao
+
+import (
+	"context"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+)
+
+type TaxDaoAssumer interface {
+	TaxSaver
+	TaxLoader
+}
+
+type TaxSaver interface {
+	Insert(ctx context.Context, input dto.TaxModel) (int, rest_err.APIError)
+	Edit(ctx context.Context, input dto.TaxEditModel) (*dto.TaxModel, rest_err.APIError)
+	Delete(ctx context.Context, id int, filterMerchant int) rest_err.APIError
+	EditSetting(ctx context.Context, input dto.TaxSetting) (*dto.TaxSetting, rest_err.APIError)
+}
+
+type TaxLoader interface {
+	Get(ctx context.Context, id int, merchantFilter int) (*dto.TaxModel, rest_err.APIError)
+	FindByMerchant(ctx context.Context, merchantFilter int) ([]dto.TaxModel, rest_err.APIError)
+	GetSetting(ctx context.Context, merchantID int) (*dto.TaxSetting, rest_err.APIError)
+}
@@ -0,0 +1,239 @@
+package totp_dao
+
+import (
+	"context"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/muchlist/mini_pos/dao"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/utils/sql_err"
+	"time"
+)
+
+const (
+	keyTotpTable = "user_totp"
+	keyUserID    = "user_id"
+	keySecret    = "secret"
+	keyEnabledAt = "enabled_at"
+	keyLastStep  = "last_step"
+	keyCreatedAt = "created_at"
+
+	keyRecoveryTable = "totp_recovery_codes"
+	keyCodeHash      = "code_hash"
+	keyUsedAt        = "used_at"
+)
+
+type totpDao struct {
+	db *dao.Pool
+	sb squirrel.StatementBuilderType
+}
+
+func New(db *pgxpool.Pool) TotpDaoAssumer {
+	return &totpDao{
+		db: dao.NewPool(db),
+		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// SetPendingSecret menyimpan secret baru yang belum dikonfirmasi, secret yang sudah aktif tidak ditimpa
+func (t *totpDao) SetPendingSecret(ctx context.Context, input dto.TotpModel) rest_err.APIError {
+	sqlStatement, args, err := t.sb.Insert(keyTotpTable).
+		Columns(keyUserID, keySecret, keyCreatedAt).
+		Values(input.UserID, input.Secret, input.CreatedAt).
+		Suffix(fmt.Sprintf("ON CONFLICT (%s) DO UPDATE SET %s WHERE %s.%s = 0",
+			keyUserID, dao.ExcludedSet(keySecret, keyCreatedAt), keyTotpTable, keyEnabledAt)).
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	res, err := t.db.Exec(ctx, sqlStatement, args...)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat exec totp (SetPendingSecret:0)", err)
+		return sql_err.ParseError(err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return rest_err.NewBadRequestError("2FA sudah aktif, nonaktifkan terlebih dahulu untuk mendaftar ulang")
+	}
+
+	return nil
+}
+
+// Enable mengaktifkan 2FA dan mengganti seluruh recovery code
+func (t *totpDao) Enable(ctx context.Context, userID int, enabledAt int64, recoveryHashes []string) rest_err.APIError {
+	trx, err := t.db.Begin(ctx)
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	// ------------------------------------------------------------- aktifkan
+	sqlStatement, args, err := t.sb.Update(keyTotpTable).
+		Set(keyEnabledAt, enabledAt).
+		Where(squirrel.And{
+			squirrel.Eq{keyUserID: userID},
+			squirrel.Eq{keyEnabledAt: 0},
+		}).
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	res, err := trx.Exec(ctx, sqlStatement, args...)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat trx exec totp (Enable:0)", err)
+		return sql_err.ParseError(err)
+	}
+	if res.RowsAffected() == 0 {
+		return rest_err.NewBadRequestError("Tidak ada pendaftaran 2FA yang menunggu konfirmasi")
+	}
+
+	// ------------------------------------------------------------- ganti recovery code
+	sqlStatement, args, err = t.sb.Delete(keyRecoveryTable).
+		Where(squirrel.Eq{keyUserID: userID}).
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	if _, err := trx.Exec(ctx, sqlStatement, args...); err != nil {
+		logger.ErrorCtx(ctx, "error saat trx exec recovery code (Enable:1)", err)
+		return sql_err.ParseError(err)
+	}
+
+	insertBuilder := t.sb.Insert(keyRecoveryTable).Columns(keyUserID, keyCodeHash)
+	for _, hash := range recoveryHashes {
+		insertBuilder = insertBuilder.Values(userID, hash)
+	}
+	sqlStatement, args, err = insertBuilder.ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	if _, err := trx.Exec(ctx, sqlStatement, args...); err != nil {
+		logger.ErrorCtx(ctx, "error saat trx exec recovery code (Enable:2)", err)
+		return sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
+	return nil
+}
+
+// Disable menghapus secret beserta recovery code
+func (t *totpDao) Disable(ctx context.Context, userID int) rest_err.APIError {
+	trx, err := t.db.Begin(ctx)
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	for i, table := range []string{keyRecoveryTable, keyTotpTable} {
+		sqlStatement, args, err := t.sb.Delete(table).
+			Where(squirrel.Eq{keyUserID: userID}).
+			ToSql()
+		if err != nil {
+			return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+		}
+
+		if _, err := trx.Exec(ctx, sqlStatement, args...); err != nil {
+			logger.ErrorCtx(ctx, fmt.Sprintf("error saat trx exec %s (Disable:%d)", table, i), err)
+			return sql_err.ParseError(err)
+		}
+	}
+
+	if err := trx.Commit(ctx); err != nil {
+		return rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
+	return nil
+}
+
+// SetLastStep mencatat step kode yang dipakai, gagal apabila step tidak lebih baru (kode dipakai ulang)
+func (t *totpDao) SetLastStep(ctx context.Context, userID int, step int64) rest_err.APIError {
+	sqlStatement, args, err := t.sb.Update(keyTotpTable).
+		Set(keyLastStep, step).
+		Where(squirrel.And{
+			squirrel.Eq{keyUserID: userID},
+			squirrel.Lt{keyLastStep: step},
+		}).
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	res, err := t.db.Exec(ctx, sqlStatement, args...)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat exec totp (SetLastStep:0)", err)
+		return sql_err.ParseError(err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return rest_err.NewUnauthorizedError("Kode 2FA sudah pernah dipakai, tunggu kode berikutnya")
+	}
+
+	return nil
+}
+
+// UseRecoveryCode menandai recovery code terpakai, setiap code hanya berlaku sekali
+func (t *totpDao) UseRecoveryCode(ctx context.Context, userID int, codeHash string) rest_err.APIError {
+	sqlStatement, args, err := t.sb.Update(keyRecoveryTable).
+		Set(keyUsedAt, time.Now().Unix()).
+		Where(squirrel.And{
+			squirrel.Eq{keyUserID: userID},
+			squirrel.Eq{keyCodeHash: codeHash},
+			squirrel.Eq{keyUsedAt: 0},
+		}).
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	res, err := t.db.Exec(ctx, sqlStatement, args...)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat exec recovery code (UseRecoveryCode:0)", err)
+		return sql_err.ParseError(err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return rest_err.NewUnauthorizedError("Recovery code tidak valid")
+	}
+
+	return nil
+}
+
+// Get mengembalikan data TOTP user, model kosong apabila user belum pernah mendaftar
+func (t *totpDao) Get(ctx context.Context, userID int) (*dto.TotpModel, rest_err.APIError) {
+	sqlStatement, args, err := t.sb.Select(keyUserID, keySecret, keyEnabledAt, keyLastStep, keyCreatedAt).
+		From(keyTotpTable).
+		Where(squirrel.Eq{keyUserID: userID}).
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var totp dto.TotpModel
+	err = t.db.QueryRow(ctx, sqlStatement, args...).
+		Scan(&totp.UserID, &totp.Secret, &totp.EnabledAt, &totp.LastStep, &totp.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return &dto.TotpModel{UserID: userID}, nil
+		}
+		logger.ErrorCtx(ctx, "error saat queryRow totp (Get:0)", err)
+		return nil, sql_err.ParseError(err)
+	}
+
+	return &totp, nil
+}
@@ -0,0 +1,24 @@
+package totp_dao
+
+import (
+	"context"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+)
+
+type TotpDaoAssumer interface {
+	TotpSaver
+	TotpLoader
+}
+
+type TotpSaver interface {
+	SetPendingSecret(ctx context.Context, input dto.TotpModel) rest_err.APIError
+	Enable(ctx context.Context, userID int, enabledAt int64, recoveryHashes []string) rest_err.APIError
+	Disable(ctx context.Context, userID int) rest_err.APIError
+	SetLastStep(ctx context.Context, userID int, step int64) rest_err.APIError
+	UseRecoveryCode(ctx context.Context, userID int, codeHash string) rest_err.APIError
+}
+
+type TotpLoader interface {
+	Get(ctx context.Context, userID int) (*dto.TotpModel, rest_err.APIError)
+}
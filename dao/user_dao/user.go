@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/muchlist/mini_pos/configs/roles"
 	"github.com/muchlist/mini_pos/dao"
-	"github.com/muchlist/mini_pos/db"
 	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/audit"
 	"github.com/muchlist/mini_pos/utils/logger"
 	"github.com/muchlist/mini_pos/utils/rest_err"
 	"github.com/muchlist/mini_pos/utils/sql_err"
@@ -22,26 +24,53 @@ const (
 	keyUserName       = "name"
 	keyUserEmail      = "email"
 	keyUserPassword   = "password"
+	keyUserPin        = "pin"
+	keyFailedLogin    = "failed_login"
+	keyLockedUntil    = "locked_until"
 	keyUserRole       = "role"
+	keyCustomRoleID   = "custom_role_id"
 	keyCreatedAt      = "created_at"
 	keyUpdatedAt      = "updated_at"
+
+	keyRefreshTable     = "refresh_tokens"
+	keySessionTable     = "sessions"
+	keyRefreshUserID    = "user_id"
+	keyRefreshFamilyID  = "family_id"
+	keySessionID        = "id"
+	keyRefreshRevokedAt = "revoked_at"
+
+	keyOutletTable     = "outlets"
+	keyOutletTableID   = "id"
+	keyUserOutletTable = "user_outlets"
+	keyUserOutletID    = "outlet_id"
 )
 
 type userDao struct {
-	db *pgxpool.Pool
+	db *dao.Pool
 	sb squirrel.StatementBuilderType
 }
 
 func New(db *pgxpool.Pool) UserDaoAssumer {
 	return &userDao{
-		db: db,
+		db: dao.NewPool(db),
 		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
 	}
 }
 
+// Insert menyimpan user baru, outlet default user ikut ditugaskan kepada user tersebut
 func (u userDao) Insert(ctx context.Context, user dto.UserModel) (string, rest_err.APIError) {
 	timeNow := time.Now().Unix()
 
+	// ------------------------------------------------------------- begin
+	trx, err := u.db.Begin(ctx)
+	if err != nil {
+		return "", rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	// ------------------------------------------------------------- insert user
 	sqlStatement, args, err := u.sb.Insert(keyUserTable).Columns(
 		keyUserMerchantID,
 		keyUserDefOutlet,
@@ -50,47 +79,166 @@ func (u userDao) Insert(ctx context.Context, user dto.UserModel) (string, rest_e
 		keyUserPassword,
 		keyCreatedAt,
 		keyUpdatedAt,
-		keyUserRole).
-		Values(user.MerchantID, user.DefOutlet, user.Name, user.Email, user.Password, timeNow, timeNow, user.Role).
-		Suffix(dao.Returning(keyUserEmail, keyUserName)).ToSql()
+		keyUserRole,
+		keyCustomRoleID).
+		Values(dao.NullIfZero(user.MerchantID), user.DefOutlet, user.Name, user.Email, user.Password, timeNow, timeNow, user.Role, user.CustomRoleID).
+		Suffix(dao.Returning(keyUserID, keyUserEmail, keyUserName)).ToSql()
 
 	if err != nil {
 		return "", rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
 	}
 
+	var id int
 	var email string
 	var name string
-	err = u.db.QueryRow(ctx, sqlStatement, args...).Scan(&email, &name)
+	err = trx.QueryRow(ctx, sqlStatement, args...).Scan(&id, &email, &name)
 	if err != nil {
-		logger.Error("error saat query users(Insert:1)", err)
+		logger.ErrorCtx(ctx, "error saat query users(Insert:1)", err)
 		return "", sql_err.ParseError(err)
 	}
 
+	// ------------------------------------------------------------- tugaskan outlet default
+	if user.DefOutlet != 0 {
+		if err := u.assignOutlet(ctx, trx, id, user.MerchantID, user.DefOutlet, timeNow); err != nil {
+			return "", err
+		}
+	}
+
+	// ------------------------------------------------------------- audit
+	user.ID = id
+	user.CreatedAt = timeNow
+	user.UpdatedAt = timeNow
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		MerchantID: user.MerchantID,
+		EntityType: audit.EntityUser,
+		EntityID:   id,
+		Action:     audit.ActionCreate,
+		After:      user,
+	}); err != nil {
+		return "", err
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return "", rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
 	return fmt.Sprintf("berhasil menambahkan user dengan nama %s - email %s", name, email), nil
 }
 
+// assignOutlet menugaskan outlet milik merchant kepada user apabila belum ditugaskan
+func (u userDao) assignOutlet(ctx context.Context, trx pgx.Tx, userID int, merchantID int, outletID int, timeNow int64) rest_err.APIError {
+	sqlStatement, args, err := u.sb.Select("1").
+		From(keyOutletTable).
+		Where(squirrel.And{
+			squirrel.Eq{keyOutletTableID: outletID},
+			squirrel.Eq{keyUserMerchantID: merchantID},
+		}).
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var exist int
+	err = trx.QueryRow(ctx, sqlStatement, args...).Scan(&exist)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return rest_err.NewBadRequestError(fmt.Sprintf("Outlet dengan id %d tidak ditemukan", outletID))
+		}
+		logger.ErrorCtx(ctx, "error saat query outlets(assignOutlet:0)", err)
+		return sql_err.ParseError(err)
+	}
+
+	sqlStatement, args, err = u.sb.Insert(keyUserOutletTable).
+		Columns(keyRefreshUserID, keyUserOutletID, keyCreatedAt).
+		Values(userID, outletID, timeNow).
+		Suffix("ON CONFLICT DO NOTHING").
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	if _, err := trx.Exec(ctx, sqlStatement, args...); err != nil {
+		logger.ErrorCtx(ctx, "error saat exec user outlets(assignOutlet:1)", err)
+		return sql_err.ParseError(err)
+	}
+	return nil
+}
+
+// Edit merubah data user, apabila role atau custom role berubah seluruh refresh token user dicabut
+// sehingga user harus login ulang untuk mendapatkan token dengan role dan permission baru.
+// Outlet default yang baru ikut ditugaskan kepada user
 func (u userDao) Edit(ctx context.Context, input dto.UserEditModel) (*dto.UserModel, rest_err.APIError) {
-	sqlStatement, args, err := u.sb.Update(keyUserTable).
+
+	// ------------------------------------------------------------- begin
+	trx, err := u.db.Begin(ctx)
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	whereUser := squirrel.And{
+		squirrel.Eq{keyUserID: input.WhereID},
+		squirrel.Eq{keyUserMerchantID: input.WhereMerchantID},
+	}
+
+	// ------------------------------------------------------------- data sebelum diubah
+	sqlStatement, args, err := u.sb.Select(
+		keyUserID,
+		dao.CoalesceInt(keyUserMerchantID, 0),
+		keyUserDefOutlet,
+		keyUserName,
+		keyUserEmail,
+		keyCreatedAt,
+		keyUpdatedAt,
+		keyUserRole,
+		keyCustomRoleID).
+		From(keyUserTable).
+		Where(whereUser).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var before dto.UserModel
+	err = trx.QueryRow(ctx, sqlStatement, args...).Scan(
+		&before.ID,
+		&before.MerchantID,
+		&before.DefOutlet,
+		&before.Name,
+		&before.Email,
+		&before.CreatedAt,
+		&before.UpdatedAt,
+		&before.Role,
+		&before.CustomRoleID)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat query users(Edit:0)", err)
+		return nil, sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- update user
+	sqlStatement, args, err = u.sb.Update(keyUserTable).
 		SetMap(squirrel.Eq{
 			keyUserName:      input.Name,
 			keyUserRole:      input.Role,
+			keyCustomRoleID:  input.CustomRoleID,
 			keyUserDefOutlet: input.DefOutlet,
 			keyUpdatedAt:     input.UpdatedAt,
 		}).
-		Where(
-			squirrel.And{
-				squirrel.Eq{keyUserID: input.WhereID},
-				squirrel.Eq{keyUserMerchantID: input.WhereMerchantID},
-			}).
+		Where(whereUser).
 		Suffix(dao.Returning(
 			keyUserID,
-			keyUserMerchantID,
+			dao.CoalesceInt(keyUserMerchantID, 0),
 			keyUserDefOutlet,
 			keyUserName,
 			keyUserEmail,
 			keyCreatedAt,
 			keyUpdatedAt,
-			keyUserRole)).
+			keyUserRole,
+			keyCustomRoleID)).
 		ToSql()
 
 	if err != nil {
@@ -98,7 +246,7 @@ func (u userDao) Edit(ctx context.Context, input dto.UserEditModel) (*dto.UserMo
 	}
 
 	var user dto.UserModel
-	err = u.db.QueryRow(
+	err = trx.QueryRow(
 		ctx,
 		sqlStatement, args...).Scan(
 		&user.ID,
@@ -108,53 +256,147 @@ func (u userDao) Edit(ctx context.Context, input dto.UserEditModel) (*dto.UserMo
 		&user.Email,
 		&user.CreatedAt,
 		&user.UpdatedAt,
-		&user.Role)
+		&user.Role,
+		&user.CustomRoleID)
 	if err != nil {
-		logger.Error("error saat query users(Edit:1)", err)
+		logger.ErrorCtx(ctx, "error saat query users(Edit:1)", err)
 		return nil, sql_err.ParseError(err)
 	}
+
+	// ------------------------------------------------------------- tugaskan outlet default
+	if user.DefOutlet != 0 {
+		if err := u.assignOutlet(ctx, trx, user.ID, user.MerchantID, user.DefOutlet, input.UpdatedAt); err != nil {
+			return nil, err
+		}
+	}
+
+	// ------------------------------------------------------------- cabut refresh token dan sesi jika role berubah
+	if before.Role != user.Role || before.CustomRoleID != user.CustomRoleID {
+		if err := u.revokeTokens(ctx, trx, user.ID, "", input.UpdatedAt); err != nil {
+			return nil, err
+		}
+	}
+
+	// ------------------------------------------------------------- audit
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		MerchantID: user.MerchantID,
+		EntityType: audit.EntityUser,
+		EntityID:   user.ID,
+		Action:     audit.ActionEdit,
+		Before:     before,
+		After:      user,
+	}); err != nil {
+		return nil, err
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
 	return &user, nil
 }
 
+// Delete menghapus user, refresh token dan sesi milik user ikut terhapus melalui foreign key cascade
 func (u userDao) Delete(ctx context.Context, id int, filterMerchant int) rest_err.APIError {
+
+	// ------------------------------------------------------------- begin
+	trx, err := u.db.Begin(ctx)
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	// ------------------------------------------------------------- hapus user
 	sqlStatement, args, err := u.sb.Delete(keyUserTable).
 		Where(squirrel.And{
 			squirrel.Eq{keyUserID: id},
 			squirrel.Eq{keyUserMerchantID: filterMerchant},
-		}).ToSql()
+		}).
+		Suffix(dao.Returning(
+			keyUserID,
+			dao.CoalesceInt(keyUserMerchantID, 0),
+			keyUserDefOutlet,
+			keyUserName,
+			keyUserEmail,
+			keyCreatedAt,
+			keyUpdatedAt,
+			keyUserRole,
+			keyCustomRoleID)).
+		ToSql()
 	if err != nil {
 		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
 	}
 
-	res, err := db.DB.Exec(ctx, sqlStatement, args...)
+	var before dto.UserModel
+	err = trx.QueryRow(ctx, sqlStatement, args...).Scan(
+		&before.ID,
+		&before.MerchantID,
+		&before.DefOutlet,
+		&before.Name,
+		&before.Email,
+		&before.CreatedAt,
+		&before.UpdatedAt,
+		&before.Role,
+		&before.CustomRoleID)
 	if err != nil {
-		logger.Error("error saat exec users(Delete:0)", err)
+		if err == pgx.ErrNoRows {
+			return rest_err.NewBadRequestError(fmt.Sprintf("UserModel dengan username %d tidak ditemukan", id))
+		}
+		logger.ErrorCtx(ctx, "error saat exec users(Delete:0)", err)
 		return rest_err.NewInternalServerError("gagal saat penghapusan user", err)
 	}
 
-	if res.RowsAffected() == 0 {
-		return rest_err.NewBadRequestError(fmt.Sprintf("UserModel dengan username %d tidak ditemukan", id))
+	// ------------------------------------------------------------- audit
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		MerchantID: before.MerchantID,
+		EntityType: audit.EntityUser,
+		EntityID:   before.ID,
+		Action:     audit.ActionDelete,
+		Before:     before,
+	}); err != nil {
+		return err
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return rest_err.NewInternalServerError(dao.ErrCommit, err)
 	}
 
 	return nil
 }
 
-func (u userDao) ChangePassword(ctx context.Context, input dto.UserModel) rest_err.APIError {
+// ChangePassword mengganti password user lalu mencabut refresh token dan sesi user di dalam transaksi
+// yang sama, keepSession id sesi (family refresh token) yang sedang dipakai sehingga tidak ikut dicabut
+func (u userDao) ChangePassword(ctx context.Context, input dto.UserModel, keepSession string) rest_err.APIError {
+
+	// ------------------------------------------------------------- begin
+	trx, err := u.db.Begin(ctx)
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	// ------------------------------------------------------------- ganti password
 	sqlStatement, args, err := u.sb.Update(keyUserTable).
 		SetMap(squirrel.Eq{
 			keyUserPassword: input.Password,
 			keyUpdatedAt:    input.UpdatedAt,
 		}).
-		Where(keyUserID, input.ID).
+		Where(squirrel.Eq{keyUserID: input.ID}).
 		ToSql()
 
 	if err != nil {
 		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
 	}
 
-	res, err := db.DB.Exec(ctx, sqlStatement, args...)
+	res, err := trx.Exec(ctx, sqlStatement, args...)
 	if err != nil {
-		logger.Error("error saat exec users(ChangePassword:0)", err)
+		logger.ErrorCtx(ctx, "error saat exec users(ChangePassword:0)", err)
 		return sql_err.ParseError(err)
 	}
 
@@ -162,19 +404,114 @@ func (u userDao) ChangePassword(ctx context.Context, input dto.UserModel) rest_e
 		return rest_err.NewBadRequestError(fmt.Sprintf("UserModel dengan username %d tidak ditemukan", input.ID))
 	}
 
+	// ------------------------------------------------------------- cabut sesi lain
+	if err := u.revokeTokens(ctx, trx, input.ID, keepSession, input.UpdatedAt); err != nil {
+		return err
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
+	return nil
+}
+
+// SetPassword mengganti password tanpa password lama (dipakai operator), di dalam satu transaksi
+// kunci akun dibuka dan seluruh refresh token serta sesi user dicabut
+func (u userDao) SetPassword(ctx context.Context, id int, hashedPassword string, updatedAt int64) rest_err.APIError {
+
+	// ------------------------------------------------------------- begin
+	trx, err := u.db.Begin(ctx)
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	// ------------------------------------------------------------- ganti password dan buka kunci akun
+	sqlStatement, args, err := u.sb.Update(keyUserTable).
+		SetMap(squirrel.Eq{
+			keyUserPassword: hashedPassword,
+			keyFailedLogin:  0,
+			keyLockedUntil:  0,
+			keyUpdatedAt:    updatedAt,
+		}).
+		Where(squirrel.Eq{keyUserID: id}).
+		ToSql()
+
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	res, err := trx.Exec(ctx, sqlStatement, args...)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat exec users(SetPassword:0)", err)
+		return sql_err.ParseError(err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return rest_err.NewBadRequestError(fmt.Sprintf("UserModel dengan username %d tidak ditemukan", id))
+	}
+
+	// ------------------------------------------------------------- cabut semua sesi
+	if err := u.revokeTokens(ctx, trx, id, "", updatedAt); err != nil {
+		return err
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
+	return nil
+}
+
+// revokeTokens mencabut refresh token dan sesi user yang masih aktif di dalam trx,
+// keepSession kosong berarti seluruh sesi dicabut
+func (u userDao) revokeTokens(ctx context.Context, trx pgx.Tx, userID int, keepSession string, revokedAt int64) rest_err.APIError {
+	keepColumn := map[string]string{
+		keyRefreshTable: keyRefreshFamilyID,
+		keySessionTable: keySessionID,
+	}
+	for _, table := range []string{keyRefreshTable, keySessionTable} {
+		where := squirrel.And{
+			squirrel.Eq{keyRefreshUserID: userID},
+			squirrel.Eq{keyRefreshRevokedAt: 0},
+		}
+		if keepSession != "" {
+			where = append(where, squirrel.NotEq{keepColumn[table]: keepSession})
+		}
+		sqlStatement, args, err := u.sb.Update(table).
+			Set(keyRefreshRevokedAt, revokedAt).
+			Where(where).
+			ToSql()
+		if err != nil {
+			return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+		}
+
+		if _, err := trx.Exec(ctx, sqlStatement, args...); err != nil {
+			logger.ErrorCtx(ctx, fmt.Sprintf("error saat exec %s(revokeTokens:0)", table), err)
+			return sql_err.ParseError(err)
+		}
+	}
 	return nil
 }
 
 func (u userDao) GetByID(ctx context.Context, id int) (*dto.UserModel, rest_err.APIError) {
 	sqlStatement, args, err := u.sb.Select(
 		keyUserID,
-		keyUserMerchantID,
+		dao.CoalesceInt(keyUserMerchantID, 0),
 		keyUserDefOutlet,
 		keyUserName,
 		keyUserEmail,
 		keyCreatedAt,
 		keyUpdatedAt,
 		keyUserRole,
+		keyCustomRoleID,
+		keyFailedLogin,
+		keyLockedUntil,
 	).From(keyUserTable).Where(squirrel.Eq{keyUserID: id}).ToSql()
 
 	if err != nil {
@@ -182,20 +519,144 @@ func (u userDao) GetByID(ctx context.Context, id int) (*dto.UserModel, rest_err.
 	}
 
 	var user dto.UserModel
-	err = db.DB.QueryRow(ctx, sqlStatement, args...).
-		Scan(&user.ID, &user.MerchantID, &user.DefOutlet, &user.Name, &user.Email, &user.CreatedAt, &user.UpdatedAt, &user.Role)
+	err = u.db.QueryRow(ctx, sqlStatement, args...).
+		Scan(&user.ID, &user.MerchantID, &user.DefOutlet, &user.Name, &user.Email, &user.CreatedAt, &user.UpdatedAt, &user.Role, &user.CustomRoleID, &user.FailedLogin, &user.LockedUntil)
 	if err != nil {
-		logger.Error("error saat QueryRow users(Get:0)", err)
+		logger.ErrorCtx(ctx, "error saat QueryRow users(Get:0)", err)
 		return nil, sql_err.ParseError(err)
 	}
 
 	return &user, nil
 }
 
+// RecordLoginFailure menambah hitungan login gagal secara atomic dan mengunci akun sampai
+// lockedUntil apabila hitungan mencapai maxFailures
+func (u userDao) RecordLoginFailure(ctx context.Context, id int, maxFailures int, lockedUntil int64) (int, int64, rest_err.APIError) {
+	sqlStatement, args, err := u.sb.Update(keyUserTable).
+		Set(keyFailedLogin, squirrel.Expr(keyFailedLogin+" + 1")).
+		Set(keyLockedUntil, squirrel.Expr(
+			fmt.Sprintf("CASE WHEN %s + 1 >= ? THEN ? ELSE %s END", keyFailedLogin, keyLockedUntil),
+			maxFailures, lockedUntil)).
+		Where(squirrel.Eq{keyUserID: id}).
+		Suffix(dao.Returning(keyFailedLogin, keyLockedUntil)).
+		ToSql()
+	if err != nil {
+		return 0, 0, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var failures int
+	var locked int64
+	err = u.db.QueryRow(ctx, sqlStatement, args...).Scan(&failures, &locked)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat QueryRow users(RecordLoginFailure:0)", err)
+		return 0, 0, sql_err.ParseError(err)
+	}
+
+	return failures, locked, nil
+}
+
+// ResetLoginFailure menghapus hitungan login gagal dan membuka kunci akun,
+// filterMerchant 0 berarti tanpa filter merchant
+func (u userDao) ResetLoginFailure(ctx context.Context, id int, filterMerchant int) rest_err.APIError {
+	where := squirrel.And{squirrel.Eq{keyUserID: id}}
+	if filterMerchant != 0 {
+		where = append(where, squirrel.Eq{keyUserMerchantID: filterMerchant})
+	}
+
+	sqlStatement, args, err := u.sb.Update(keyUserTable).
+		SetMap(squirrel.Eq{
+			keyFailedLogin: 0,
+			keyLockedUntil: 0,
+		}).
+		Where(where).
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	res, err := u.db.Exec(ctx, sqlStatement, args...)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat exec users(ResetLoginFailure:0)", err)
+		return sql_err.ParseError(err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return rest_err.NewBadRequestError(fmt.Sprintf("User dengan id %d tidak ditemukan", id))
+	}
+
+	return nil
+}
+
+// SetPin menyimpan hash PIN user untuk login cepat pada perangkat kasir
+func (u userDao) SetPin(ctx context.Context, id int, pinHash string, updatedAt int64) rest_err.APIError {
+	sqlStatement, args, err := u.sb.Update(keyUserTable).
+		SetMap(squirrel.Eq{
+			keyUserPin:   pinHash,
+			keyUpdatedAt: updatedAt,
+		}).
+		Where(squirrel.Eq{keyUserID: id}).
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	res, err := u.db.Exec(ctx, sqlStatement, args...)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat exec users(SetPin:0)", err)
+		return sql_err.ParseError(err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return rest_err.NewBadRequestError(fmt.Sprintf("User dengan id %d tidak ditemukan", id))
+	}
+
+	return nil
+}
+
+// GetPinByID mendapatkan hash PIN user, string kosong apabila PIN belum diatur
+func (u userDao) GetPinByID(ctx context.Context, id int) (string, rest_err.APIError) {
+	sqlStatement, args, err := u.sb.Select(keyUserPin).
+		From(keyUserTable).
+		Where(squirrel.Eq{keyUserID: id}).
+		ToSql()
+	if err != nil {
+		return "", rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var pin string
+	err = u.db.QueryRow(ctx, sqlStatement, args...).Scan(&pin)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat QueryRow users(GetPinByID:0)", err)
+		return "", sql_err.ParseError(err)
+	}
+
+	return pin, nil
+}
+
+// GetPasswordByID mendapatkan hash password user, dipakai untuk verifikasi password lama
+func (u userDao) GetPasswordByID(ctx context.Context, id int) (string, rest_err.APIError) {
+	sqlStatement, args, err := u.sb.Select(keyUserPassword).
+		From(keyUserTable).
+		Where(squirrel.Eq{keyUserID: id}).
+		ToSql()
+	if err != nil {
+		return "", rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var password string
+	err = u.db.QueryRow(ctx, sqlStatement, args...).Scan(&password)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat QueryRow users(GetPasswordByID:0)", err)
+		return "", sql_err.ParseError(err)
+	}
+
+	return password, nil
+}
+
 func (u userDao) GetByEmail(ctx context.Context, email string) (*dto.UserModel, rest_err.APIError) {
 	sqlStatement, args, err := u.sb.Select(
 		keyUserID,
-		keyUserMerchantID,
+		dao.CoalesceInt(keyUserMerchantID, 0),
 		keyUserDefOutlet,
 		keyUserName,
 		keyUserEmail,
@@ -203,18 +664,21 @@ func (u userDao) GetByEmail(ctx context.Context, email string) (*dto.UserModel,
 		keyCreatedAt,
 		keyUpdatedAt,
 		keyUserRole,
+		keyCustomRoleID,
+		keyFailedLogin,
+		keyLockedUntil,
 	).From(keyUserTable).Where(squirrel.Eq{keyUserEmail: email}).ToSql()
 
 	if err != nil {
-		logger.Error("error saat query builder users(GetByEmail:0)", err)
+		logger.ErrorCtx(ctx, "error saat query builder users(GetByEmail:0)", err)
 		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
 	}
 
 	var user dto.UserModel
-	err = db.DB.QueryRow(ctx, sqlStatement, args...).
-		Scan(&user.ID, &user.MerchantID, &user.DefOutlet, &user.Name, &user.Email, &user.Password, &user.CreatedAt, &user.UpdatedAt, &user.Role)
+	err = u.db.QueryRow(ctx, sqlStatement, args...).
+		Scan(&user.ID, &user.MerchantID, &user.DefOutlet, &user.Name, &user.Email, &user.Password, &user.CreatedAt, &user.UpdatedAt, &user.Role, &user.CustomRoleID, &user.FailedLogin, &user.LockedUntil)
 	if err != nil {
-		logger.Error("error saat QueryRow users(GetByEmail:1)", err)
+		logger.ErrorCtx(ctx, "error saat QueryRow users(GetByEmail:1)", err)
 		return nil, sql_err.ParseError(err)
 	}
 
@@ -225,19 +689,24 @@ type FindPaginationParams struct {
 	Search string
 	Limit  int
 	Offset int
+	// MerchantID apabila diisi hanya menampilkan user pada merchant tersebut
+	MerchantID int
 }
 
 func (u *userDao) FindWithPagination(ctx context.Context, opt FindPaginationParams) ([]dto.UserModel, rest_err.APIError) {
 	// ------------------------------------------------------------------------- find user
 	sqlFrom := u.sb.Select(
 		keyUserID,
-		keyUserMerchantID,
+		dao.CoalesceInt(keyUserMerchantID, 0),
 		keyUserDefOutlet,
 		keyUserName,
 		keyUserEmail,
 		keyCreatedAt,
 		keyUpdatedAt,
-		keyUserRole).
+		keyUserRole,
+		keyCustomRoleID,
+		keyFailedLogin,
+		keyLockedUntil).
 		From(keyUserTable)
 
 	// where
@@ -245,6 +714,9 @@ func (u *userDao) FindWithPagination(ctx context.Context, opt FindPaginationPara
 		// search
 		sqlFrom = sqlFrom.Where(squirrel.ILike{keyUserName: fmt.Sprint("%", opt.Search, "%")})
 	}
+	if opt.MerchantID != 0 {
+		sqlFrom = sqlFrom.Where(squirrel.Eq{keyUserMerchantID: opt.MerchantID})
+	}
 
 	sqlStatement, args, err := sqlFrom.OrderBy(keyUserName + " ASC").
 		Limit(uint64(opt.Limit)).
@@ -254,7 +726,7 @@ func (u *userDao) FindWithPagination(ctx context.Context, opt FindPaginationPara
 	if err != nil {
 		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
 	}
-	rows, err := db.DB.Query(ctx, sqlStatement, args...)
+	rows, err := u.db.Query(ctx, sqlStatement, args...)
 	if err != nil {
 		return nil, rest_err.NewInternalServerError("gagal mendapatkan daftar user", err)
 	}
@@ -263,7 +735,7 @@ func (u *userDao) FindWithPagination(ctx context.Context, opt FindPaginationPara
 	users := make([]dto.UserModel, 0)
 	for rows.Next() {
 		var user dto.UserModel
-		err := rows.Scan(&user.ID, &user.MerchantID, &user.DefOutlet, &user.Name, &user.Email, &user.CreatedAt, &user.UpdatedAt, &user.Role)
+		err := rows.Scan(&user.ID, &user.MerchantID, &user.DefOutlet, &user.Name, &user.Email, &user.CreatedAt, &user.UpdatedAt, &user.Role, &user.CustomRoleID, &user.FailedLogin, &user.LockedUntil)
 		if err != nil {
 			return nil, sql_err.ParseError(err)
 		}
@@ -272,3 +744,63 @@ func (u *userDao) FindWithPagination(ctx context.Context, opt FindPaginationPara
 
 	return users, nil
 }
+
+// GetOwnerByMerchant mengembalikan owner pertama dari merchant
+func (u *userDao) GetOwnerByMerchant(ctx context.Context, merchantID int) (*dto.UserModel, rest_err.APIError) {
+	sqlStatement, args, err := u.sb.Select(
+		keyUserID,
+		dao.CoalesceInt(keyUserMerchantID, 0),
+		keyUserDefOutlet,
+		keyUserName,
+		keyUserEmail,
+		keyCreatedAt,
+		keyUpdatedAt,
+		keyUserRole,
+	).From(keyUserTable).
+		Where(squirrel.And{
+			squirrel.Eq{keyUserMerchantID: merchantID},
+			squirrel.Eq{keyUserRole: roles.RoleOwner},
+		}).
+		OrderBy(keyUserID + " ASC").
+		Limit(1).
+		ToSql()
+
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var user dto.UserModel
+	err = u.db.QueryRow(ctx, sqlStatement, args...).
+		Scan(&user.ID, &user.MerchantID, &user.DefOutlet, &user.Name, &user.Email, &user.CreatedAt, &user.UpdatedAt, &user.Role)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat QueryRow users(GetOwnerByMerchant:0)", err)
+		return nil, sql_err.ParseError(err)
+	}
+
+	return &user, nil
+}
+
+// IsRoleExist return true jika sudah ada user dengan role tersebut
+func (u *userDao) IsRoleExist(ctx context.Context, role string) (bool, rest_err.APIError) {
+	sqlStatement, args, err := u.sb.Select("1").
+		From(keyUserTable).
+		Where(squirrel.Eq{keyUserRole: role}).
+		Limit(1).
+		ToSql()
+
+	if err != nil {
+		return false, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var exist int
+	err = u.db.QueryRow(ctx, sqlStatement, args...).Scan(&exist)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return false, nil
+		}
+		logger.ErrorCtx(ctx, "error saat QueryRow users(IsRoleExist:0)", err)
+		return false, sql_err.ParseError(err)
+	}
+
+	return true, nil
+}
@@ -15,11 +15,19 @@ type UserSaver interface {
 	Insert(ctx context.Context, user dto.UserModel) (string, rest_err.APIError)
 	Edit(ctx context.Context, userInput dto.UserEditModel) (*dto.UserModel, rest_err.APIError)
 	Delete(ctx context.Context, id int, filterMerchant int) rest_err.APIError
-	ChangePassword(ctx context.Context, input dto.UserModel) rest_err.APIError
+	ChangePassword(ctx context.Context, input dto.UserModel, keepSession string) rest_err.APIError
+	SetPassword(ctx context.Context, id int, hashedPassword string, updatedAt int64) rest_err.APIError
+	SetPin(ctx context.Context, id int, pinHash string, updatedAt int64) rest_err.APIError
+	RecordLoginFailure(ctx context.Context, id int, maxFailures int, lockedUntil int64) (int, int64, rest_err.APIError)
+	ResetLoginFailure(ctx context.Context, id int, filterMerchant int) rest_err.APIError
 }
 
 type UserReader interface {
 	GetByID(ctx context.Context, id int) (*dto.UserModel, rest_err.APIError)
+	GetPasswordByID(ctx context.Context, id int) (string, rest_err.APIError)
+	GetPinByID(ctx context.Context, id int) (string, rest_err.APIError)
 	GetByEmail(ctx context.Context, email string) (*dto.UserModel, rest_err.APIError)
 	FindWithPagination(ctx context.Context, opt FindPaginationParams) ([]dto.UserModel, rest_err.APIError)
+	GetOwnerByMerchant(ctx context.Context, merchantID int) (*dto.UserModel, rest_err.APIError)
+	IsRoleExist(ctx context.Context, role string) (bool, rest_err.APIError)
 }
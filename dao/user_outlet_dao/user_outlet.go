@@ -0,0 +1,242 @@
+package user_outlet_dao
+
+import (
+	"context"
+	"fmt"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/muchlist/mini_pos/dao"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/audit"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/utils/sfunc"
+	"github.com/muchlist/mini_pos/utils/sql_err"
+	"sort"
+)
+
+const (
+	keyUserOutletTable = "user_outlets"
+	keyUserID          = "user_id"
+	keyOutletID        = "outlet_id"
+	keyCreatedAt       = "created_at"
+
+	keyID              = "id"
+	keyMerchantID      = "merchant_id"
+	keyUserTable       = "users"
+	keyUserDefOutlet   = "def_outlet"
+	keyOutletTable     = "outlets"
+	keyOutletName      = "outlet_name"
+	keyOutletAddress   = "address"
+	keyOutletUpdatedAt = "updated_at"
+)
+
+type userOutletDao struct {
+	db *dao.Pool
+	sb squirrel.StatementBuilderType
+}
+
+func New(db *pgxpool.Pool) UserOutletDaoAssumer {
+	return &userOutletDao{
+		db: dao.NewPool(db),
+		sb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// Set mengganti seluruh outlet yang ditugaskan kepada user. Outlet harus milik merchant
+// yang sama dan outlet default user harus termasuk di dalamnya
+func (u *userOutletDao) Set(ctx context.Context, input dto.UserOutletModel) rest_err.APIError {
+
+	// ------------------------------------------------------------- begin
+	trx, err := u.db.Begin(ctx)
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrBeginTrx, err)
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	// ------------------------------------------------------------- kunci user
+	sqlStatement, args, err := u.sb.Select(keyUserDefOutlet).
+		From(keyUserTable).
+		Where(squirrel.And{
+			squirrel.Eq{keyID: input.UserID},
+			squirrel.Eq{keyMerchantID: input.MerchantID},
+		}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var defOutlet int
+	err = trx.QueryRow(ctx, sqlStatement, args...).Scan(&defOutlet)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return rest_err.NewBadRequestError(fmt.Sprintf("User dengan id %d tidak ditemukan", input.UserID))
+		}
+		logger.ErrorCtx(ctx, "error saat trx query users(Set:0)", err)
+		return sql_err.ParseError(err)
+	}
+	if defOutlet != 0 && !sfunc.IntInSlice(defOutlet, input.OutletIDs) {
+		return rest_err.NewBadRequestError(fmt.Sprintf("Outlet default user (%d) harus termasuk dalam daftar outlet", defOutlet))
+	}
+
+	// ------------------------------------------------------------- outlet harus milik merchant
+	sqlStatement, args, err = u.sb.Select("COUNT(*)").
+		From(keyOutletTable).
+		Where(squirrel.And{
+			squirrel.Eq{keyID: input.OutletIDs},
+			squirrel.Eq{keyMerchantID: input.MerchantID},
+		}).
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var outletCount int
+	if err := trx.QueryRow(ctx, sqlStatement, args...).Scan(&outletCount); err != nil {
+		logger.ErrorCtx(ctx, "error saat trx query outlets(Set:1)", err)
+		return sql_err.ParseError(err)
+	}
+	if outletCount != len(input.OutletIDs) {
+		return rest_err.NewBadRequestError("Terdapat outlet yang tidak ditemukan pada merchant")
+	}
+
+	// ------------------------------------------------------------- hapus penugasan lama
+	sqlStatement, args, err = u.sb.Delete(keyUserOutletTable).
+		Where(squirrel.Eq{keyUserID: input.UserID}).
+		Suffix(dao.Returning(keyOutletID)).
+		ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	rows, err := trx.Query(ctx, sqlStatement, args...)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat trx exec user outlets(Set:2)", err)
+		return sql_err.ParseError(err)
+	}
+	beforeOutletIDs := make([]int, 0)
+	for rows.Next() {
+		var outletID int
+		if err := rows.Scan(&outletID); err != nil {
+			rows.Close()
+			return sql_err.ParseError(err)
+		}
+		beforeOutletIDs = append(beforeOutletIDs, outletID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		logger.ErrorCtx(ctx, "error saat trx exec user outlets(Set:2)", err)
+		return sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- simpan penugasan baru
+	insertBuilder := u.sb.Insert(keyUserOutletTable).
+		Columns(keyUserID, keyOutletID, keyCreatedAt)
+	for _, outletID := range input.OutletIDs {
+		insertBuilder = insertBuilder.Values(input.UserID, outletID, input.CreatedAt)
+	}
+	sqlStatement, args, err = insertBuilder.ToSql()
+	if err != nil {
+		return rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	if _, err := trx.Exec(ctx, sqlStatement, args...); err != nil {
+		logger.ErrorCtx(ctx, "error saat trx exec user outlets(Set:3)", err)
+		return sql_err.ParseError(err)
+	}
+
+	// ------------------------------------------------------------- audit
+	sort.Ints(beforeOutletIDs)
+	afterOutletIDs := append([]int{}, input.OutletIDs...)
+	sort.Ints(afterOutletIDs)
+	if err := dao.WriteAudit(ctx, trx, dao.AuditEntry{
+		MerchantID: input.MerchantID,
+		EntityType: audit.EntityUserOutlet,
+		EntityID:   input.UserID,
+		Action:     audit.ActionEdit,
+		Before:     map[string][]int{"outlet_ids": beforeOutletIDs},
+		After:      map[string][]int{"outlet_ids": afterOutletIDs},
+	}); err != nil {
+		return err
+	}
+
+	// ------------------------------------------------------------- commit
+	if err := trx.Commit(ctx); err != nil {
+		return rest_err.NewInternalServerError(dao.ErrCommit, err)
+	}
+
+	return nil
+}
+
+// FindByUser mengembalikan outlet yang ditugaskan kepada user
+func (u *userOutletDao) FindByUser(ctx context.Context, userID int, merchantFilter int) ([]dto.OutletModel, rest_err.APIError) {
+	sqlStatement, args, err := u.sb.Select(
+		dao.B(keyID),
+		dao.B(keyMerchantID),
+		dao.B(keyOutletName),
+		dao.B(keyOutletAddress),
+		dao.B(keyCreatedAt),
+		dao.B(keyOutletUpdatedAt),
+	).
+		From(keyUserOutletTable + " A").
+		Join(fmt.Sprintf("%s B ON %s = %s", keyOutletTable, dao.B(keyID), dao.A(keyOutletID))).
+		Where(squirrel.And{
+			squirrel.Eq{dao.A(keyUserID): userID},
+			squirrel.Eq{dao.B(keyMerchantID): merchantFilter},
+		}).
+		OrderBy(dao.B(keyID) + " ASC").
+		ToSql()
+	if err != nil {
+		return nil, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	rows, err := u.db.Query(ctx, sqlStatement, args...)
+	if err != nil {
+		logger.ErrorCtx(ctx, "error saat query user outlets(FindByUser:0)", err)
+		return nil, rest_err.NewInternalServerError("gagal mendapatkan daftar outlet user", err)
+	}
+	defer rows.Close()
+
+	outlets := make([]dto.OutletModel, 0)
+	for rows.Next() {
+		outlet := dto.OutletModel{}
+		err := rows.Scan(&outlet.ID, &outlet.MerchantID, &outlet.OutletName, &outlet.Address, &outlet.CreatedAt, &outlet.UpdatedAt)
+		if err != nil {
+			logger.ErrorCtx(ctx, "error saat parsing user outlets(FindByUser:1)", err)
+			return nil, sql_err.ParseError(err)
+		}
+		outlets = append(outlets, outlet)
+	}
+
+	return outlets, nil
+}
+
+// IsAssigned return true jika outlet ditugaskan kepada user
+func (u *userOutletDao) IsAssigned(ctx context.Context, userID int, outletID int) (bool, rest_err.APIError) {
+	sqlStatement, args, err := u.sb.Select("1").
+		From(keyUserOutletTable).
+		Where(squirrel.And{
+			squirrel.Eq{keyUserID: userID},
+			squirrel.Eq{keyOutletID: outletID},
+		}).
+		ToSql()
+	if err != nil {
+		return false, rest_err.NewInternalServerError(dao.ErrSqlBuilder, err)
+	}
+
+	var exist int
+	err = u.db.QueryRow(ctx, sqlStatement, args...).Scan(&exist)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return false, nil
+		}
+		logger.ErrorCtx(ctx, "error saat query user outlets(IsAssigned:0)", err)
+		return false, sql_err.ParseError(err)
+	}
+
+	return true, nil
+}
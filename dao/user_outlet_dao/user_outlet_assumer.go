@@ -0,0 +1,21 @@
+package user_outlet_dao
+
+import (
+	"context"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+)
+
+type UserOutletDaoAssumer interface {
+	UserOutletSaver
+	UserOutletLoader
+}
+
+type UserOutletSaver interface {
+	Set(ctx context.Context, input dto.UserOutletModel) rest_err.APIError
+}
+
+type UserOutletLoader interface {
+	FindByUser(ctx context.Context, userID int, merchantFilter int) ([]dto.OutletModel, rest_err.APIError)
+	IsAssigned(ctx context.Context, userID int, outletID int) (bool, rest_err.APIError)
+}
@@ -0,0 +1,305 @@
+package db
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+//go:embed migrations/*.sql
+var migrationFS embed.FS
+
+const (
+	migrationTable = "schema_migrations"
+	// migrationLockKey kunci advisory lock postgres, mencegah dua proses menjalankan migrasi bersamaan
+	migrationLockKey int64 = 72364100
+)
+
+// nama file migrasi <versi>_<nama>.up.sql dan <versi>_<nama>.down.sql
+var migrationFileRegex = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration satu versi skema beserta script untuk menaikkan dan menurunkannya
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus status versi migrasi pada database, AppliedAt 0 berarti belum dijalankan
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt int64
+}
+
+// LoadMigrations membaca seluruh file migrasi dan mengurutkannya berdasarkan versi.
+// Setiap versi wajib memiliki script up dan down
+func LoadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	files, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		match := migrationFileRegex.FindStringSubmatch(file.Name())
+		if match == nil {
+			return nil, fmt.Errorf("nama file migrasi %s tidak valid, gunakan <versi>_<nama>.up.sql atau .down.sql", file.Name())
+		}
+		version, _ := strconv.Atoi(match[1])
+		if version == 0 {
+			return nil, fmt.Errorf("versi migrasi %s harus lebih dari 0", file.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("versi migrasi %d dipakai oleh %s dan %s", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migrasi %d_%s harus memiliki script up dan down", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Migrator menjalankan migrasi pada database, setiap versi dijalankan dalam transaksi tersendiri
+type Migrator struct {
+	pool       *pgxpool.Pool
+	migrations []Migration
+}
+
+// NewMigrator memakai migrasi yang di embed pada binary dari folder db/migrations
+func NewMigrator(pool *pgxpool.Pool) (*Migrator, error) {
+	migrations, err := LoadMigrations(migrationFS, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{pool: pool, migrations: migrations}, nil
+}
+
+// withLock menjalankan fn pada satu koneksi yang memegang advisory lock migrasi
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+		return fmt.Errorf("gagal mendapatkan lock migrasi: %w", err)
+	}
+	defer func() {
+		_, _ = conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockKey)
+	}()
+
+	_, err = conn.Exec(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS "%s" (
+		"version" int PRIMARY KEY,
+		"name" varchar(255) NOT NULL,
+		"applied_at" bigint NOT NULL
+	)`, migrationTable))
+	if err != nil {
+		return fmt.Errorf("gagal membuat tabel %s: %w", migrationTable, err)
+	}
+
+	return fn(conn)
+}
+
+func appliedVersions(ctx context.Context, conn *pgxpool.Conn) (map[int]int64, error) {
+	rows, err := conn.Query(ctx, fmt.Sprintf(`SELECT "version", "applied_at" FROM "%s"`, migrationTable))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int]int64{}
+	for rows.Next() {
+		var version int
+		var appliedAt int64
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+// run menjalankan script migrasi dan mencatat atau menghapus versinya pada transaksi yang sama
+func run(ctx context.Context, conn *pgxpool.Conn, script string, record func(trx pgx.Tx) error) error {
+	trx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func(trx pgx.Tx) {
+		_ = trx.Rollback(context.Background())
+	}(trx)
+
+	// tanpa argumen pgx memakai simple protocol sehingga satu script dapat berisi banyak statement
+	if _, err := trx.Exec(ctx, script); err != nil {
+		return err
+	}
+	if err := record(trx); err != nil {
+		return err
+	}
+	return trx.Commit(ctx)
+}
+
+// Up menjalankan migrasi yang belum diterapkan sampai versi target, target 0 berarti versi terakhir.
+// Mengembalikan migrasi yang dijalankan
+func (m *Migrator) Up(ctx context.Context, target int) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, mig := range m.migrations {
+			if target != 0 && mig.Version > target {
+				break
+			}
+			if _, ok := applied[mig.Version]; ok {
+				continue
+			}
+			mig := mig
+			err := run(ctx, conn, mig.Up, func(trx pgx.Tx) error {
+				_, err := trx.Exec(ctx,
+					fmt.Sprintf(`INSERT INTO "%s" ("version", "name", "applied_at") VALUES ($1, $2, $3)`, migrationTable),
+					mig.Version, mig.Name, time.Now().Unix())
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("migrasi %d_%s gagal: %w", mig.Version, mig.Name, err)
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down membatalkan migrasi yang sudah diterapkan sebanyak steps dimulai dari versi terbaru.
+// Mengembalikan migrasi yang dibatalkan
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps < 1 {
+		return nil, errors.New("jumlah langkah down minimal 1")
+	}
+
+	var done []Migration
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			mig := m.migrations[i]
+			if _, ok := applied[mig.Version]; !ok {
+				continue
+			}
+			err := run(ctx, conn, mig.Down, func(trx pgx.Tx) error {
+				_, err := trx.Exec(ctx, fmt.Sprintf(`DELETE FROM "%s" WHERE "version" = $1`, migrationTable), mig.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("rollback migrasi %d_%s gagal: %w", mig.Version, mig.Name, err)
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Baseline menandai migrasi sampai versi tertentu sebagai sudah diterapkan tanpa menjalankan scriptnya,
+// dipakai untuk database yang sebelumnya dibuat manual dari doc/database.sql
+func (m *Migrator) Baseline(ctx context.Context, version int) error {
+	return m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		for _, mig := range m.migrations {
+			if mig.Version > version {
+				break
+			}
+			_, err := conn.Exec(ctx,
+				fmt.Sprintf(`INSERT INTO "%s" ("version", "name", "applied_at") VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`, migrationTable),
+				mig.Version, mig.Name, time.Now().Unix())
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Pending migrasi yang di embed namun belum diterapkan pada database. Tidak memakai advisory lock
+// sehingga aman dipanggil berkala, misalnya oleh readiness probe
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	applied, err := appliedVersions(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	var pending []Migration
+	for _, mig := range m.migrations {
+		if _, ok := applied[mig.Version]; !ok {
+			pending = append(pending, mig)
+		}
+	}
+	return pending, nil
+}
+
+// Status daftar seluruh migrasi beserta waktu diterapkan
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, mig := range m.migrations {
+			statuses = append(statuses, MigrationStatus{
+				Version:   mig.Version,
+				Name:      mig.Name,
+				AppliedAt: applied[mig.Version],
+			})
+		}
+		return nil
+	})
+	return statuses, err
+}
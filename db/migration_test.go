@@ -0,0 +1,53 @@
+package db
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadMigrationsSorted(t *testing.T) {
+	fsys := fstest.MapFS{
+		"m/0002_add_column.up.sql":   {Data: []byte("ALTER 2")},
+		"m/0002_add_column.down.sql": {Data: []byte("REVERT 2")},
+		"m/0001_init.up.sql":         {Data: []byte("CREATE 1")},
+		"m/0001_init.down.sql":       {Data: []byte("DROP 1")},
+	}
+
+	migrations, err := LoadMigrations(fsys, "m")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(migrations))
+	assert.Equal(t, Migration{Version: 1, Name: "init", Up: "CREATE 1", Down: "DROP 1"}, migrations[0])
+	assert.Equal(t, 2, migrations[1].Version)
+}
+
+func TestLoadMigrationsInvalid(t *testing.T) {
+	_, err := LoadMigrations(fstest.MapFS{
+		"m/0001_init.up.sql": {Data: []byte("CREATE 1")},
+	}, "m")
+	assert.NotNil(t, err)
+
+	_, err = LoadMigrations(fstest.MapFS{
+		"m/init.sql": {Data: []byte("CREATE 1")},
+	}, "m")
+	assert.NotNil(t, err)
+
+	_, err = LoadMigrations(fstest.MapFS{
+		"m/0001_init.up.sql":    {Data: []byte("CREATE 1")},
+		"m/0001_init.down.sql":  {Data: []byte("DROP 1")},
+		"m/0001_other.up.sql":   {Data: []byte("CREATE 1")},
+		"m/0001_other.down.sql": {Data: []byte("DROP 1")},
+	}, "m")
+	assert.NotNil(t, err)
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := LoadMigrations(migrationFS, "migrations")
+	assert.Nil(t, err)
+	assert.True(t, len(migrations) > 0)
+	// versi berurutan tanpa celah, setiap fitur memiliki migrasi sendiri setelah skema awal
+	for i, m := range migrations {
+		assert.Equal(t, i+1, m.Version, m.Name)
+	}
+}
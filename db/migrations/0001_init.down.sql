@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "product_price" CASCADE;
+DROP TABLE IF EXISTS "products" CASCADE;
+DROP TABLE IF EXISTS "outlets" CASCADE;
+DROP TABLE IF EXISTS "merchant" CASCADE;
+DROP TABLE IF EXISTS "users" CASCADE;
+
+DROP TYPE IF EXISTS "role";
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "taxes";
+
+ALTER TABLE "products" DROP COLUMN IF EXISTS "tax_id";
+
+ALTER TABLE "merchant" DROP COLUMN IF EXISTS "tax_rounding";
+
+ALTER TABLE "merchant" DROP COLUMN IF EXISTS "tax_inclusive";
@@ -0,0 +1,16 @@
+ALTER TABLE "merchant" ADD COLUMN IF NOT EXISTS "tax_inclusive" boolean NOT NULL DEFAULT false;
+
+ALTER TABLE "merchant" ADD COLUMN IF NOT EXISTS "tax_rounding" varchar(20) NOT NULL DEFAULT 'half_up';
+
+ALTER TABLE "products" ADD COLUMN IF NOT EXISTS "tax_id" int NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS "taxes" (
+                         "id" serial PRIMARY KEY,
+                         "merchant_id" int NOT NULL REFERENCES "merchant" ("id") ON DELETE CASCADE ON UPDATE CASCADE,
+                         "name" varchar(100) NOT NULL,
+                         "rate" int NOT NULL DEFAULT 0,
+                         "created_at" bigint NOT NULL,
+                         "updated_at" bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "t_merchant_id" ON "taxes" ("merchant_id");
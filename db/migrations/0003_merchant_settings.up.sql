@@ -0,0 +1,12 @@
+-- merchant tanpa baris pengaturan memakai pengaturan default
+CREATE TABLE IF NOT EXISTS "merchant_settings" (
+                                     "merchant_id" int PRIMARY KEY REFERENCES "merchant" ("id") ON DELETE CASCADE ON UPDATE CASCADE,
+                                     "currency" varchar(3) NOT NULL DEFAULT 'IDR',
+                                     "minor_unit" int NOT NULL DEFAULT 0,
+                                     "timezone" varchar(64) NOT NULL DEFAULT 'Asia/Jakarta',
+                                     "cash_rounding" int NOT NULL DEFAULT 0,
+                                     "receipt_header" text NOT NULL DEFAULT '',
+                                     "receipt_footer" text NOT NULL DEFAULT '',
+                                     "logo" text NOT NULL DEFAULT '',
+                                     "updated_at" bigint NOT NULL
+);
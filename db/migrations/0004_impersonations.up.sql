@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS "impersonations" (
+                                  "id" serial PRIMARY KEY,
+                                  "super_id" int NOT NULL,
+                                  "merchant_id" int NOT NULL,
+                                  "owner_id" int NOT NULL,
+                                  "reason" text NOT NULL,
+                                  "created_at" bigint NOT NULL,
+                                  "expired_at" bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "i_merchant_id" ON "impersonations" ("merchant_id");
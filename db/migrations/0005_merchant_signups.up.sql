@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS "merchant_signups" (
+                                    "id" serial PRIMARY KEY,
+                                    "merchant_name" varchar(255) NOT NULL,
+                                    "description" text NOT NULL DEFAULT '',
+                                    "owner_name" varchar(100) NOT NULL,
+                                    "owner_email" varchar(100) NOT NULL,
+                                    "token_hash" varchar(64) UNIQUE NOT NULL,
+                                    "created_at" bigint NOT NULL,
+                                    "expired_at" bigint NOT NULL,
+                                    "verified_at" bigint NOT NULL DEFAULT 0
+);
@@ -0,0 +1,5 @@
+ALTER TABLE "products" DROP COLUMN IF EXISTS "image_size";
+
+ALTER TABLE "merchant" DROP COLUMN IF EXISTS "plan_id";
+
+DROP TABLE IF EXISTS "plans";
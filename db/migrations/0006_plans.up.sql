@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS "plans" (
+                         "id" serial PRIMARY KEY,
+                         "name" varchar(100) UNIQUE NOT NULL,
+                         "max_outlets" int NOT NULL DEFAULT 0,
+                         "max_users" int NOT NULL DEFAULT 0,
+                         "max_products" int NOT NULL DEFAULT 0,
+                         "max_image_bytes" bigint NOT NULL DEFAULT 0,
+                         "created_at" bigint NOT NULL,
+                         "updated_at" bigint NOT NULL
+);
+
+-- paket default untuk merchant baru (id 1), harus ada sebelum kolom plan_id merchant lama diisi
+INSERT INTO "plans" ("name", "max_outlets", "max_users", "max_products", "max_image_bytes", "created_at", "updated_at")
+VALUES ('FREE', 1, 3, 100, 52428800, extract(epoch from now())::bigint, extract(epoch from now())::bigint)
+ON CONFLICT ("name") DO NOTHING;
+
+ALTER TABLE "merchant" ADD COLUMN IF NOT EXISTS "plan_id" int NOT NULL DEFAULT 1 REFERENCES "plans" ("id") ON UPDATE CASCADE;
+
+ALTER TABLE "products" ADD COLUMN IF NOT EXISTS "image_size" bigint NOT NULL DEFAULT 0;
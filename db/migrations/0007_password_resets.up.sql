@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS "password_resets" (
+                                   "id" serial PRIMARY KEY,
+                                   "user_id" int NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE ON UPDATE CASCADE,
+                                   "token_hash" varchar(64) UNIQUE NOT NULL,
+                                   "requested_by" int NOT NULL DEFAULT 0,
+                                   "created_at" bigint NOT NULL,
+                                   "expired_at" bigint NOT NULL,
+                                   "used_at" bigint NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS "pr_user_id" ON "password_resets" ("user_id");
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS "refresh_tokens" (
+                                  "id" serial PRIMARY KEY,
+                                  "jti" varchar(64) UNIQUE NOT NULL,
+                                  "family_id" varchar(64) NOT NULL,
+                                  "user_id" int NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE ON UPDATE CASCADE,
+                                  "token_hash" varchar(64) NOT NULL,
+                                  "created_at" bigint NOT NULL,
+                                  "expired_at" bigint NOT NULL,
+                                  "used_at" bigint NOT NULL DEFAULT 0,
+                                  "revoked_at" bigint NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS "rt_user_id" ON "refresh_tokens" ("user_id");
+
+CREATE INDEX IF NOT EXISTS "rt_family_id" ON "refresh_tokens" ("family_id");
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS "sessions" (
+                            "id" varchar(64) PRIMARY KEY,
+                            "user_id" int NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE ON UPDATE CASCADE,
+                            "user_agent" varchar(255) NOT NULL DEFAULT '',
+                            "ip" varchar(64) NOT NULL DEFAULT '',
+                            "outlet_id" int NOT NULL DEFAULT 0,
+                            "created_at" bigint NOT NULL,
+                            "last_seen_at" bigint NOT NULL,
+                            "expired_at" bigint NOT NULL,
+                            "revoked_at" bigint NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS "s_user_id" ON "sessions" ("user_id");
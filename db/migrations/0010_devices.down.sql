@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "s_device_id";
+
+ALTER TABLE "sessions" DROP COLUMN IF EXISTS "device_id";
+
+DROP TABLE IF EXISTS "devices";
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "pin";
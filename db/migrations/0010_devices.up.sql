@@ -0,0 +1,19 @@
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "pin" varchar(100) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS "devices" (
+                           "id" serial PRIMARY KEY,
+                           "merchant_id" int NOT NULL REFERENCES "merchant" ("id") ON DELETE CASCADE ON UPDATE CASCADE,
+                           "outlet_id" int NOT NULL REFERENCES "outlets" ("id") ON DELETE CASCADE ON UPDATE CASCADE,
+                           "name" varchar(50) NOT NULL,
+                           "token_hash" varchar(64) UNIQUE NOT NULL,
+                           "created_by" int NOT NULL,
+                           "created_at" bigint NOT NULL,
+                           "last_used_at" bigint NOT NULL DEFAULT 0,
+                           "revoked_at" bigint NOT NULL DEFAULT 0
+);
+
+ALTER TABLE "sessions" ADD COLUMN IF NOT EXISTS "device_id" int NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS "d_merchant_id" ON "devices" ("merchant_id");
+
+CREATE INDEX IF NOT EXISTS "s_device_id" ON "sessions" ("device_id");
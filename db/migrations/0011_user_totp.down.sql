@@ -0,0 +1,5 @@
+ALTER TABLE "merchant_settings" DROP COLUMN IF EXISTS "require_owner_2fa";
+
+DROP TABLE IF EXISTS "totp_recovery_codes";
+
+DROP TABLE IF EXISTS "user_totp";
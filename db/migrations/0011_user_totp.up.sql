@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS "user_totp" (
+                             "user_id" int PRIMARY KEY REFERENCES "users" ("id") ON DELETE CASCADE ON UPDATE CASCADE,
+                             "secret" varchar(64) NOT NULL,
+                             "enabled_at" bigint NOT NULL DEFAULT 0,
+                             "last_step" bigint NOT NULL DEFAULT 0,
+                             "created_at" bigint NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "totp_recovery_codes" (
+                                       "id" serial PRIMARY KEY,
+                                       "user_id" int NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE ON UPDATE CASCADE,
+                                       "code_hash" varchar(64) NOT NULL,
+                                       "used_at" bigint NOT NULL DEFAULT 0
+);
+
+ALTER TABLE "merchant_settings" ADD COLUMN IF NOT EXISTS "require_owner_2fa" boolean NOT NULL DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS "trc_user_id" ON "totp_recovery_codes" ("user_id");
@@ -0,0 +1,3 @@
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "failed_login" int NOT NULL DEFAULT 0;
+
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "locked_until" bigint NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "merchant_roles";
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "custom_role_id";
@@ -0,0 +1,21 @@
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "custom_role_id" int NOT NULL DEFAULT 0;
+
+-- role bawaan memiliki merchant_id 0, custom role memiliki merchant_id pemiliknya
+CREATE TABLE IF NOT EXISTS "merchant_roles" (
+                                  "id" serial PRIMARY KEY,
+                                  "merchant_id" int NOT NULL DEFAULT 0,
+                                  "name" varchar(50) NOT NULL,
+                                  "permissions" text[] NOT NULL DEFAULT '{}',
+                                  "created_at" bigint NOT NULL,
+                                  "updated_at" bigint NOT NULL,
+                                  UNIQUE ("merchant_id", "name")
+);
+
+CREATE INDEX IF NOT EXISTS "mr_merchant_id" ON "merchant_roles" ("merchant_id");
+
+-- role bawaan, owner memiliki seluruh permission
+INSERT INTO "merchant_roles" ("merchant_id", "name", "permissions", "created_at", "updated_at")
+VALUES (0, 'owner', '{*}', extract(epoch from now())::bigint, extract(epoch from now())::bigint),
+       (0, 'employee', '{}', extract(epoch from now())::bigint, extract(epoch from now())::bigint),
+       (0, 'customer', '{}', extract(epoch from now())::bigint, extract(epoch from now())::bigint)
+ON CONFLICT ("merchant_id", "name") DO NOTHING;
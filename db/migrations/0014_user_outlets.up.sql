@@ -0,0 +1,15 @@
+-- outlet yang boleh diakses user tanpa permission outlet.manage
+CREATE TABLE IF NOT EXISTS "user_outlets" (
+                                "user_id" int NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE ON UPDATE CASCADE,
+                                "outlet_id" int NOT NULL REFERENCES "outlets" ("id") ON DELETE CASCADE ON UPDATE CASCADE,
+                                "created_at" bigint NOT NULL,
+                                PRIMARY KEY ("user_id", "outlet_id")
+);
+
+CREATE INDEX IF NOT EXISTS "uo_outlet_id" ON "user_outlets" ("outlet_id");
+
+-- outlet default user yang sudah ada menjadi outlet yang ditugaskan
+INSERT INTO "user_outlets" ("user_id", "outlet_id", "created_at")
+SELECT "users"."id", "users"."def_outlet", extract(epoch from now())::bigint
+FROM "users" JOIN "outlets" ON "outlets"."id" = "users"."def_outlet" AND "outlets"."merchant_id" = "users"."merchant_id"
+ON CONFLICT DO NOTHING;
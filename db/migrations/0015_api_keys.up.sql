@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS "api_keys" (
+                            "id" serial PRIMARY KEY,
+                            "merchant_id" int NOT NULL REFERENCES "merchant" ("id") ON DELETE CASCADE ON UPDATE CASCADE,
+                            "outlet_id" int NOT NULL DEFAULT 0,
+                            "name" varchar(50) NOT NULL,
+                            "prefix" varchar(12) UNIQUE NOT NULL,
+                            "secret_hash" varchar(64) NOT NULL,
+                            "scopes" text[] NOT NULL DEFAULT '{}',
+                            "created_by" int NOT NULL,
+                            "created_at" bigint NOT NULL,
+                            "expired_at" bigint NOT NULL DEFAULT 0,
+                            "last_used_at" bigint NOT NULL DEFAULT 0,
+                            "revoked_at" bigint NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS "ak_merchant_id" ON "api_keys" ("merchant_id");
@@ -0,0 +1,19 @@
+-- audit log tidak memakai foreign key agar catatan tetap ada setelah entitas dihapus
+CREATE TABLE IF NOT EXISTS "audit_logs" (
+                              "id" bigserial PRIMARY KEY,
+                              "merchant_id" int NOT NULL DEFAULT 0,
+                              "actor_id" int NOT NULL DEFAULT 0,
+                              "impersonator_id" int NOT NULL DEFAULT 0,
+                              "api_key_id" int NOT NULL DEFAULT 0,
+                              "entity_type" varchar(50) NOT NULL,
+                              "entity_id" int NOT NULL,
+                              "action" varchar(20) NOT NULL,
+                              "changes" jsonb NOT NULL DEFAULT '{}',
+                              "ip" varchar(45) NOT NULL DEFAULT '',
+                              "request_id" varchar(64) NOT NULL DEFAULT '',
+                              "created_at" bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "al_merchant_created" ON "audit_logs" ("merchant_id", "created_at");
+
+CREATE INDEX IF NOT EXISTS "al_entity" ON "audit_logs" ("entity_type", "entity_id");
@@ -0,0 +1 @@
+ALTER TABLE "product_price" ADD COLUMN IF NOT EXISTS "image" text NOT NULL DEFAULT '';
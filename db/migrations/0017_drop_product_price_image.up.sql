@@ -0,0 +1,2 @@
+-- image product disimpan pada tabel products, kolom ini tidak pernah dipakai
+ALTER TABLE "product_price" DROP COLUMN IF EXISTS "image";
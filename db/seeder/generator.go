@@ -0,0 +1,218 @@
+package seeder
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+const (
+	emailDomain = "minipos.demo"
+	taxName     = "PPN"
+	taxRate     = 1100 // basis poin, 11%
+	// taxedRatio porsi product yang dikenakan pajak
+	taxedRatio = 0.7
+	// priceStep harga dibulatkan ke kelipatan ini
+	priceStep = 500
+)
+
+var (
+	merchantPrefixes = []string{"Toko", "Warung", "Kedai", "Depot", "Rumah Makan", "Mini Market"}
+	merchantWords    = []string{"Sumber Rejeki", "Makmur Jaya", "Berkah", "Sinar Abadi", "Maju Bersama", "Sederhana",
+		"Barokah", "Cahaya Baru", "Sentosa", "Mekar Sari", "Harapan Kita", "Sido Mampir"}
+	cities = []string{"Banjarmasin", "Martapura", "Banjarbaru", "Samarinda", "Balikpapan", "Surabaya",
+		"Malang", "Yogyakarta", "Bandung", "Semarang", "Makassar", "Denpasar"}
+	areas   = []string{"Pusat", "Timur", "Barat", "Utara", "Selatan", "Kota", "Pasar", "Terminal"}
+	streets = []string{"Pangeran Samudera", "Ahmad Yani", "Sudirman", "Gatot Subroto", "Diponegoro", "Veteran",
+		"Lambung Mangkurat", "Hasanuddin", "Pahlawan", "Merdeka"}
+	firstNames = []string{"Budi", "Siti", "Agus", "Dewi", "Rizky", "Putri", "Andi", "Wahyu", "Nur", "Fajar",
+		"Rina", "Hendra", "Ayu", "Dimas", "Lestari", "Yusuf"}
+	lastNames = []string{"Santoso", "Rahmawati", "Saputra", "Wijaya", "Hidayat", "Maharani", "Pratama", "Kusuma",
+		"Nugroho", "Permata"}
+)
+
+// category kelompok product, harga beli acak di antara minBuy dan maxBuy
+type category struct {
+	prefix   string
+	minBuy   int
+	maxBuy   int
+	items    []string
+	variants []string
+}
+
+var categories = []category{
+	{
+		prefix: "MKN", minBuy: 8000, maxBuy: 35000,
+		items: []string{"Nasi Goreng", "Mie Ayam", "Sate Ayam", "Soto Banjar", "Rendang", "Gado Gado", "Bakso",
+			"Pecel Lele", "Ayam Geprek", "Nasi Uduk", "Lontong Sayur", "Ketoprak", "Nasi Kuning", "Ikan Bakar"},
+		variants: []string{"Original", "Pedas", "Spesial", "Komplit", "Jumbo", "Telur", "Keju"},
+	},
+	{
+		prefix: "MNM", minBuy: 2000, maxBuy: 15000,
+		items: []string{"Kopi Susu", "Es Teh Manis", "Es Jeruk", "Teh Tarik", "Jus Alpukat", "Es Cendol",
+			"Wedang Jahe", "Kopi Tubruk", "Es Kelapa Muda", "Jus Mangga", "Susu Jahe"},
+		variants: []string{"Dingin", "Panas", "Gula Aren", "Tanpa Gula", "Besar", "Kecil"},
+	},
+	{
+		prefix: "SNK", minBuy: 500, maxBuy: 10000,
+		items: []string{"Kerupuk Udang", "Keripik Singkong", "Pisang Goreng", "Tahu Isi", "Bakwan",
+			"Martabak Manis", "Kue Lapis", "Onde Onde", "Risoles", "Lemper", "Klepon"},
+		variants: []string{"Original", "Pedas", "Coklat", "Keju", "Balado", "Isi 5", "Isi 10"},
+	},
+	{
+		prefix: "SMB", minBuy: 3000, maxBuy: 75000,
+		items: []string{"Beras Pandan Wangi", "Gula Pasir", "Minyak Goreng", "Telur Ayam", "Tepung Terigu",
+			"Kecap Manis", "Garam Dapur", "Susu Kental Manis", "Mie Instan", "Kopi Bubuk"},
+		variants: []string{"250 Gr", "500 Gr", "1 Kg", "5 Kg", "1 Liter", "2 Liter", "Renceng"},
+	},
+}
+
+// Person data user demo
+type Person struct {
+	Name  string
+	Email string
+}
+
+type OutletData struct {
+	Name      string
+	Address   string
+	Employees []Person
+}
+
+type ProductData struct {
+	Code  string
+	Name  string
+	Buy   int
+	Sell  int
+	Taxed bool
+}
+
+// PriceData harga khusus product pada outlet, ProductIndex adalah indeks pada MerchantData.Products
+type PriceData struct {
+	ProductIndex int
+	Buy          int
+	Sell         int
+}
+
+type MerchantData struct {
+	Name    string
+	Owner   Person
+	Outlets []OutletData
+	// CustomPrices harga khusus per outlet dengan indeks yang sama dengan Outlets
+	CustomPrices [][]PriceData
+	Products     []ProductData
+}
+
+// Generate membuat data demo secara deterministik, seed dan preset yang sama selalu menghasilkan data
+// yang sama. Seed ikut menjadi bagian email dan kode product sehingga beberapa seed dapat dipakai
+// pada database yang sama
+func Generate(preset Preset, seed int64) []MerchantData {
+	rng := rand.New(rand.NewSource(seed))
+	merchants := make([]MerchantData, 0, preset.Merchants)
+
+	for m := 0; m < preset.Merchants; m++ {
+		merchant := MerchantData{
+			Name: strings.ToUpper(fmt.Sprintf("%s %s", pick(rng, merchantPrefixes), pick(rng, merchantWords))),
+			Owner: Person{
+				Name:  personName(rng),
+				Email: fmt.Sprintf("owner%d.s%d@%s", m+1, seed, emailDomain),
+			},
+		}
+
+		for o := 0; o < preset.OutletsPerMerchant; o++ {
+			outlet := OutletData{
+				Name:    strings.ToUpper(fmt.Sprintf("%s %s", pick(rng, cities), pick(rng, areas))),
+				Address: fmt.Sprintf("Jl %s No %d", pick(rng, streets), rng.Intn(200)+1),
+			}
+			for e := 0; e < preset.EmployeesPerOutlet; e++ {
+				outlet.Employees = append(outlet.Employees, Person{
+					Name:  personName(rng),
+					Email: fmt.Sprintf("kasir%d.%d.%d.s%d@%s", m+1, o+1, e+1, seed, emailDomain),
+				})
+			}
+			merchant.Outlets = append(merchant.Outlets, outlet)
+		}
+
+		merchant.Products = generateProducts(rng, preset.ProductsPerMerch, fmt.Sprintf("S%dM%d", seed, m+1))
+
+		for range merchant.Outlets {
+			var prices []PriceData
+			for i, product := range merchant.Products {
+				if rng.Float64() >= preset.CustomPriceRatio {
+					continue
+				}
+				// harga outlet berbeda -10% sampai +10% dari harga master
+				factor := 0.9 + rng.Float64()*0.2
+				prices = append(prices, PriceData{
+					ProductIndex: i,
+					Buy:          product.Buy,
+					Sell:         roundPrice(float64(product.Sell) * factor),
+				})
+			}
+			merchant.CustomPrices = append(merchant.CustomPrices, prices)
+		}
+
+		merchants = append(merchants, merchant)
+	}
+
+	return merchants
+}
+
+// generateProducts membuat product dengan nama unik dari kombinasi item dan varian yang diacak,
+// apabila kombinasi habis nama diberi nomor seri
+func generateProducts(rng *rand.Rand, total int, codePrefix string) []ProductData {
+	type candidate struct {
+		cat  category
+		name string
+	}
+	var candidates []candidate
+	for _, cat := range categories {
+		for _, item := range cat.items {
+			for _, variant := range cat.variants {
+				candidates = append(candidates, candidate{cat: cat, name: item + " " + variant})
+			}
+		}
+	}
+	rng.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+
+	products := make([]ProductData, 0, total)
+	for i := 0; i < total; i++ {
+		c := candidates[i%len(candidates)]
+		name := c.name
+		if series := i / len(candidates); series > 0 {
+			name = fmt.Sprintf("%s %d", name, series+1)
+		}
+
+		buy := roundPrice(float64(c.cat.minBuy + rng.Intn(c.cat.maxBuy-c.cat.minBuy+1)))
+		// margin 20% sampai 60%
+		sell := roundPrice(float64(buy) * (1.2 + rng.Float64()*0.4))
+
+		products = append(products, ProductData{
+			Code:  fmt.Sprintf("%s-%s-%04d", codePrefix, c.cat.prefix, i+1),
+			Name:  strings.ToUpper(name),
+			Buy:   buy,
+			Sell:  sell,
+			Taxed: rng.Float64() < taxedRatio,
+		})
+	}
+	return products
+}
+
+func pick(rng *rand.Rand, list []string) string {
+	return list[rng.Intn(len(list))]
+}
+
+func personName(rng *rand.Rand) string {
+	return strings.ToUpper(pick(rng, firstNames) + " " + pick(rng, lastNames))
+}
+
+// roundPrice membulatkan harga ke kelipatan priceStep terdekat dengan nilai minimal priceStep
+func roundPrice(price float64) int {
+	rounded := int(price/priceStep+0.5) * priceStep
+	if rounded < priceStep {
+		return priceStep
+	}
+	return rounded
+}
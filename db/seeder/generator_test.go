@@ -0,0 +1,55 @@
+package seeder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateDeterministic(t *testing.T) {
+	preset, err := GetPreset("small")
+	assert.Nil(t, err)
+
+	assert.Equal(t, Generate(preset, 7), Generate(preset, 7))
+	assert.NotEqual(t, Generate(preset, 7), Generate(preset, 8))
+}
+
+func TestGenerateCounts(t *testing.T) {
+	preset, err := GetPreset("medium")
+	assert.Nil(t, err)
+
+	merchants := Generate(preset, 1)
+	assert.Equal(t, preset.Merchants, len(merchants))
+
+	codes := map[string]bool{}
+	emails := map[string]bool{}
+	for _, merchant := range merchants {
+		assert.Equal(t, preset.OutletsPerMerchant, len(merchant.Outlets))
+		assert.Equal(t, preset.OutletsPerMerchant, len(merchant.CustomPrices))
+		assert.Equal(t, preset.ProductsPerMerch, len(merchant.Products))
+
+		emails[merchant.Owner.Email] = true
+		for _, outlet := range merchant.Outlets {
+			assert.Equal(t, preset.EmployeesPerOutlet, len(outlet.Employees))
+			for _, employee := range outlet.Employees {
+				emails[employee.Email] = true
+			}
+		}
+
+		names := map[string]bool{}
+		for _, product := range merchant.Products {
+			codes[product.Code] = true
+			names[product.Name] = true
+			assert.True(t, product.Sell >= product.Buy)
+			assert.Equal(t, 0, product.Buy%priceStep)
+		}
+		assert.Equal(t, preset.ProductsPerMerch, len(names))
+	}
+	assert.Equal(t, preset.Merchants*preset.ProductsPerMerch, len(codes))
+	assert.Equal(t, preset.Merchants*(1+preset.OutletsPerMerchant*preset.EmployeesPerOutlet), len(emails))
+}
+
+func TestGetPresetUnknown(t *testing.T) {
+	_, err := GetPreset("huge")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,63 @@
+package seeder
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Preset ukuran data demo, large dipakai untuk uji beban
+type Preset struct {
+	Name               string
+	Merchants          int
+	OutletsPerMerchant int
+	EmployeesPerOutlet int
+	ProductsPerMerch   int
+	// CustomPriceRatio porsi product yang memiliki harga khusus pada setiap outlet
+	CustomPriceRatio float64
+}
+
+var presets = map[string]Preset{
+	"small": {
+		Name:               "small",
+		Merchants:          1,
+		OutletsPerMerchant: 2,
+		EmployeesPerOutlet: 1,
+		ProductsPerMerch:   100,
+		CustomPriceRatio:   0.1,
+	},
+	"medium": {
+		Name:               "medium",
+		Merchants:          5,
+		OutletsPerMerchant: 3,
+		EmployeesPerOutlet: 2,
+		ProductsPerMerch:   300,
+		CustomPriceRatio:   0.2,
+	},
+	"large": {
+		Name:               "large",
+		Merchants:          20,
+		OutletsPerMerchant: 5,
+		EmployeesPerOutlet: 3,
+		ProductsPerMerch:   1000,
+		CustomPriceRatio:   0.3,
+	},
+}
+
+// GetPreset mengembalikan preset small, medium atau large
+func GetPreset(name string) (Preset, error) {
+	preset, ok := presets[name]
+	if !ok {
+		return Preset{}, fmt.Errorf("preset %s tidak tersedia, gunakan %v", name, PresetNames())
+	}
+	return preset, nil
+}
+
+// PresetNames daftar nama preset terurut
+func PresetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,206 @@
+package seeder
+
+import (
+	"context"
+	"fmt"
+	"github.com/muchlist/mini_pos/configs/roles"
+	"github.com/muchlist/mini_pos/dao/merchant_dao"
+	"github.com/muchlist/mini_pos/dao/outlet_dao"
+	"github.com/muchlist/mini_pos/dao/plan_dao"
+	"github.com/muchlist/mini_pos/dao/product_dao"
+	"github.com/muchlist/mini_pos/dao/tax_dao"
+	"github.com/muchlist/mini_pos/dao/user_dao"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/mcrypt"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"time"
+)
+
+// demoPlanName paket tanpa batas yang dipasang pada merchant demo agar preset besar tidak terhalang kuota
+const demoPlanName = "DEMO"
+
+// Result ringkasan data yang dibuat seeder
+type Result struct {
+	MerchantIDs  []int
+	Outlets      int
+	Users        int
+	Products     int
+	CustomPrices int
+	// Owners email owner setiap merchant dengan urutan yang sama dengan MerchantIDs
+	Owners []string
+}
+
+// Seeder menyimpan data hasil Generate melalui dao, service tidak dipakai karena
+// validasi kuota paket dan permission tidak relevan untuk data demo
+type Seeder struct {
+	merchantDao merchant_dao.MerchantDaoAssumer
+	planDao     plan_dao.PlanDaoAssumer
+	outletDao   outlet_dao.OutletDaoAssumer
+	userDao     user_dao.UserDaoAssumer
+	taxDao      tax_dao.TaxDaoAssumer
+	productDao  product_dao.ProductDaoAssumer
+	crypto      mcrypt.BcryptAssumer
+}
+
+func New(
+	merchantDao merchant_dao.MerchantDaoAssumer,
+	planDao plan_dao.PlanDaoAssumer,
+	outletDao outlet_dao.OutletDaoAssumer,
+	userDao user_dao.UserDaoAssumer,
+	taxDao tax_dao.TaxDaoAssumer,
+	productDao product_dao.ProductDaoAssumer,
+	crypto mcrypt.BcryptAssumer,
+) *Seeder {
+	return &Seeder{
+		merchantDao: merchantDao,
+		planDao:     planDao,
+		outletDao:   outletDao,
+		userDao:     userDao,
+		taxDao:      taxDao,
+		productDao:  productDao,
+		crypto:      crypto,
+	}
+}
+
+// Run membuat data demo sesuai preset dan seed, seluruh user memakai password yang sama
+func (s *Seeder) Run(ctx context.Context, preset Preset, seed int64, password string) (*Result, rest_err.APIError) {
+	// hash sekali saja, bcrypt untuk ratusan user akan sangat lambat
+	passwordHash, err := s.crypto.GenerateHash(password)
+	if err != nil {
+		return nil, err
+	}
+
+	planID, err := s.demoPlan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	for _, merchant := range Generate(preset, seed) {
+		if err := s.seedMerchant(ctx, merchant, planID, passwordHash, result); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// demoPlan mencari paket DEMO dan membuatnya apabila belum ada
+func (s *Seeder) demoPlan(ctx context.Context) (int, rest_err.APIError) {
+	plans, err := s.planDao.Find(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, plan := range plans {
+		if plan.Name == demoPlanName {
+			return plan.ID, nil
+		}
+	}
+	// batas 0 berarti tanpa batas
+	return s.planDao.Insert(ctx, dto.PlanModel{Name: demoPlanName})
+}
+
+func (s *Seeder) seedMerchant(ctx context.Context, merchant MerchantData, planID int, passwordHash string, result *Result) rest_err.APIError {
+	created, err := s.merchantDao.Insert(ctx, dto.MerchantCreateReq{
+		MerchantName:    merchant.Name,
+		Description:     "merchant demo",
+		OwnerEmail:      merchant.Owner.Email,
+		OwnerName:       merchant.Owner.Name,
+		DefaultPassword: passwordHash,
+	})
+	if err != nil {
+		return err
+	}
+	merchantID := created.MerchantID
+	result.MerchantIDs = append(result.MerchantIDs, merchantID)
+	result.Owners = append(result.Owners, created.OwnerEmail)
+	result.Users++
+
+	if err := s.planDao.SetMerchantPlan(ctx, merchantID, planID); err != nil {
+		return err
+	}
+
+	// ----------------------------------------------------------------- outlet dan employee
+	timeNow := time.Now().Unix()
+	outletIDs := make([]int, 0, len(merchant.Outlets))
+	for _, outlet := range merchant.Outlets {
+		outletID, err := s.outletDao.Insert(ctx, dto.OutletModel{
+			MerchantID: merchantID,
+			OutletName: dto.UppercaseString(outlet.Name),
+			Address:    outlet.Address,
+		})
+		if err != nil {
+			return err
+		}
+		outletIDs = append(outletIDs, outletID)
+		result.Outlets++
+
+		for _, employee := range outlet.Employees {
+			_, err := s.userDao.Insert(ctx, dto.UserModel{
+				MerchantID: merchantID,
+				DefOutlet:  outletID,
+				Name:       dto.UppercaseString(employee.Name),
+				Email:      dto.LowercaseString(employee.Email),
+				Password:   passwordHash,
+				Role:       roles.RoleEmployee,
+				CreatedAt:  timeNow,
+				UpdatedAt:  timeNow,
+			})
+			if err != nil {
+				return err
+			}
+			result.Users++
+		}
+	}
+
+	// ----------------------------------------------------------------- pajak dan product
+	taxID, err := s.taxDao.Insert(ctx, dto.TaxModel{
+		MerchantID: merchantID,
+		Name:       taxName,
+		Rate:       taxRate,
+	})
+	if err != nil {
+		return err
+	}
+
+	productIDs := make([]int, 0, len(merchant.Products))
+	for _, product := range merchant.Products {
+		input := dto.ProductModel{
+			MerchantID:      merchantID,
+			Code:            dto.UppercaseString(product.Code),
+			Name:            dto.UppercaseString(product.Name),
+			MasterBuyPrice:  product.Buy,
+			MasterSellPrice: product.Sell,
+		}
+		if product.Taxed {
+			input.TaxID = taxID
+		}
+		productID, err := s.productDao.Insert(ctx, input)
+		if err != nil {
+			return err
+		}
+		productIDs = append(productIDs, productID)
+		result.Products++
+	}
+
+	// ----------------------------------------------------------------- harga khusus outlet
+	for i, prices := range merchant.CustomPrices {
+		outletID := outletIDs[i]
+		for _, price := range prices {
+			productID := productIDs[price.ProductIndex]
+			_, err := s.productDao.InsertCustomPrice(ctx, dto.ProductPriceModel{
+				ID:        dto.UppercaseString(fmt.Sprintf("%d-%d", outletID, productID)),
+				ProductID: productID,
+				OutletID:  outletID,
+				BuyPrice:  price.Buy,
+				SellPrice: price.Sell,
+				UpdatedAt: timeNow,
+			})
+			if err != nil {
+				return err
+			}
+			result.CustomPrices++
+		}
+	}
+
+	return nil
+}
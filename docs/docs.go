@@ -31,14 +31,35 @@ var doc = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/current-outlet": {
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "kunci publik (RFC 7517) untuk memverifikasi access token RS256/EdDSA berdasarkan header kid, kunci lama tetap ditampilkan selama rotasi. Endpoint berada di luar /api/v1",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Access"
+                ],
+                "summary": "jwks",
+                "operationId": "jwks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mjwt.JWKS"
+                        }
+                    }
+                }
+            }
+        },
+        "/api-keys": {
             "get": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "menampilkan outlet berdasarkan user yang saat ini login",
+                "description": "menampilkan seluruh api key milik merchant termasuk yang sudah dicabut, secret tidak pernah ditampilkan",
                 "consumes": [
                     "application/json"
                 ],
@@ -46,10 +67,10 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Outlet"
+                    "ApiKey"
                 ],
-                "summary": "get outlet by current user",
-                "operationId": "outlet-curent",
+                "summary": "find api key",
+                "operationId": "api-key-find",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -62,7 +83,10 @@ var doc = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.OutletModel"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.APIKeyModel"
+                                            }
                                         }
                                     }
                                 }
@@ -106,11 +130,14 @@ var doc = `{
                         }
                     }
                 }
-            }
-        },
-        "/login": {
+            },
             "post": {
-                "description": "login menggunakan userID dan password untuk mendapatkan JWT Token",
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "membuat api key merchant dengan scope dan batasan outlet opsional, key hanya ditampilkan sekali dan dikirim melalui header Authorization: ApiKey {key}. Scope tidak boleh melebihi permission pembuat, memerlukan fresh token",
                 "consumes": [
                     "application/json"
                 ],
@@ -118,10 +145,10 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Access"
+                    "ApiKey"
                 ],
-                "summary": "login",
-                "operationId": "user-login",
+                "summary": "create api key",
+                "operationId": "api-key-create",
                 "parameters": [
                     {
                         "description": "Body raw JSON",
@@ -129,7 +156,7 @@ var doc = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UserLoginRequest"
+                            "$ref": "#/definitions/dto.APIKeyRequest"
                         }
                     }
                 ],
@@ -145,7 +172,7 @@ var doc = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.UserLoginResponse"
+                                            "$ref": "#/definitions/dto.APIKeyCreateResponse"
                                         }
                                     }
                                 }
@@ -191,14 +218,14 @@ var doc = `{
                 }
             }
         },
-        "/merchant": {
-            "get": {
+        "/api-keys/{id}": {
+            "delete": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "menampilkan daftar merchant",
+                "description": "mencabut api key, request berikutnya yang memakai key tersebut langsung ditolak",
                 "consumes": [
                     "application/json"
                 ],
@@ -206,28 +233,17 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Merchant"
+                    "ApiKey"
                 ],
-                "summary": "find merchant",
-                "operationId": "merchant-find",
+                "summary": "revoke api key",
+                "operationId": "api-key-revoke",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Limit",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Offset cursor untuk skip data sebanyak offsite",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search apabila di isi akan melakukan pencarian berdasarkan nama merchant",
-                        "name": "search",
-                        "in": "query"
+                        "description": "Api Key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -242,10 +258,7 @@ var doc = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/dto.Merchant"
-                                            }
+                                            "type": "string"
                                         }
                                     }
                                 }
@@ -289,14 +302,16 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/audit-logs": {
+            "get": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "Menambahkan merchant juga akan membuat user dengan role owner",
+                "description": "menampilkan catatan create/edit/delete entitas merchant beserta pelaku dan perubahan field, terbaru lebih dulu",
                 "consumes": [
                     "application/json"
                 ],
@@ -304,19 +319,58 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Merchant"
+                    "Audit"
                 ],
-                "summary": "create merchant and owner user",
-                "operationId": "merchant-create",
+                "summary": "find audit logs",
+                "operationId": "audit-log-find",
                 "parameters": [
                     {
-                        "description": "Body raw JSON",
-                        "name": "ReqBody",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.MerchantCreateReq"
-                        }
+                        "type": "string",
+                        "description": "merchant, merchant_setting, merchant_plan, plan, outlet, user, user_outlet, role, product, product_price, tax, tax_setting, device, api_key",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID entitas",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID user pelaku",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "create, edit, delete, revoke",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "unix time awal",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "unix time akhir",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset cursor untuk skip data sebanyak offsite",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -331,7 +385,10 @@ var doc = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.MerchantCreateRes"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.AuditLogModel"
+                                            }
                                         }
                                     }
                                 }
@@ -377,14 +434,14 @@ var doc = `{
                 }
             }
         },
-        "/merchant/{id}": {
-            "get": {
+        "/change-password": {
+            "put": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "menampilkan user berdasarkan userID",
+                "description": "mengganti password user yang login, memerlukan password lama dan fresh token",
                 "consumes": [
                     "application/json"
                 ],
@@ -392,17 +449,19 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Merchant"
+                    "Password"
                 ],
-                "summary": "get user by ID",
-                "operationId": "merchant-get",
+                "summary": "change password",
+                "operationId": "password-change",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Merchant ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChangePasswordRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -417,7 +476,7 @@ var doc = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.Merchant"
+                                            "type": "string"
                                         }
                                     }
                                 }
@@ -461,14 +520,16 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/current-outlet": {
+            "get": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "melakukan perubahan data pada merchant",
+                "description": "menampilkan outlet berdasarkan user yang saat ini login",
                 "consumes": [
                     "application/json"
                 ],
@@ -476,28 +537,10 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Merchant"
-                ],
-                "summary": "edit merchant",
-                "operationId": "merchant-edit",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Merchant ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Body raw JSON",
-                        "name": "ReqBody",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.MerchantEditReq"
-                        }
-                    }
+                    "Outlet"
                 ],
+                "summary": "get outlet by current user",
+                "operationId": "outlet-curent",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -510,7 +553,7 @@ var doc = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.Merchant"
+                                            "$ref": "#/definitions/dto.OutletModel"
                                         }
                                     }
                                 }
@@ -554,14 +597,11 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "menghapus merchant berdasarkan ID",
+            }
+        },
+        "/device/users": {
+            "get": {
+                "description": "menampilkan employee outlet perangkat yang sudah mengatur PIN, memerlukan header X-Device-Token",
                 "consumes": [
                     "application/json"
                 ],
@@ -569,16 +609,16 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Merchant"
+                    "Device"
                 ],
-                "summary": "delete merchant by ID",
-                "operationId": "merchant-delete",
+                "summary": "find device users",
+                "operationId": "device-users",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Merchant ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "Device Token",
+                        "name": "X-Device-Token",
+                        "in": "header",
                         "required": true
                     }
                 ],
@@ -586,7 +626,22 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wrap.RespMsgExample"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.DeviceUserModel"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -628,14 +683,14 @@ var doc = `{
                 }
             }
         },
-        "/outlets": {
+        "/devices": {
             "get": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "menampilkan daftar outlet",
+                "description": "menampilkan perangkat kasir aktif milik merchant",
                 "consumes": [
                     "application/json"
                 ],
@@ -643,30 +698,10 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Access"
-                ],
-                "summary": "find outlet",
-                "operationId": "outlet-find",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Limit",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Offset cursor untuk skip data sebanyak offsite",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search apabila di isi akan melakukan pencarian berdasarkan nama outlet",
-                        "name": "search",
-                        "in": "query"
-                    }
+                    "Device"
                 ],
+                "summary": "find device",
+                "operationId": "device-find",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -681,7 +716,7 @@ var doc = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/dto.OutletModel"
+                                                "$ref": "#/definitions/dto.DeviceModel"
                                             }
                                         }
                                     }
@@ -733,7 +768,7 @@ var doc = `{
                         "bearerAuth": []
                     }
                 ],
-                "description": "Menambahkan outlets sesuai dengan ID merchant yang melekat di user",
+                "description": "owner memasangkan perangkat kasir ke outlet, device_token hanya ditampilkan sekali dan dikirim melalui header X-Device-Token",
                 "consumes": [
                     "application/json"
                 ],
@@ -741,10 +776,10 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Outlet"
+                    "Device"
                 ],
-                "summary": "create outlet for merchant user",
-                "operationId": "outlet-create",
+                "summary": "register device",
+                "operationId": "device-register",
                 "parameters": [
                     {
                         "description": "Body raw JSON",
@@ -752,7 +787,7 @@ var doc = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.OutletCreateRequest"
+                            "$ref": "#/definitions/dto.DeviceRequest"
                         }
                     }
                 ],
@@ -768,7 +803,7 @@ var doc = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/wrap.RespMsgExample"
+                                            "$ref": "#/definitions/dto.DeviceRegisterResponse"
                                         }
                                     }
                                 }
@@ -814,14 +849,14 @@ var doc = `{
                 }
             }
         },
-        "/outlets/{id}": {
-            "get": {
+        "/devices/{id}": {
+            "delete": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "menampilkan outlet berdasarkan userID",
+                "description": "mencabut perangkat kasir, seluruh sesi login PIN dari perangkat tersebut ikut berakhir",
                 "consumes": [
                     "application/json"
                 ],
@@ -829,14 +864,14 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Outlet"
+                    "Device"
                 ],
-                "summary": "get outlet by ID",
-                "operationId": "outlet-get",
+                "summary": "revoke device",
+                "operationId": "device-revoke",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Outlet ID",
+                        "description": "Device ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -854,7 +889,7 @@ var doc = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.OutletModel"
+                                            "type": "string"
                                         }
                                     }
                                 }
@@ -898,14 +933,11 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "melakukan perubahan data pada outlet",
+            }
+        },
+        "/forgot-password": {
+            "post": {
+                "description": "mengirim link reset password ke email apabila email terdaftar",
                 "consumes": [
                     "application/json"
                 ],
@@ -913,25 +945,18 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Outlet"
+                    "Password"
                 ],
-                "summary": "edit outlet",
-                "operationId": "outlet-edit",
+                "summary": "forgot password",
+                "operationId": "password-forgot",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Outlet ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "description": "Body raw JSON",
                         "name": "ReqBody",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.OutletEditRequest"
+                            "$ref": "#/definitions/dto.ForgotPasswordRequest"
                         }
                     }
                 ],
@@ -947,7 +972,7 @@ var doc = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.OutletModel"
+                                            "type": "string"
                                         }
                                     }
                                 }
@@ -991,88 +1016,37 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "menghapus outlet berdasarkan ID",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "selalu 200 selama proses dapat melayani request. Endpoint berada di luar /api/v1",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Outlet"
-                ],
-                "summary": "delete outlet by ID",
-                "operationId": "outlet-delete",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Outlet ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Health"
                 ],
+                "summary": "liveness probe",
+                "operationId": "healthz",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wrap.RespMsgExample"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/wrap.Resp"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "error": {
-                                            "$ref": "#/definitions/wrap.ErrorExample400"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/wrap.Resp"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "error": {
-                                            "$ref": "#/definitions/wrap.ErrorExample500"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dto.ReadinessResponse"
                         }
                     }
                 }
             }
         },
-        "/products": {
+        "/impersonations": {
             "get": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "menampilkan daftar product untuk merchant tertentu, gunakan query outlet untuk mendapatkan harga product sesuai outlet",
+                "description": "menampilkan catatan impersonate yang dilakukan super user",
                 "consumes": [
                     "application/json"
                 ],
@@ -1080,33 +1054,27 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Merchant"
                 ],
-                "summary": "find product",
-                "operationId": "product-find",
+                "summary": "find impersonation log",
+                "operationId": "user-impersonation-find",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Limit",
-                        "name": "limit",
+                        "description": "filter merchant ID",
+                        "name": "merchant",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Offset cursor untuk skip data sebanyak offsite",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search apabila di isi akan melakukan pencarian berdasarkan nama outlet",
-                        "name": "search",
+                        "description": "Limit",
+                        "name": "limit",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "tambahkan outlet untuk melihat harga outlet tertentu",
-                        "name": "outlet",
+                        "description": "Offset cursor untuk skip data sebanyak offsite",
+                        "name": "offset",
                         "in": "query"
                     }
                 ],
@@ -1124,7 +1092,7 @@ var doc = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/dto.OutletModel"
+                                                "$ref": "#/definitions/dto.ImpersonationModel"
                                             }
                                         }
                                     }
@@ -1169,14 +1137,11 @@ var doc = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/login": {
             "post": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "Menambahkan product sesuai dengan ID merchant yang melekat di user",
+                "description": "login menggunakan userID dan password untuk mendapatkan JWT Token, login gagal berulang akan dikenai jeda dan penguncian akun sementara",
                 "consumes": [
                     "application/json"
                 ],
@@ -1184,10 +1149,10 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Access"
                 ],
-                "summary": "create product for merchant user",
-                "operationId": "product-create",
+                "summary": "login",
+                "operationId": "user-login",
                 "parameters": [
                     {
                         "description": "Body raw JSON",
@@ -1195,7 +1160,7 @@ var doc = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.ProductCreateRequest"
+                            "$ref": "#/definitions/dto.UserLoginRequest"
                         }
                     }
                 ],
@@ -1211,7 +1176,7 @@ var doc = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/wrap.RespMsgExample"
+                                            "$ref": "#/definitions/dto.UserLoginResponse"
                                         }
                                     }
                                 }
@@ -1236,6 +1201,24 @@ var doc = `{
                             ]
                         }
                     },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample429"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1257,14 +1240,9 @@ var doc = `{
                 }
             }
         },
-        "/products-image/{id}": {
+        "/login/2fa": {
             "post": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "menambahkan foto pada product",
+                "description": "menukar challenge token dari /login dan kode 2FA (atau recovery code) dengan access token dan refresh token",
                 "consumes": [
                     "application/json"
                 ],
@@ -1272,17 +1250,19 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Access"
                 ],
-                "summary": "menambahkan foto pada product",
-                "operationId": "product-upload-photo",
+                "summary": "login 2fa",
+                "operationId": "two-factor-login",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "file gambar",
-                        "name": "image",
-                        "in": "formData",
-                        "required": true
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TwoFactorLoginRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -1297,7 +1277,7 @@ var doc = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.ProductModel"
+                                            "$ref": "#/definitions/dto.UserLoginResponse"
                                         }
                                     }
                                 }
@@ -1343,14 +1323,9 @@ var doc = `{
                 }
             }
         },
-        "/products/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "bearerAuth": []
-                    }
-                ],
-                "description": "menampilkan product berdasarkan userID, query outlet untuk mendapatkan harga custom pada outlet tertentu",
+        "/login/2fa/setup": {
+            "post": {
+                "description": "bagi owner yang diwajibkan 2FA namun belum mendaftar (two_factor_setup true), membuat secret TOTP menggunakan challenge token dari /login",
                 "consumes": [
                     "application/json"
                 ],
@@ -1358,23 +1333,19 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Access"
                 ],
-                "summary": "get product by ID",
-                "operationId": "product-get",
+                "summary": "setup 2fa on login",
+                "operationId": "two-factor-login-setup",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Outlet Price",
-                        "name": "outlet",
-                        "in": "query"
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TwoFactorChallengeRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -1389,7 +1360,7 @@ var doc = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.ProductModel"
+                                            "$ref": "#/definitions/dto.TwoFactorEnrollResponse"
                                         }
                                     }
                                 }
@@ -1433,14 +1404,16 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/logout": {
+            "post": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "melakukan perubahan data pada product",
+                "description": "mencabut refresh token pada perangkat yang sedang dipakai",
                 "consumes": [
                     "application/json"
                 ],
@@ -1448,28 +1421,10 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
-                ],
-                "summary": "edit product",
-                "operationId": "product-edit",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Body raw JSON",
-                        "name": "ReqBody",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.ProductEditRequest"
-                        }
-                    }
+                    "Access"
                 ],
+                "summary": "logout",
+                "operationId": "user-logout",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1482,7 +1437,7 @@ var doc = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.OutletModel"
+                                            "type": "string"
                                         }
                                     }
                                 }
@@ -1526,14 +1481,16 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/logout-all": {
+            "post": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "menghapus product berdasarkan ID",
+                "description": "mencabut seluruh refresh token user pada semua perangkat",
                 "consumes": [
                     "application/json"
                 ],
@@ -1541,24 +1498,27 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Outlet"
-                ],
-                "summary": "delete product by ID",
-                "operationId": "product-delete",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Access"
                 ],
+                "summary": "logout all devices",
+                "operationId": "user-logout-all",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wrap.RespMsgExample"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1600,14 +1560,14 @@ var doc = `{
                 }
             }
         },
-        "/profile": {
+        "/merchant": {
             "get": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "menampilkan profile berdasarkan user yang login saat ini",
+                "description": "menampilkan daftar merchant",
                 "consumes": [
                     "application/json"
                 ],
@@ -1615,10 +1575,30 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Access"
+                    "Merchant"
+                ],
+                "summary": "find merchant",
+                "operationId": "merchant-find",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset cursor untuk skip data sebanyak offsite",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search apabila di isi akan melakukan pencarian berdasarkan nama merchant",
+                        "name": "search",
+                        "in": "query"
+                    }
                 ],
-                "summary": "get current profile",
-                "operationId": "user-profile",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1631,7 +1611,46 @@ var doc = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.UserModel"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.Merchant"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
                                         }
                                     }
                                 }
@@ -1639,11 +1658,14 @@ var doc = `{
                         }
                     }
                 }
-            }
-        },
-        "/refresh": {
+            },
             "post": {
-                "description": "mendapatkan token dengan tambahan waktu expired menggunakan refresh token",
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Menambahkan merchant juga akan membuat user dengan role owner",
                 "consumes": [
                     "application/json"
                 ],
@@ -1651,10 +1673,10 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Access"
+                    "Merchant"
                 ],
-                "summary": "refresh token",
-                "operationId": "user-refresh",
+                "summary": "create merchant and owner user",
+                "operationId": "merchant-create",
                 "parameters": [
                     {
                         "description": "Body raw JSON",
@@ -1662,7 +1684,7 @@ var doc = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UserRefreshTokenRequest"
+                            "$ref": "#/definitions/dto.MerchantCreateReq"
                         }
                     }
                 ],
@@ -1678,7 +1700,7 @@ var doc = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.UserRefreshTokenResponse"
+                                            "$ref": "#/definitions/dto.MerchantCreateRes"
                                         }
                                     }
                                 }
@@ -1724,14 +1746,14 @@ var doc = `{
                 }
             }
         },
-        "/set-price/{id}": {
-            "post": {
+        "/merchant-settings": {
+            "get": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "menambahkan harga custom product pada outlet tertentu",
+                "description": "menampilkan pengaturan mata uang, timezone, pembulatan tunai dan struk merchant user yang login",
                 "consumes": [
                     "application/json"
                 ],
@@ -1739,21 +1761,10 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
-                ],
-                "summary": "menambahkan harga custom",
-                "operationId": "product-set-price",
-                "parameters": [
-                    {
-                        "description": "Body raw JSON",
-                        "name": "ReqBody",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.ProductPriceRequest"
-                        }
-                    }
+                    "Setting"
                 ],
+                "summary": "get merchant setting",
+                "operationId": "setting-get",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1766,7 +1777,7 @@ var doc = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.OutletModel"
+                                            "$ref": "#/definitions/dto.MerchantSetting"
                                         }
                                     }
                                 }
@@ -1810,16 +1821,14 @@ var doc = `{
                         }
                     }
                 }
-            }
-        },
-        "/users": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "menampilkan daftar user",
+                "description": "merubah pengaturan mata uang (kode dan minor unit), timezone, pembulatan tunai (0, 100, 500) dan header footer struk, minor unit ditolak apabila merchant sudah memiliki product",
                 "consumes": [
                     "application/json"
                 ],
@@ -1827,28 +1836,19 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Access"
+                    "Setting"
                 ],
-                "summary": "find user",
-                "operationId": "user-find",
+                "summary": "edit merchant setting",
+                "operationId": "setting-edit",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Limit",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Offset cursor untuk skip data sebanyak offsite",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search apabila di isi akan melakukan pencarian berdasarkan nama",
-                        "name": "search",
-                        "in": "query"
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MerchantSettingRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -1863,10 +1863,7 @@ var doc = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/dto.UserModel"
-                                            }
+                                            "$ref": "#/definitions/dto.MerchantSetting"
                                         }
                                     }
                                 }
@@ -1910,14 +1907,16 @@ var doc = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/merchant-settings/logo": {
             "post": {
                 "security": [
                     {
                         "bearerAuth": []
                     }
                 ],
-                "description": "menambahkan user pada merchant sesuai usr owner, endpoint ini membutuhkan hak akses owner, sedangkan akun owner dapat didapatkan ketika membuat Merchant",
+                "description": "menambahkan logo merchant yang dipakai pada struk",
                 "consumes": [
                     "application/json"
                 ],
@@ -1925,26 +1924,36 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Access"
+                    "Setting"
                 ],
-                "summary": "register user",
-                "operationId": "user-register",
+                "summary": "menambahkan logo merchant",
+                "operationId": "setting-upload-logo",
                 "parameters": [
                     {
-                        "description": "Body raw JSON",
-                        "name": "ReqBody",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UserRegisterRequest"
-                        }
+                        "type": "file",
+                        "description": "file gambar",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/wrap.RespMsgExample"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.MerchantSetting"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1986,7 +1995,7 @@ var doc = `{
                 }
             }
         },
-        "/users/{id}": {
+        "/merchant/{id}": {
             "get": {
                 "security": [
                     {
@@ -2001,14 +2010,14 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Access"
+                    "Merchant"
                 ],
                 "summary": "get user by ID",
-                "operationId": "user-get",
+                "operationId": "merchant-get",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Merchant ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2026,7 +2035,7 @@ var doc = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.UserModel"
+                                            "$ref": "#/definitions/dto.Merchant"
                                         }
                                     }
                                 }
@@ -2077,7 +2086,7 @@ var doc = `{
                         "bearerAuth": []
                     }
                 ],
-                "description": "melakukan perubahan data pada user",
+                "description": "melakukan perubahan data pada merchant",
                 "consumes": [
                     "application/json"
                 ],
@@ -2085,14 +2094,14 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Access"
+                    "Merchant"
                 ],
-                "summary": "edit user",
-                "operationId": "user-edit",
+                "summary": "edit merchant",
+                "operationId": "merchant-edit",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Merchant ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2103,7 +2112,7 @@ var doc = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UserEditRequest"
+                            "$ref": "#/definitions/dto.MerchantEditReq"
                         }
                     }
                 ],
@@ -2119,7 +2128,7 @@ var doc = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.UserModel"
+                                            "$ref": "#/definitions/dto.Merchant"
                                         }
                                     }
                                 }
@@ -2170,7 +2179,7 @@ var doc = `{
                         "bearerAuth": []
                     }
                 ],
-                "description": "menghapus user berdasarkan userID",
+                "description": "menghapus merchant berdasarkan ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -2178,14 +2187,14 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Access"
+                    "Merchant"
                 ],
-                "summary": "delete user by ID",
-                "operationId": "user-delete",
+                "summary": "delete merchant by ID",
+                "operationId": "merchant-delete",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Merchant ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2236,62 +2245,5908 @@ var doc = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.Merchant": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "integer",
-                    "example": 1631341964
-                },
-                "id": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "merchant_name": {
-                    "type": "string",
-                    "example": "KUKUS-TOKO"
-                },
-                "updated_at": {
-                    "type": "integer",
-                    "example": 1631341964
-                }
-            }
         },
-        "dto.MerchantCreateReq": {
-            "type": "object",
-            "properties": {
-                "default_password": {
-                    "type": "string",
-                    "example": "secret"
-                },
-                "description": {
-                    "type": "string",
-                    "example": "penjualan barang barang tidak kasat mata"
-                },
-                "merchant_name": {
-                    "type": "string",
-                    "example": "KUKUS-TOKO"
-                },
-                "owner_email": {
-                    "type": "string",
-                    "example": "example@gmail.com"
-                },
-                "owner_name": {
-                    "type": "string",
-                    "example": "MUCHLIS"
+        "/merchant/{id}/impersonate": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "super user mendapatkan token akses berumur 15 menit atas nama owner merchant, setiap impersonate dicatat beserta alasannya",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Merchant"
+                ],
+                "summary": "impersonate merchant owner",
+                "operationId": "user-impersonate",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Merchant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImpersonateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ImpersonateResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/merchant/{id}/plan": {
+            "put": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "memindahkan merchant ke paket lain. khusus super user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Plan"
+                ],
+                "summary": "set merchant plan",
+                "operationId": "plan-set-merchant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Merchant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MerchantPlanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.MerchantUsage"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/outlets": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "menampilkan daftar outlet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Access"
+                ],
+                "summary": "find outlet",
+                "operationId": "outlet-find",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset cursor untuk skip data sebanyak offsite",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search apabila di isi akan melakukan pencarian berdasarkan nama outlet",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.OutletModel"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Menambahkan outlets sesuai dengan ID merchant yang melekat di user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Outlet"
+                ],
+                "summary": "create outlet for merchant user",
+                "operationId": "outlet-create",
+                "parameters": [
+                    {
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutletCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/wrap.RespMsgExample"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample402"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/outlets/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "menampilkan outlet berdasarkan userID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Outlet"
+                ],
+                "summary": "get outlet by ID",
+                "operationId": "outlet-get",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Outlet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.OutletModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "melakukan perubahan data pada outlet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Outlet"
+                ],
+                "summary": "edit outlet",
+                "operationId": "outlet-edit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Outlet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.OutletEditRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.OutletModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "menghapus outlet berdasarkan ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Outlet"
+                ],
+                "summary": "delete outlet by ID",
+                "operationId": "outlet-delete",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Outlet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/wrap.RespMsgExample"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/permissions": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "menampilkan daftar permission yang dapat dipakai pada custom role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role"
+                ],
+                "summary": "find permissions",
+                "operationId": "permission-find",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/pin-login": {
+            "post": {
+                "description": "berganti user pada perangkat kasir menggunakan PIN, token tidak fresh, tanpa refresh token dan hanya berlaku untuk outlet perangkat",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Access"
+                ],
+                "summary": "pin login",
+                "operationId": "device-pin-login",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device Token",
+                        "name": "X-Device-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PinLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.UserLoginResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/plans": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "menampilkan daftar paket langganan, nilai maksimal 0 berarti tidak terbatas",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Plan"
+                ],
+                "summary": "find plan",
+                "operationId": "plan-find",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.PlanModel"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "menambahkan paket langganan, nilai maksimal 0 berarti tidak terbatas. khusus super user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Plan"
+                ],
+                "summary": "create plan",
+                "operationId": "plan-create",
+                "parameters": [
+                    {
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PlanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/wrap.RespMsgExample"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/plans/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "merubah batas paket langganan. khusus super user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Plan"
+                ],
+                "summary": "edit plan",
+                "operationId": "plan-edit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Plan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PlanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PlanModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/products": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "menampilkan daftar product untuk merchant tertentu, gunakan query outlet untuk mendapatkan harga product sesuai outlet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "find product",
+                "operationId": "product-find",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset cursor untuk skip data sebanyak offsite",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search apabila di isi akan melakukan pencarian berdasarkan nama outlet",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "tambahkan outlet untuk melihat harga outlet tertentu",
+                        "name": "outlet",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.OutletModel"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Menambahkan product sesuai dengan ID merchant yang melekat di user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "create product for merchant user",
+                "operationId": "product-create",
+                "parameters": [
+                    {
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProductCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/wrap.RespMsgExample"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample402"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/products-image/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "menambahkan foto pada product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "menambahkan foto pada product",
+                "operationId": "product-upload-photo",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "file gambar",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ProductModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample402"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "menampilkan product berdasarkan userID, query outlet untuk mendapatkan harga custom pada outlet tertentu",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "get product by ID",
+                "operationId": "product-get",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Outlet Price",
+                        "name": "outlet",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ProductModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "melakukan perubahan data pada product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "edit product",
+                "operationId": "product-edit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProductEditRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.OutletModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "menghapus product berdasarkan ID beserta file gambarnya",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Outlet"
+                ],
+                "summary": "delete product by ID",
+                "operationId": "product-delete",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/wrap.RespMsgExample"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/profile": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "menampilkan profile berdasarkan user yang login saat ini",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Access"
+                ],
+                "summary": "get current profile",
+                "operationId": "user-profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.UserModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/profile/2fa": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "membuat secret TOTP dan provisioning uri (tampilkan sebagai QR code), 2FA aktif setelah dikonfirmasi melalui /profile/2fa/confirm",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TwoFactor"
+                ],
+                "summary": "enroll 2fa",
+                "operationId": "two-factor-enroll",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.TwoFactorEnrollResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/profile/2fa/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "mengaktifkan 2FA dengan kode dari aplikasi authenticator, response berisi recovery code yang hanya ditampilkan sekali",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TwoFactor"
+                ],
+                "summary": "confirm 2fa",
+                "operationId": "two-factor-confirm",
+                "parameters": [
+                    {
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TwoFactorCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.TwoFactorRecoveryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/profile/2fa/disable": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "menonaktifkan 2FA dengan kode atau recovery code, ditolak apabila merchant mewajibkan 2FA untuk owner",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TwoFactor"
+                ],
+                "summary": "disable 2fa",
+                "operationId": "two-factor-disable",
+                "parameters": [
+                    {
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TwoFactorCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/profile/outlet": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "mengganti outlet aktif dan mengembalikan access token baru dengan outlet tersebut, refresh token tetap dipakai. Outlet harus ditugaskan kepada user kecuali memiliki permission outlet.manage",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "switch active outlet",
+                "operationId": "profile-outlet-switch",
+                "parameters": [
+                    {
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SwitchOutletRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SwitchOutletResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/profile/pin": {
+            "put": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "mengatur PIN 4 sampai 6 digit untuk login cepat pada perangkat kasir, memerlukan fresh token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "set pin",
+                "operationId": "device-set-pin",
+                "parameters": [
+                    {
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PinRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/profile/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "menampilkan sesi login aktif milik user yang login beserta user agent, ip dan outlet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Access"
+                ],
+                "summary": "get my sessions",
+                "operationId": "user-profile-sessions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.SessionModel"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/profile/sessions/{sid}": {
+            "delete": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "mengakhiri salah satu sesi login milik user yang login",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Access"
+                ],
+                "summary": "terminate my session",
+                "operationId": "user-profile-session-delete",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "sid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "memeriksa koneksi database, versi migrasi dan direktori gambar, 503 apabila salah satu gagal atau aplikasi sedang shutdown. Endpoint berada di luar /api/v1",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "readiness probe",
+                "operationId": "readyz",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReadinessResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReadinessResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/refresh": {
+            "post": {
+                "description": "mendapatkan token dengan tambahan waktu expired menggunakan refresh token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Access"
+                ],
+                "summary": "refresh token",
+                "operationId": "user-refresh",
+                "parameters": [
+                    {
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserRefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.UserRefreshTokenResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/reset-password": {
+            "post": {
+                "description": "mengganti password memakai token dari link reset password, token hanya berlaku sekali",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Password"
+                ],
+                "summary": "reset password",
+                "operationId": "password-reset",
+                "parameters": [
+                    {
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/roles": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "menampilkan role bawaan (merchant_id 0) dan custom role milik merchant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role"
+                ],
+                "summary": "find roles",
+                "operationId": "role-find",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.RoleModel"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "menambahkan custom role berisi kumpulan permission untuk merchant user, permission harus dimiliki pembuat",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role"
+                ],
+                "summary": "create custom role",
+                "operationId": "role-create",
+                "parameters": [
+                    {
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/wrap.RespMsgExample"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/roles/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "mengubah nama dan permission custom role, permission harus dimiliki pengubah. Berlaku pada user setelah access token diperbarui",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role"
+                ],
+                "summary": "edit custom role",
+                "operationId": "role-edit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.RoleModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "menghapus custom role, user yang memakai role ini kembali memakai role bawaan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Role"
+                ],
+                "summary": "delete custom role by ID",
+                "operationId": "role-delete",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/wrap.RespMsgExample"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/set-price/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "menambahkan harga custom product pada outlet tertentu",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "menambahkan harga custom",
+                "operationId": "product-set-price",
+                "parameters": [
+                    {
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProductPriceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.OutletModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/signup": {
+            "post": {
+                "description": "mendaftarkan merchant baru, link verifikasi akan dikirim ke email owner. Merchant aktif setelah verifikasi. Response sama untuk email yang sudah terdaftar, namun email tidak dikirim",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Signup"
+                ],
+                "summary": "signup merchant",
+                "operationId": "signup",
+                "parameters": [
+                    {
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SignupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/signup/verify": {
+            "post": {
+                "description": "memverifikasi token dari email dan membuat password owner, merchant beserta outlet pertama yang ditugaskan kepada owner akan dibuat",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Signup"
+                ],
+                "summary": "verify signup merchant",
+                "operationId": "signup-verify",
+                "parameters": [
+                    {
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SignupVerifyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.MerchantCreateRes"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/tax-setting": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "menampilkan pengaturan harga termasuk pajak (inclusive) atau belum (exclusive) serta aturan pembulatan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tax"
+                ],
+                "summary": "get tax setting",
+                "operationId": "tax-setting-get",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.TaxSetting"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "merubah pengaturan inclusive/exclusive dan pembulatan (half_up, up, down) pajak merchant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tax"
+                ],
+                "summary": "edit tax setting",
+                "operationId": "tax-setting-edit",
+                "parameters": [
+                    {
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TaxSettingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.TaxSetting"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/taxes": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "menampilkan seluruh tarif pajak milik merchant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tax"
+                ],
+                "summary": "find tax",
+                "operationId": "tax-find",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.TaxModel"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "Menambahkan tarif pajak sesuai dengan ID merchant yang melekat di user, rate dalam basis poin (1100 = 11%)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tax"
+                ],
+                "summary": "create tax for merchant user",
+                "operationId": "tax-create",
+                "parameters": [
+                    {
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TaxCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/wrap.RespMsgExample"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/taxes/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "menampilkan tarif pajak berdasarkan ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tax"
+                ],
+                "summary": "get tax by ID",
+                "operationId": "tax-get",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tax ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.TaxModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "melakukan perubahan data pada tarif pajak",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tax"
+                ],
+                "summary": "edit tax",
+                "operationId": "tax-edit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tax ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TaxEditRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.TaxModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "menghapus tarif pajak berdasarkan ID, product yang memakai tarif ini menjadi bebas pajak",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tax"
+                ],
+                "summary": "delete tax by ID",
+                "operationId": "tax-delete",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tax ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/wrap.RespMsgExample"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/usage": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "menampilkan jumlah outlet, user, product dan ukuran gambar yang terpakai beserta batas paket merchant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Plan"
+                ],
+                "summary": "get merchant usage",
+                "operationId": "plan-usage",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.MerchantUsage"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "menampilkan daftar user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Access"
+                ],
+                "summary": "find user",
+                "operationId": "user-find",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset cursor untuk skip data sebanyak offsite",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search apabila di isi akan melakukan pencarian berdasarkan nama",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.UserModel"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "menambahkan user pada merchant sesuai usr owner, endpoint ini membutuhkan hak akses owner, sedangkan akun owner dapat didapatkan ketika membuat Merchant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Access"
+                ],
+                "summary": "register user",
+                "operationId": "user-register",
+                "parameters": [
+                    {
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserRegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/wrap.RespMsgExample"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample402"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "menampilkan user berdasarkan userID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Access"
+                ],
+                "summary": "get user by ID",
+                "operationId": "user-get",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.UserModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "melakukan perubahan data pada user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Access"
+                ],
+                "summary": "edit user",
+                "operationId": "user-edit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserEditRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.UserModel"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "menghapus user berdasarkan userID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Access"
+                ],
+                "summary": "delete user by ID",
+                "operationId": "user-delete",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/wrap.RespMsgExample"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/outlets": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "menampilkan outlet yang ditugaskan kepada user pada merchant yang sama",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "get user outlets",
+                "operationId": "user-outlet-get",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.OutletModel"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "mengganti seluruh outlet yang ditugaskan kepada user, user tanpa permission outlet.manage hanya dapat mengakses outlet tersebut. Outlet default user harus termasuk",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "set user outlets",
+                "operationId": "user-outlet-set",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Body raw JSON",
+                        "name": "ReqBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserOutletRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.OutletModel"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/reset-password": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "owner mengirim link reset password ke email employee pada merchant yang sama",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Password"
+                ],
+                "summary": "reset employee password",
+                "operationId": "password-owner-reset",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "owner menampilkan sesi login aktif user pada merchant yang sama",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "get user sessions",
+                "operationId": "user-sessions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.SessionModel"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/sessions/{sid}": {
+            "delete": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "owner mengakhiri sesi login user pada merchant yang sama",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "terminate user session",
+                "operationId": "user-session-delete",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "sid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/unlock": {
+            "post": {
+                "security": [
+                    {
+                        "bearerAuth": []
+                    }
+                ],
+                "description": "owner membuka kunci akun user pada merchant yang sama yang terkunci karena login gagal berulang",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "unlock user",
+                "operationId": "user-unlock",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample400"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/wrap.Resp"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "error": {
+                                            "$ref": "#/definitions/wrap.ErrorExample500"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/version": {
+            "get": {
+                "description": "commit dan waktu build diisi melalui ldflags saat build. Endpoint berada di luar /api/v1",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "build info",
+                "operationId": "version",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/buildinfo.Info"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "buildinfo.Info": {
+            "type": "object",
+            "properties": {
+                "build_time": {
+                    "type": "string",
+                    "example": "2021-10-19T08:00:00Z"
+                },
+                "commit": {
+                    "type": "string",
+                    "example": "7acacac"
+                },
+                "go_version": {
+                    "type": "string",
+                    "example": "go1.17"
+                }
+            }
+        },
+        "dto.APIKeyCreateResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "integer",
+                    "example": 1631341964
+                },
+                "created_at_text": {
+                    "description": "sesuai timezone merchant",
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
+                },
+                "created_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "expired_at": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "expired_at_text": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "key": {
+                    "type": "string",
+                    "example": "mpk_3f9a1c7d20be_9b1c..."
+                },
+                "last_used_at": {
+                    "type": "integer",
+                    "example": 1631341964
+                },
+                "last_used_at_text": {
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
+                },
+                "merchant_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "integrasi marketplace"
+                },
+                "outlet_id": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "3f9a1c7d20be"
+                },
+                "revoked_at": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "revoked_at_text": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "product.edit",
+                        "price.set"
+                    ]
+                }
+            }
+        },
+        "dto.APIKeyModel": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "integer",
+                    "example": 1631341964
+                },
+                "created_at_text": {
+                    "description": "sesuai timezone merchant",
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
+                },
+                "created_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "expired_at": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "expired_at_text": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_used_at": {
+                    "type": "integer",
+                    "example": 1631341964
+                },
+                "last_used_at_text": {
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
+                },
+                "merchant_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "integrasi marketplace"
+                },
+                "outlet_id": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "3f9a1c7d20be"
+                },
+                "revoked_at": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "revoked_at_text": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "product.edit",
+                        "price.set"
+                    ]
+                }
+            }
+        },
+        "dto.APIKeyRequest": {
+            "type": "object",
+            "properties": {
+                "expired_at": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "name": {
+                    "type": "string",
+                    "example": "integrasi marketplace"
+                },
+                "outlet_id": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "product.edit",
+                        "price.set"
+                    ]
+                }
+            }
+        },
+        "dto.AuditLogModel": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "edit"
+                },
+                "actor_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "api_key_id": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "changes": {
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "integer",
+                    "example": 1631341964
+                },
+                "created_at_text": {
+                    "description": "sesuai timezone merchant",
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
+                },
+                "entity_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "entity_type": {
+                    "type": "string",
+                    "example": "product"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "impersonator_id": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "ip": {
+                    "type": "string",
+                    "example": "127.0.0.1"
+                },
+                "merchant_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "0c5b4a3e-1f2d-4c1b-9a7e-2e0f3b1d6c11"
+                }
+            }
+        },
+        "dto.ChangePasswordRequest": {
+            "type": "object",
+            "properties": {
+                "current_password": {
+                    "type": "string",
+                    "example": "password123"
+                },
+                "new_password": {
+                    "type": "string",
+                    "example": "password456"
+                }
+            }
+        },
+        "dto.DeviceModel": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "integer",
+                    "example": 1631341964
+                },
+                "created_at_text": {
+                    "description": "sesuai timezone merchant",
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
+                },
+                "created_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_used_at": {
+                    "type": "integer",
+                    "example": 1631341964
+                },
+                "last_used_at_text": {
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
+                },
+                "merchant_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "KASIR DEPAN"
+                },
+                "outlet_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.DeviceRegisterResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "integer",
+                    "example": 1631341964
+                },
+                "created_at_text": {
+                    "description": "sesuai timezone merchant",
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
+                },
+                "created_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "device_token": {
+                    "type": "string",
+                    "example": "5f2b9c1e..."
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_used_at": {
+                    "type": "integer",
+                    "example": 1631341964
+                },
+                "last_used_at_text": {
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
+                },
+                "merchant_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "KASIR DEPAN"
+                },
+                "outlet_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.DeviceRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "kasir depan"
+                },
+                "outlet_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.DeviceUserModel": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "name": {
+                    "type": "string",
+                    "example": "MUCHLIS"
+                }
+            }
+        },
+        "dto.ForgotPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "example@example.com"
+                }
+            }
+        },
+        "dto.HealthCheck": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "database"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "dto.ImpersonateRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "membantu owner memperbaiki harga product"
+                }
+            }
+        },
+        "dto.ImpersonateResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1N.ywibmFtZSI6IkR5cGUiOjB9.aFjz4esDQ4-_K3dMUmo"
+                },
+                "expired": {
+                    "type": "integer",
+                    "example": 1631342864
+                },
+                "expired_text": {
+                    "description": "sesuai timezone merchant",
+                    "type": "string",
+                    "example": "11-09-2021 14:47 WITA"
+                },
+                "merchant_id": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "owner_id": {
+                    "type": "integer",
+                    "example": 21
+                },
+                "owner_name": {
+                    "type": "string",
+                    "example": "MUCHLIS"
+                }
+            }
+        },
+        "dto.ImpersonationModel": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "integer",
+                    "example": 1631341964
+                },
+                "created_at_text": {
+                    "description": "sesuai timezone merchant",
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
+                },
+                "expired_at": {
+                    "type": "integer",
+                    "example": 1631342864
+                },
+                "expired_at_text": {
+                    "type": "string",
+                    "example": "11-09-2021 14:47 WITA"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "merchant_id": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "owner_id": {
+                    "type": "integer",
+                    "example": 21
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "membantu owner memperbaiki harga product"
+                },
+                "super_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.Merchant": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "integer",
+                    "example": 1631341964
+                },
+                "created_at_text": {
+                    "description": "sesuai timezone merchant",
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "merchant_name": {
+                    "type": "string",
+                    "example": "KUKUS-TOKO"
+                },
+                "updated_at": {
+                    "type": "integer",
+                    "example": 1631341964
+                },
+                "updated_at_text": {
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
+                }
+            }
+        },
+        "dto.MerchantCreateReq": {
+            "type": "object",
+            "properties": {
+                "default_password": {
+                    "type": "string",
+                    "example": "secret"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "penjualan barang barang tidak kasat mata"
+                },
+                "merchant_name": {
+                    "type": "string",
+                    "example": "KUKUS-TOKO"
+                },
+                "owner_email": {
+                    "type": "string",
+                    "example": "example@gmail.com"
+                },
+                "owner_name": {
+                    "type": "string",
+                    "example": "MUCHLIS"
+                }
+            }
+        },
+        "dto.MerchantCreateRes": {
+            "type": "object",
+            "properties": {
+                "merchant_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "merchant_name": {
+                    "type": "string",
+                    "example": "KUKUS-TOKO"
+                },
+                "owner_email": {
+                    "type": "string",
+                    "example": "example@gmail.com"
+                },
+                "owner_name": {
+                    "type": "string",
+                    "example": "MUCHLIS"
+                }
+            }
+        },
+        "dto.MerchantEditReq": {
+            "type": "object",
+            "properties": {
+                "merchant_name": {
+                    "type": "string",
+                    "example": "KUKUS TOKO"
+                }
+            }
+        },
+        "dto.MerchantPlanRequest": {
+            "type": "object",
+            "properties": {
+                "plan_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.MerchantSetting": {
+            "type": "object",
+            "properties": {
+                "cash_rounding": {
+                    "description": "0, 100, 500",
+                    "type": "integer",
+                    "example": 100
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "logo": {
+                    "type": "string",
+                    "example": "image/merchants/11634211915.jpg"
+                },
+                "merchant_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "minor_unit": {
+                    "description": "jumlah digit desimal mata uang, harga disimpan dalam satuan ini",
+                    "type": "integer",
+                    "example": 0
+                },
+                "receipt_footer": {
+                    "type": "string",
+                    "example": "Terima kasih atas kunjungan anda"
+                },
+                "receipt_header": {
+                    "type": "string",
+                    "example": "KUKUS TOKO - Jl Pangeran Samudera"
+                },
+                "require_owner_2fa": {
+                    "description": "RequireOwner2FA owner wajib memakai two factor authentication ketika login",
+                    "type": "boolean",
+                    "example": false
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Makassar"
+                },
+                "updated_at": {
+                    "type": "integer",
+                    "example": 1631341964
+                },
+                "updated_at_text": {
+                    "description": "sesuai timezone merchant",
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
+                }
+            }
+        },
+        "dto.MerchantSettingRequest": {
+            "type": "object",
+            "properties": {
+                "cash_rounding": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "minor_unit": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "receipt_footer": {
+                    "type": "string",
+                    "example": "Terima kasih atas kunjungan anda"
+                },
+                "receipt_header": {
+                    "type": "string",
+                    "example": "KUKUS TOKO - Jl Pangeran Samudera"
+                },
+                "require_owner_2fa": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Makassar"
+                }
+            }
+        },
+        "dto.MerchantUsage": {
+            "type": "object",
+            "properties": {
+                "image_bytes": {
+                    "type": "integer",
+                    "example": 1048576
+                },
+                "merchant_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "outlets": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "plan": {
+                    "$ref": "#/definitions/dto.PlanModel"
+                },
+                "products": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "users": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "dto.OutletCreateRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "Jl Pangeran Samudera"
+                },
+                "outlet_name": {
+                    "type": "string",
+                    "example": "BLOK B"
+                }
+            }
+        },
+        "dto.OutletEditRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "Jl Pangeran Samudera"
+                },
+                "outlet_name": {
+                    "type": "string",
+                    "example": "BLOK B"
+                }
+            }
+        },
+        "dto.OutletModel": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "Jl Pangeran Samudera"
+                },
+                "created_at": {
+                    "type": "integer",
+                    "example": 1631341964
+                },
+                "created_at_text": {
+                    "description": "sesuai timezone merchant",
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "merchant_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "outlet_name": {
+                    "type": "string",
+                    "example": "BLOK B"
+                },
+                "updated_at": {
+                    "type": "integer",
+                    "example": 1631341964
+                },
+                "updated_at_text": {
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
+                }
+            }
+        },
+        "dto.PinLoginRequest": {
+            "type": "object",
+            "properties": {
+                "pin": {
+                    "type": "string",
+                    "example": "1234"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "dto.PinRequest": {
+            "type": "object",
+            "properties": {
+                "pin": {
+                    "type": "string",
+                    "example": "1234"
+                }
+            }
+        },
+        "dto.PlanModel": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "integer",
+                    "example": 1631341964
+                },
+                "created_at_text": {
+                    "description": "sesuai timezone merchant",
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "max_image_bytes": {
+                    "type": "integer",
+                    "example": 52428800
+                },
+                "max_outlets": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "max_products": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "max_users": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "name": {
+                    "type": "string",
+                    "example": "FREE"
+                },
+                "updated_at": {
+                    "type": "integer",
+                    "example": 1631341964
+                },
+                "updated_at_text": {
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
+                }
+            }
+        },
+        "dto.PlanRequest": {
+            "type": "object",
+            "properties": {
+                "max_image_bytes": {
+                    "type": "integer",
+                    "example": 52428800
+                },
+                "max_outlets": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "max_products": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "max_users": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "name": {
+                    "type": "string",
+                    "example": "FREE"
+                }
+            }
+        },
+        "dto.ProductCreateRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "SKU",
+                    "type": "string",
+                    "example": "CAT-20"
+                },
+                "master_buy_price": {
+                    "type": "integer",
+                    "example": 1000000
+                },
+                "master_sell_price": {
+                    "type": "integer",
+                    "example": 1050000
+                },
+                "name": {
+                    "type": "string",
+                    "example": "JAM TANGAN"
+                },
+                "tax_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.ProductEditRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "SKU",
+                    "type": "string",
+                    "example": "CAT-20"
+                },
+                "master_buy_price": {
+                    "type": "integer",
+                    "example": 1000000
+                },
+                "master_sell_price": {
+                    "type": "integer",
+                    "example": 1050000
+                },
+                "name": {
+                    "type": "string",
+                    "example": "JAM TANGAN"
+                },
+                "tax_id": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "dto.MerchantCreateRes": {
+        "dto.ProductModel": {
+            "type": "object",
+            "properties": {
+                "buy_price": {
+                    "description": "berasal dari table lain",
+                    "type": "integer",
+                    "example": 1000000
+                },
+                "buy_price_text": {
+                    "type": "string",
+                    "example": "Rp1.000.000"
+                },
+                "cash_price": {
+                    "description": "harga gross setelah pembulatan tunai",
+                    "type": "integer",
+                    "example": 1000000
+                },
+                "cash_price_text": {
+                    "type": "string",
+                    "example": "Rp1.000.000"
+                },
+                "code": {
+                    "description": "SKU",
+                    "type": "string",
+                    "example": "CAT-20"
+                },
+                "created_at": {
+                    "type": "integer",
+                    "example": 1631341964
+                },
+                "created_at_text": {
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "image": {
+                    "type": "string",
+                    "example": "image/products/121634211915.jpg"
+                },
+                "master_buy_price": {
+                    "type": "integer",
+                    "example": 1000000
+                },
+                "master_buy_price_text": {
+                    "type": "string",
+                    "example": "Rp1.000.000"
+                },
+                "master_sell_price": {
+                    "type": "integer",
+                    "example": 1050000
+                },
+                "master_sell_price_text": {
+                    "type": "string",
+                    "example": "Rp1.050.000"
+                },
+                "merchant_id": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "name": {
+                    "type": "string",
+                    "example": "JAM TANGAN"
+                },
+                "sell_price": {
+                    "description": "berasal dari table lain",
+                    "type": "integer",
+                    "example": 1000000
+                },
+                "sell_price_gross": {
+                    "description": "harga jual setelah pajak",
+                    "type": "integer",
+                    "example": 1000000
+                },
+                "sell_price_net": {
+                    "description": "harga jual sebelum pajak",
+                    "type": "integer",
+                    "example": 900901
+                },
+                "sell_price_net_text": {
+                    "type": "string",
+                    "example": "Rp900.901"
+                },
+                "sell_price_tax": {
+                    "description": "nilai pajak dari harga jual",
+                    "type": "integer",
+                    "example": 99099
+                },
+                "sell_price_tax_text": {
+                    "type": "string",
+                    "example": "Rp99.099"
+                },
+                "sell_price_text": {
+                    "description": "harga gross sesuai format mata uang merchant",
+                    "type": "string",
+                    "example": "Rp1.000.000"
+                },
+                "tax_id": {
+                    "description": "0 berarti bebas pajak",
+                    "type": "integer",
+                    "example": 1
+                },
+                "tax_rate": {
+                    "description": "basis poin, 1100 = 11%",
+                    "type": "integer",
+                    "example": 1100
+                },
+                "updated_at": {
+                    "type": "integer",
+                    "example": 1631341964
+                },
+                "updated_at_text": {
+                    "description": "sesuai timezone merchant",
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
+                }
+            }
+        },
+        "dto.ProductPriceRequest": {
+            "type": "object",
+            "properties": {
+                "buy_price": {
+                    "type": "integer",
+                    "example": 1000000
+                },
+                "outlet_id": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "sell_price": {
+                    "type": "integer",
+                    "example": 1050000
+                }
+            }
+        },
+        "dto.ReadinessResponse": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.HealthCheck"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "dto.ResetPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "new_password": {
+                    "type": "string",
+                    "example": "password456"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "5f2b...token dari email"
+                }
+            }
+        },
+        "dto.RoleModel": {
             "type": "object",
             "properties": {
+                "created_at": {
+                    "type": "integer",
+                    "example": 1631341964
+                },
+                "created_at_text": {
+                    "description": "sesuai timezone merchant",
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
                 "merchant_id": {
                     "type": "integer",
                     "example": 1
                 },
+                "name": {
+                    "type": "string",
+                    "example": "supervisor"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "product.edit",
+                        "price.set"
+                    ]
+                },
+                "updated_at": {
+                    "type": "integer",
+                    "example": 1631341964
+                },
+                "updated_at_text": {
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
+                }
+            }
+        },
+        "dto.RoleRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "supervisor"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "product.edit",
+                        "price.set"
+                    ]
+                }
+            }
+        },
+        "dto.SessionModel": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "integer",
+                    "example": 1631341964
+                },
+                "created_at_text": {
+                    "description": "sesuai timezone merchant",
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
+                },
+                "current": {
+                    "description": "sesi yang sedang dipakai untuk request ini",
+                    "type": "boolean",
+                    "example": true
+                },
+                "device_id": {
+                    "description": "terisi apabila login PIN melalui perangkat kasir",
+                    "type": "integer",
+                    "example": 0
+                },
+                "expired_at": {
+                    "type": "integer",
+                    "example": 1631341964
+                },
+                "expired_at_text": {
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015"
+                },
+                "ip": {
+                    "type": "string",
+                    "example": "10.0.0.1"
+                },
+                "last_seen_at": {
+                    "type": "integer",
+                    "example": 1631341964
+                },
+                "last_seen_at_text": {
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
+                },
+                "outlet_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "user_agent": {
+                    "type": "string",
+                    "example": "Mozilla/5.0 (Linux; Android 11)"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.SignupRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "penjualan barang barang tidak kasat mata"
+                },
                 "merchant_name": {
                     "type": "string",
                     "example": "KUKUS-TOKO"
@@ -2306,52 +8161,88 @@ var doc = `{
                 }
             }
         },
-        "dto.MerchantEditReq": {
+        "dto.SignupVerifyRequest": {
             "type": "object",
             "properties": {
-                "merchant_name": {
+                "password": {
                     "type": "string",
-                    "example": "KUKUS TOKO"
+                    "example": "password123"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "5f2b...token dari email"
                 }
             }
         },
-        "dto.OutletCreateRequest": {
+        "dto.SwitchOutletRequest": {
             "type": "object",
             "properties": {
-                "address": {
+                "outlet_id": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "dto.SwitchOutletResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
                     "type": "string",
-                    "example": "Jl Pangeran Samudera"
+                    "example": "eyJhbGciOiJIUzI1N.ywibmFtZSI6IkR5cGUiOjB9.aFjz4esDQ4-_K3dMUmo"
                 },
-                "outlet_name": {
+                "expired": {
+                    "type": "integer",
+                    "example": 1631341964
+                },
+                "expired_text": {
+                    "description": "sesuai timezone merchant",
                     "type": "string",
-                    "example": "BLOK B"
+                    "example": "11-09-2021 14:32 WITA"
+                },
+                "outlet_id": {
+                    "type": "integer",
+                    "example": 2
                 }
             }
         },
-        "dto.OutletEditRequest": {
+        "dto.TaxCreateRequest": {
             "type": "object",
             "properties": {
-                "address": {
+                "name": {
                     "type": "string",
-                    "example": "Jl Pangeran Samudera"
+                    "example": "PPN"
                 },
-                "outlet_name": {
-                    "type": "string",
-                    "example": "BLOK B"
+                "rate": {
+                    "type": "integer",
+                    "example": 1100
                 }
             }
         },
-        "dto.OutletModel": {
+        "dto.TaxEditRequest": {
             "type": "object",
             "properties": {
-                "address": {
+                "name": {
                     "type": "string",
-                    "example": "Jl Pangeran Samudera"
+                    "example": "PPN"
                 },
+                "rate": {
+                    "type": "integer",
+                    "example": 1100
+                }
+            }
+        },
+        "dto.TaxModel": {
+            "type": "object",
+            "properties": {
                 "created_at": {
                     "type": "integer",
                     "example": 1631341964
                 },
+                "created_at_text": {
+                    "description": "sesuai timezone merchant",
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
+                },
                 "id": {
                     "type": "integer",
                     "example": 1
@@ -2360,136 +8251,132 @@ var doc = `{
                     "type": "integer",
                     "example": 1
                 },
-                "outlet_name": {
+                "name": {
                     "type": "string",
-                    "example": "BLOK B"
+                    "example": "PPN"
+                },
+                "rate": {
+                    "description": "basis poin, 1100 = 11%",
+                    "type": "integer",
+                    "example": 1100
                 },
                 "updated_at": {
                     "type": "integer",
                     "example": 1631341964
+                },
+                "updated_at_text": {
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
                 }
             }
         },
-        "dto.ProductCreateRequest": {
+        "dto.TaxSetting": {
             "type": "object",
             "properties": {
-                "code": {
-                    "description": "SKU",
-                    "type": "string",
-                    "example": "CAT-20"
+                "inclusive": {
+                    "description": "harga jual sudah termasuk pajak",
+                    "type": "boolean",
+                    "example": true
                 },
-                "master_buy_price": {
+                "merchant_id": {
                     "type": "integer",
-                    "example": 1000000
+                    "example": 1
                 },
-                "master_sell_price": {
-                    "type": "integer",
-                    "example": 1050000
+                "rounding": {
+                    "description": "half_up, up, down",
+                    "type": "string",
+                    "example": "half_up"
+                }
+            }
+        },
+        "dto.TaxSettingRequest": {
+            "type": "object",
+            "properties": {
+                "inclusive": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "name": {
+                "rounding": {
                     "type": "string",
-                    "example": "JAM TANGAN"
+                    "example": "half_up"
                 }
             }
         },
-        "dto.ProductEditRequest": {
+        "dto.TwoFactorChallengeRequest": {
+            "type": "object",
+            "properties": {
+                "challenge_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1N.ywibmFtZSI6IkR5cGUiOjB9.aFjz4esDQ4-_K3dMUmo"
+                }
+            }
+        },
+        "dto.TwoFactorCodeRequest": {
             "type": "object",
             "properties": {
                 "code": {
-                    "description": "SKU",
                     "type": "string",
-                    "example": "CAT-20"
-                },
-                "master_buy_price": {
-                    "type": "integer",
-                    "example": 1000000
-                },
-                "master_sell_price": {
-                    "type": "integer",
-                    "example": 1050000
+                    "example": "123456"
                 },
-                "name": {
+                "recovery_code": {
                     "type": "string",
-                    "example": "JAM TANGAN"
+                    "example": "a1b2c-d3e4f"
                 }
             }
         },
-        "dto.ProductModel": {
+        "dto.TwoFactorEnrollResponse": {
             "type": "object",
             "properties": {
-                "buy_price": {
-                    "description": "berasal dari table lain",
-                    "type": "integer",
-                    "example": 1000000
-                },
-                "code": {
-                    "description": "SKU",
+                "provisioning_uri": {
                     "type": "string",
-                    "example": "CAT-20"
-                },
-                "created_at": {
-                    "type": "integer",
-                    "example": 1631341964
+                    "example": "otpauth://totp/Mini%20POS:owner@example.com?secret=JBSWY3DPEHPK3PXP\u0026issuer=Mini+POS"
                 },
-                "id": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "image": {
+                "secret": {
                     "type": "string",
-                    "example": "image/products/121634211915.jpg"
-                },
-                "master_buy_price": {
-                    "type": "integer",
-                    "example": 1000000
-                },
-                "master_sell_price": {
-                    "type": "integer",
-                    "example": 1050000
-                },
-                "merchant_id": {
-                    "type": "integer",
-                    "example": 20
-                },
-                "name": {
+                    "example": "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+                }
+            }
+        },
+        "dto.TwoFactorLoginRequest": {
+            "type": "object",
+            "properties": {
+                "challenge_token": {
                     "type": "string",
-                    "example": "JAM TANGAN"
+                    "example": "eyJhbGciOiJIUzI1N.ywibmFtZSI6IkR5cGUiOjB9.aFjz4esDQ4-_K3dMUmo"
                 },
-                "sell_price": {
-                    "description": "berasal dari table lain",
-                    "type": "integer",
-                    "example": 1000000
+                "code": {
+                    "type": "string",
+                    "example": "123456"
                 },
-                "updated_at": {
-                    "type": "integer",
-                    "example": 1631341964
+                "recovery_code": {
+                    "type": "string",
+                    "example": "a1b2c-d3e4f"
                 }
             }
         },
-        "dto.ProductPriceRequest": {
+        "dto.TwoFactorRecoveryResponse": {
             "type": "object",
             "properties": {
-                "buy_price": {
-                    "type": "integer",
-                    "example": 1000000
-                },
-                "outlet_id": {
-                    "type": "integer",
-                    "example": 20
-                },
-                "product_id": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "sell_price": {
-                    "type": "integer",
-                    "example": 1050000
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "a1b2c-d3e4f",
+                        "0a9b8-c7d6e"
+                    ]
                 }
             }
         },
         "dto.UserEditRequest": {
             "type": "object",
             "properties": {
+                "custom_role_id": {
+                    "description": "CustomRoleID opsional, permission user mengikuti custom role ini",
+                    "type": "integer",
+                    "example": 0
+                },
                 "def_outlet": {
                     "type": "integer",
                     "example": 1
@@ -2526,6 +8413,10 @@ var doc = `{
                     "type": "string",
                     "example": "eyJhbGciOiJIUzI1N.ywibmFtZSI6IkR5cGUiOjB9.aFjz4esDQ4-_K3dMUmo"
                 },
+                "challenge_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1N.ywibmFtZSI6IkR5cGUiOjB9.aFjz4esDQ4-_K3dMUmo"
+                },
                 "def_outlet": {
                     "type": "integer",
                     "example": 1
@@ -2538,6 +8429,11 @@ var doc = `{
                     "type": "integer",
                     "example": 1631341964
                 },
+                "expired_text": {
+                    "description": "sesuai timezone merchant",
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
+                },
                 "id": {
                     "type": "integer",
                     "example": 1
@@ -2550,6 +8446,27 @@ var doc = `{
                     "type": "string",
                     "example": "muchlis"
                 },
+                "permissions": {
+                    "description": "Permissions permission yang melekat pada access token, \"*\" berarti seluruh permission",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "product.edit",
+                        "price.set"
+                    ]
+                },
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "a1b2c-d3e4f",
+                        "0a9b8-c7d6e"
+                    ]
+                },
                 "refresh_token": {
                     "type": "string",
                     "example": "eyJhbGciOiJIUzI1N.ywibmFtZSI6IkR5cGUiOjB9.aFjz4esDQ4-_K3dMUmo"
@@ -2557,6 +8474,16 @@ var doc = `{
                 "role": {
                     "type": "string",
                     "example": "owner,employee"
+                },
+                "two_factor_required": {
+                    "description": "TwoFactorRequired bernilai true apabila login masih memerlukan kode 2FA, access token dan\nrefresh token kosong, kirim ChallengeToken beserta kode ke /login/2fa",
+                    "type": "boolean",
+                    "example": false
+                },
+                "two_factor_setup": {
+                    "description": "TwoFactorSetup bernilai true apabila merchant mewajibkan 2FA namun user belum mendaftar,\nlakukan setup melalui /login/2fa/setup terlebih dahulu",
+                    "type": "boolean",
+                    "example": false
                 }
             }
         },
@@ -2567,6 +8494,16 @@ var doc = `{
                     "type": "integer",
                     "example": 1631341964
                 },
+                "created_at_text": {
+                    "description": "sesuai timezone merchant",
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
+                },
+                "custom_role_id": {
+                    "description": "CustomRoleID role buatan merchant yang menggantikan permission role bawaan, 0 berarti memakai role bawaan",
+                    "type": "integer",
+                    "example": 0
+                },
                 "def_outlet": {
                     "type": "integer",
                     "example": 1
@@ -2579,6 +8516,13 @@ var doc = `{
                     "type": "integer",
                     "example": 1
                 },
+                "locked_until": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "locked_until_text": {
+                    "type": "string"
+                },
                 "merchant_id": {
                     "type": "integer",
                     "example": 1
@@ -2594,6 +8538,25 @@ var doc = `{
                 "updated_at": {
                     "type": "integer",
                     "example": 1631341964
+                },
+                "updated_at_text": {
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
+                }
+            }
+        },
+        "dto.UserOutletRequest": {
+            "type": "object",
+            "properties": {
+                "outlet_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
                 }
             }
         },
@@ -2616,12 +8579,26 @@ var doc = `{
                 "expired": {
                     "type": "integer",
                     "example": 1631341964
+                },
+                "expired_text": {
+                    "description": "sesuai timezone merchant",
+                    "type": "string",
+                    "example": "11-09-2021 14:32 WITA"
+                },
+                "refresh_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1N.ywibmFtZSI6IkR5cGUiOjB9.aFjz4esDQ4-_K3dMUmo"
                 }
             }
         },
         "dto.UserRegisterRequest": {
             "type": "object",
             "properties": {
+                "custom_role_id": {
+                    "description": "CustomRoleID opsional, permission user mengikuti custom role ini",
+                    "type": "integer",
+                    "example": 0
+                },
                 "def_outlet": {
                     "type": "integer",
                     "example": 1
@@ -2644,6 +8621,46 @@ var doc = `{
                 }
             }
         },
+        "mjwt.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string"
+                },
+                "crv": {
+                    "type": "string"
+                },
+                "e": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string"
+                },
+                "kty": {
+                    "type": "string"
+                },
+                "n": {
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "string"
+                }
+            }
+        },
+        "mjwt.JWKS": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mjwt.JWK"
+                    }
+                }
+            }
+        },
         "wrap.ErrorExample400": {
             "type": "object",
             "properties": {
@@ -2671,6 +8688,52 @@ var doc = `{
                 }
             }
         },
+        "wrap.ErrorExample402": {
+            "type": "object",
+            "properties": {
+                "causes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "error": {
+                    "type": "string",
+                    "example": "quota_exceeded"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "kuota product paket FREE sudah habis (terpakai 100 dari 100), silahkan upgrade paket"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 402
+                }
+            }
+        },
+        "wrap.ErrorExample429": {
+            "type": "object",
+            "properties": {
+                "causes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "error": {
+                    "type": "string",
+                    "example": "too_many_requests"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Terlalu banyak percobaan login, coba lagi dalam 30 detik"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 429
+                }
+            }
+        },
         "wrap.ErrorExample500": {
             "type": "object",
             "properties": {
@@ -0,0 +1,78 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/muchlist/mini_pos/configs/permissions"
+	"github.com/muchlist/mini_pos/utils/mformat"
+	"github.com/muchlist/mini_pos/utils/sfunc"
+	"time"
+)
+
+// APIKeyModel kunci akses integrasi pihak ketiga milik merchant. Secret hanya disimpan dalam bentuk hash,
+// prefix dipakai untuk mencari kunci dan ditampilkan agar owner dapat mengenali kunci yang dipakai
+type APIKeyModel struct {
+	ID             int      `json:"id" example:"1"`
+	MerchantID     int      `json:"merchant_id" example:"1"`
+	OutletID       int      `json:"outlet_id" example:"0"`
+	Name           string   `json:"name" example:"integrasi marketplace"`
+	Prefix         string   `json:"prefix" example:"3f9a1c7d20be"`
+	SecretHash     string   `json:"-"`
+	Scopes         []string `json:"scopes" example:"product.edit,price.set"`
+	CreatedBy      int      `json:"created_by" example:"1"`
+	CreatedAt      int64    `json:"created_at" example:"1631341964"`
+	CreatedAtText  string   `json:"created_at_text" example:"11-09-2021 14:32 WITA"` // sesuai timezone merchant
+	ExpiredAt      int64    `json:"expired_at" example:"0"`
+	ExpiredAtText  string   `json:"expired_at_text"`
+	LastUsedAt     int64    `json:"last_used_at" example:"1631341964"`
+	LastUsedAtText string   `json:"last_used_at_text" example:"11-09-2021 14:32 WITA"`
+	RevokedAt      int64    `json:"revoked_at" example:"0"`
+	RevokedAtText  string   `json:"revoked_at_text"`
+}
+
+// ApplyFormat mengisi waktu dibuat, kadaluarsa, terakhir dipakai dan dicabut sesuai timezone merchant,
+// waktu yang bernilai 0 tetap kosong
+func (a *APIKeyModel) ApplyFormat(f mformat.Formatter) {
+	a.CreatedAtText = f.Time(a.CreatedAt)
+	a.ExpiredAtText = f.Time(a.ExpiredAt)
+	a.LastUsedAtText = f.Time(a.LastUsedAt)
+	a.RevokedAtText = f.Time(a.RevokedAt)
+}
+
+// APIKeyRequest outlet_id 0 berarti berlaku untuk seluruh outlet, expired_at 0 berarti tidak kadaluarsa
+type APIKeyRequest struct {
+	Name      string   `json:"name" example:"integrasi marketplace"`
+	Scopes    []string `json:"scopes" example:"product.edit,price.set"`
+	OutletID  int      `json:"outlet_id" example:"0"`
+	ExpiredAt int64    `json:"expired_at" example:"0"`
+}
+
+func (a APIKeyRequest) Validate() error {
+	if err := validation.ValidateStruct(&a,
+		validation.Field(&a.Name, validation.Required, validation.Length(1, 50)),
+		validation.Field(&a.Scopes, validation.Required),
+		validation.Field(&a.OutletID, validation.Min(0)),
+	); err != nil {
+		return err
+	}
+
+	for _, s := range a.Scopes {
+		if !sfunc.InSlice(s, permissions.GetPermissionsAvailable()) {
+			return errors.New(fmt.Sprintf("Scope %s tidak tersedia, gunakan %v", s, permissions.GetPermissionsAvailable()))
+		}
+	}
+
+	if a.ExpiredAt != 0 && a.ExpiredAt <= time.Now().Unix() {
+		return errors.New("expired_at harus di masa mendatang atau 0")
+	}
+
+	return nil
+}
+
+// APIKeyCreateResponse key hanya ditampilkan sekali ketika dibuat, kirim melalui header
+// Authorization: ApiKey <key>
+type APIKeyCreateResponse struct {
+	APIKeyModel
+	Key string `json:"key" example:"mpk_3f9a1c7d20be_9b1c..."`
+}
@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"encoding/json"
+	"github.com/muchlist/mini_pos/utils/mformat"
+)
+
+// AuditLogModel catatan perubahan entitas. Changes berisi field yang berubah dalam bentuk
+// {"field": {"before": .., "after": ..}}
+type AuditLogModel struct {
+	ID             int             `json:"id" example:"1"`
+	MerchantID     int             `json:"merchant_id" example:"1"`
+	ActorID        int             `json:"actor_id" example:"1"`
+	ImpersonatorID int             `json:"impersonator_id" example:"0"`
+	APIKeyID       int             `json:"api_key_id" example:"0"`
+	EntityType     string          `json:"entity_type" example:"product"`
+	EntityID       int             `json:"entity_id" example:"12"`
+	Action         string          `json:"action" example:"edit"`
+	Changes        json.RawMessage `json:"changes" swaggertype:"object"`
+	IP             string          `json:"ip" example:"127.0.0.1"`
+	RequestID      string          `json:"request_id" example:"0c5b4a3e-1f2d-4c1b-9a7e-2e0f3b1d6c11"`
+	CreatedAt      int64           `json:"created_at" example:"1631341964"`
+	CreatedAtText  string          `json:"created_at_text" example:"11-09-2021 14:32 WITA"` // sesuai timezone merchant
+}
+
+// ApplyFormat mengisi waktu perubahan sesuai timezone merchant
+func (a *AuditLogModel) ApplyFormat(f mformat.Formatter) {
+	a.CreatedAtText = f.Time(a.CreatedAt)
+}
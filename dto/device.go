@@ -0,0 +1,85 @@
+package dto
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/muchlist/mini_pos/utils/mformat"
+	"regexp"
+)
+
+var pinRegex = regexp.MustCompile(`^[0-9]{4,6}$`)
+
+// DeviceModel perangkat kasir yang dipasangkan owner ke sebuah outlet
+type DeviceModel struct {
+	ID             int             `json:"id" example:"1"`
+	MerchantID     int             `json:"merchant_id" example:"1"`
+	OutletID       int             `json:"outlet_id" example:"1"`
+	Name           UppercaseString `json:"name" example:"KASIR DEPAN"`
+	TokenHash      string          `json:"-"`
+	CreatedBy      int             `json:"created_by" example:"1"`
+	CreatedAt      int64           `json:"created_at" example:"1631341964"`
+	CreatedAtText  string          `json:"created_at_text" example:"11-09-2021 14:32 WITA"` // sesuai timezone merchant
+	LastUsedAt     int64           `json:"last_used_at" example:"1631341964"`
+	LastUsedAtText string          `json:"last_used_at_text" example:"11-09-2021 14:32 WITA"`
+}
+
+// ApplyFormat mengisi waktu pendaftaran dan pemakaian terakhir perangkat sesuai timezone merchant
+func (d *DeviceModel) ApplyFormat(f mformat.Formatter) {
+	d.CreatedAtText = f.Time(d.CreatedAt)
+	d.LastUsedAtText = f.Time(d.LastUsedAt)
+}
+
+type DeviceRequest struct {
+	Name     string `json:"name" example:"kasir depan"`
+	OutletID int    `json:"outlet_id" example:"1"`
+}
+
+func (d DeviceRequest) Validate() error {
+	if err := validation.ValidateStruct(&d,
+		validation.Field(&d.Name, validation.Required, validation.Length(1, 50)),
+		validation.Field(&d.OutletID, validation.Required),
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeviceRegisterResponse device token hanya ditampilkan sekali ketika perangkat didaftarkan,
+// simpan pada perangkat dan kirim melalui header X-Device-Token
+type DeviceRegisterResponse struct {
+	DeviceModel
+	DeviceToken string `json:"device_token" example:"5f2b9c1e..."`
+}
+
+// DeviceUserModel user yang dapat login PIN pada perangkat
+type DeviceUserModel struct {
+	ID   int    `json:"id" example:"2"`
+	Name string `json:"name" example:"MUCHLIS"`
+}
+
+type PinRequest struct {
+	Pin string `json:"pin" example:"1234"`
+}
+
+func (p PinRequest) Validate() error {
+	if err := validation.ValidateStruct(&p,
+		validation.Field(&p.Pin, validation.Required, validation.Match(pinRegex).Error("pin harus 4 sampai 6 digit angka")),
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+type PinLoginRequest struct {
+	UserID int    `json:"user_id" example:"2"`
+	Pin    string `json:"pin" example:"1234"`
+}
+
+func (p PinLoginRequest) Validate() error {
+	if err := validation.ValidateStruct(&p,
+		validation.Field(&p.UserID, validation.Required),
+		validation.Field(&p.Pin, validation.Required),
+	); err != nil {
+		return err
+	}
+	return nil
+}
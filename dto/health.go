@@ -0,0 +1,17 @@
+package dto
+
+const (
+	HealthOk   = "ok"
+	HealthFail = "fail"
+)
+
+type HealthCheck struct {
+	Name   string `json:"name" example:"database"`
+	Status string `json:"status" example:"ok"`
+	Error  string `json:"error,omitempty" example:""`
+}
+
+type ReadinessResponse struct {
+	Status string        `json:"status" example:"ok"`
+	Checks []HealthCheck `json:"checks"`
+}
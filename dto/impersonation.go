@@ -0,0 +1,51 @@
+package dto
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/muchlist/mini_pos/utils/mformat"
+)
+
+// ImpersonationModel catatan audit ketika super user masuk sebagai owner merchant
+type ImpersonationModel struct {
+	ID            int    `json:"id" example:"1"`
+	SuperID       int    `json:"super_id" example:"1"`
+	MerchantID    int    `json:"merchant_id" example:"20"`
+	OwnerID       int    `json:"owner_id" example:"21"`
+	Reason        string `json:"reason" example:"membantu owner memperbaiki harga product"`
+	CreatedAt     int64  `json:"created_at" example:"1631341964"`
+	CreatedAtText string `json:"created_at_text" example:"11-09-2021 14:32 WITA"` // sesuai timezone merchant
+	ExpiredAt     int64  `json:"expired_at" example:"1631342864"`
+	ExpiredAtText string `json:"expired_at_text" example:"11-09-2021 14:47 WITA"`
+}
+
+// ApplyFormat mengisi waktu mulai dan berakhirnya impersonasi sesuai timezone merchant yang dimasuki
+func (i *ImpersonationModel) ApplyFormat(f mformat.Formatter) {
+	i.CreatedAtText = f.Time(i.CreatedAt)
+	i.ExpiredAtText = f.Time(i.ExpiredAt)
+}
+
+type ImpersonateRequest struct {
+	MerchantID int    `json:"-"`
+	Reason     string `json:"reason" example:"membantu owner memperbaiki harga product"`
+}
+
+func (i ImpersonateRequest) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(&i.Reason, validation.Required, validation.Length(5, 500)),
+	)
+}
+
+// ImpersonateResponse token akses owner berumur pendek tanpa refresh token
+type ImpersonateResponse struct {
+	MerchantID  int    `json:"merchant_id" example:"20"`
+	OwnerID     int    `json:"owner_id" example:"21"`
+	OwnerName   string `json:"owner_name" example:"MUCHLIS"`
+	AccessToken string `json:"access_token" example:"eyJhbGciOiJIUzI1N.ywibmFtZSI6IkR5cGUiOjB9.aFjz4esDQ4-_K3dMUmo"`
+	Expired     int64  `json:"expired" example:"1631342864"`
+	ExpiredText string `json:"expired_text" example:"11-09-2021 14:47 WITA"` // sesuai timezone merchant
+}
+
+// ApplyFormat mengisi waktu kadaluarsa token impersonasi sesuai timezone merchant yang dimasuki
+func (i *ImpersonateResponse) ApplyFormat(f mformat.Formatter) {
+	i.ExpiredText = f.Time(i.Expired)
+}
@@ -3,14 +3,23 @@ package dto
 import (
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/muchlist/mini_pos/utils/mformat"
 	"time"
 )
 
 type Merchant struct {
-	Id           int    `json:"id" example:"1"`
-	MerchantName string `json:"merchant_name" example:"KUKUS-TOKO"`
-	CreatedAt    int64  `json:"created_at" example:"1631341964"`
-	UpdatedAt    int64  `json:"updated_at" example:"1631341964"`
+	Id            int    `json:"id" example:"1"`
+	MerchantName  string `json:"merchant_name" example:"KUKUS-TOKO"`
+	CreatedAt     int64  `json:"created_at" example:"1631341964"`
+	CreatedAtText string `json:"created_at_text" example:"11-09-2021 14:32 WITA"` // sesuai timezone merchant
+	UpdatedAt     int64  `json:"updated_at" example:"1631341964"`
+	UpdatedAtText string `json:"updated_at_text" example:"11-09-2021 14:32 WITA"`
+}
+
+// ApplyFormat mengisi waktu dibuat dan diubah sesuai timezone merchant tersebut
+func (m *Merchant) ApplyFormat(f mformat.Formatter) {
+	m.CreatedAtText = f.Time(m.CreatedAt)
+	m.UpdatedAtText = f.Time(m.UpdatedAt)
 }
 
 func (m *Merchant) Prepare() {
@@ -36,7 +45,7 @@ func (m MerchantCreateReq) Validate() error {
 		validation.Field(&m.MerchantName, validation.Required),
 		validation.Field(&m.OwnerName, validation.Required),
 		validation.Field(&m.OwnerEmail, validation.Required, is.Email),
-		validation.Field(&m.DefaultPassword, validation.Required, validation.Length(3, 20)),
+		validation.Field(&m.DefaultPassword, validation.Required, validation.Length(PasswordMinLength, PasswordMaxLength)),
 	); err != nil {
 		return err
 	}
@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/muchlist/mini_pos/utils/mformat"
+	"time"
+)
+
+// MerchantSetting pengaturan format uang, waktu dan struk pada merchant
+type MerchantSetting struct {
+	MerchantID    int    `json:"merchant_id" example:"1"`
+	Currency      string `json:"currency" example:"IDR"`
+	MinorUnit     int    `json:"minor_unit" example:"0"` // jumlah digit desimal mata uang, harga disimpan dalam satuan ini
+	Timezone      string `json:"timezone" example:"Asia/Makassar"`
+	CashRounding  int    `json:"cash_rounding" example:"100"` // 0, 100, 500
+	ReceiptHeader string `json:"receipt_header" example:"KUKUS TOKO - Jl Pangeran Samudera"`
+	ReceiptFooter string `json:"receipt_footer" example:"Terima kasih atas kunjungan anda"`
+	Logo          string `json:"logo" example:"image/merchants/11634211915.jpg"`
+	// RequireOwner2FA owner wajib memakai two factor authentication ketika login
+	RequireOwner2FA bool   `json:"require_owner_2fa" example:"false"`
+	UpdatedAt       int64  `json:"updated_at" example:"1631341964"`
+	UpdatedAtText   string `json:"updated_at_text" example:"11-09-2021 14:32 WITA"` // sesuai timezone merchant
+}
+
+// ApplyFormat mengisi waktu perubahan pengaturan memakai timezone yang tersimpan
+func (m *MerchantSetting) ApplyFormat(f mformat.Formatter) {
+	m.UpdatedAtText = f.Time(m.UpdatedAt)
+}
+
+// DefaultMerchantSetting dipakai apabila merchant belum pernah menyimpan pengaturan
+func DefaultMerchantSetting(merchantID int) MerchantSetting {
+	return MerchantSetting{
+		MerchantID:   merchantID,
+		Currency:     mformat.DefaultCurrency,
+		MinorUnit:    mformat.DefaultMinorUnit,
+		Timezone:     mformat.DefaultTimezone,
+		CashRounding: 0,
+	}
+}
+
+// Formatter membuat formatter uang dan waktu sesuai pengaturan, dipakai seluruh service untuk mengisi
+// field *_text pada response di samping nilai mentah (harga dalam minor unit dan unix detik)
+func (m MerchantSetting) Formatter() mformat.Formatter {
+	return mformat.NewFormatter(m.Currency, m.MinorUnit, m.Timezone, m.CashRounding)
+}
+
+type MerchantSettingRequest struct {
+	Currency        string `json:"currency" example:"IDR"`
+	MinorUnit       int    `json:"minor_unit" example:"0"`
+	Timezone        string `json:"timezone" example:"Asia/Makassar"`
+	CashRounding    int    `json:"cash_rounding" example:"100"`
+	ReceiptHeader   string `json:"receipt_header" example:"KUKUS TOKO - Jl Pangeran Samudera"`
+	ReceiptFooter   string `json:"receipt_footer" example:"Terima kasih atas kunjungan anda"`
+	RequireOwner2FA bool   `json:"require_owner_2fa" example:"false"`
+}
+
+func (m MerchantSettingRequest) Validate() error {
+	if err := validation.ValidateStruct(&m,
+		validation.Field(&m.Currency, validation.Required, validation.Length(3, 3)),
+		validation.Field(&m.MinorUnit, validation.Min(0), validation.Max(4)),
+		validation.Field(&m.Timezone, validation.Required),
+		validation.Field(&m.ReceiptHeader, validation.Length(0, 500)),
+		validation.Field(&m.ReceiptFooter, validation.Length(0, 500)),
+	); err != nil {
+		return err
+	}
+
+	if _, err := time.LoadLocation(m.Timezone); err != nil {
+		return errors.New(fmt.Sprintf("Timezone %s tidak dikenali, contoh : Asia/Jakarta", m.Timezone))
+	}
+
+	validRounding := false
+	for _, rounding := range mformat.GetCashRoundingAvailable() {
+		if m.CashRounding == rounding {
+			validRounding = true
+		}
+	}
+	if !validRounding {
+		return errors.New(fmt.Sprintf("Cash rounding yang dimasukkan salah, gunakan %v", mformat.GetCashRoundingAvailable()))
+	}
+
+	return nil
+}
@@ -1,14 +1,25 @@
 package dto
 
-import validation "github.com/go-ozzo/ozzo-validation/v4"
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/muchlist/mini_pos/utils/mformat"
+)
 
 type OutletModel struct {
-	ID         int             `json:"id" example:"1"`
-	MerchantID int             `json:"merchant_id" example:"1"`
-	OutletName UppercaseString `json:"outlet_name" example:"BLOK B"`
-	Address    string          `json:"address" example:"Jl Pangeran Samudera"`
-	CreatedAt  int64           `json:"created_at" example:"1631341964"`
-	UpdatedAt  int64           `json:"updated_at" example:"1631341964"`
+	ID            int             `json:"id" example:"1"`
+	MerchantID    int             `json:"merchant_id" example:"1"`
+	OutletName    UppercaseString `json:"outlet_name" example:"BLOK B"`
+	Address       string          `json:"address" example:"Jl Pangeran Samudera"`
+	CreatedAt     int64           `json:"created_at" example:"1631341964"`
+	CreatedAtText string          `json:"created_at_text" example:"11-09-2021 14:32 WITA"` // sesuai timezone merchant
+	UpdatedAt     int64           `json:"updated_at" example:"1631341964"`
+	UpdatedAtText string          `json:"updated_at_text" example:"11-09-2021 14:32 WITA"`
+}
+
+// ApplyFormat mengisi waktu dibuat dan diubah outlet sesuai timezone merchant
+func (o *OutletModel) ApplyFormat(f mformat.Formatter) {
+	o.CreatedAtText = f.Time(o.CreatedAt)
+	o.UpdatedAtText = f.Time(o.UpdatedAt)
 }
 
 type OutletCreateRequest struct {
@@ -0,0 +1,66 @@
+package dto
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+// PasswordMinLength dan PasswordMaxLength kebijakan panjang password seluruh user, termasuk super user dari konfigurasi
+const (
+	PasswordMinLength = 3
+	PasswordMaxLength = 20
+)
+
+// PasswordResetModel token reset password, token asli tidak disimpan hanya hash-nya
+type PasswordResetModel struct {
+	ID          int
+	UserID      int
+	TokenHash   string
+	RequestedBy int // 0 berarti diminta oleh user sendiri, selain itu id owner
+	CreatedAt   int64
+	ExpiredAt   int64
+	UsedAt      int64
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" example:"password123"`
+	NewPassword     string `json:"new_password" example:"password456"`
+}
+
+func (c ChangePasswordRequest) Validate() error {
+	if err := validation.ValidateStruct(&c,
+		validation.Field(&c.CurrentPassword, validation.Required),
+		validation.Field(&c.NewPassword, validation.Required, validation.Length(PasswordMinLength, PasswordMaxLength), validation.NotIn(c.CurrentPassword).Error("tidak boleh sama dengan password lama")),
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" example:"example@example.com"`
+}
+
+func (f ForgotPasswordRequest) Validate() error {
+	if err := validation.ValidateStruct(&f,
+		validation.Field(&f.Email, validation.Required, is.Email),
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" example:"5f2b...token dari email"`
+	NewPassword string `json:"new_password" example:"password456"`
+}
+
+func (r ResetPasswordRequest) Validate() error {
+	if err := validation.ValidateStruct(&r,
+		validation.Field(&r.Token, validation.Required),
+		validation.Field(&r.NewPassword, validation.Required, validation.Length(PasswordMinLength, PasswordMaxLength)),
+	); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package dto
+
+import (
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/muchlist/mini_pos/utils/mformat"
+)
+
+const (
+	QuotaOutlet  = "outlet"
+	QuotaUser    = "user"
+	QuotaProduct = "product"
+	QuotaImage   = "image" // dalam byte
+)
+
+// PlanModel paket langganan merchant, nilai maksimal 0 berarti tidak terbatas
+type PlanModel struct {
+	ID            int    `json:"id" example:"1"`
+	Name          string `json:"name" example:"FREE"`
+	MaxOutlets    int    `json:"max_outlets" example:"1"`
+	MaxUsers      int    `json:"max_users" example:"3"`
+	MaxProducts   int    `json:"max_products" example:"100"`
+	MaxImageBytes int64  `json:"max_image_bytes" example:"52428800"`
+	CreatedAt     int64  `json:"created_at" example:"1631341964"`
+	CreatedAtText string `json:"created_at_text" example:"11-09-2021 14:32 WITA"` // sesuai timezone merchant
+	UpdatedAt     int64  `json:"updated_at" example:"1631341964"`
+	UpdatedAtText string `json:"updated_at_text" example:"11-09-2021 14:32 WITA"`
+}
+
+// ApplyFormat mengisi waktu dibuat dan diubah paket, paket berlaku lintas merchant sehingga
+// timezone yang dipakai adalah milik merchant yang melihat atau timezone default untuk super user
+func (p *PlanModel) ApplyFormat(f mformat.Formatter) {
+	p.CreatedAtText = f.Time(p.CreatedAt)
+	p.UpdatedAtText = f.Time(p.UpdatedAt)
+}
+
+type PlanRequest struct {
+	ID            int    `json:"-"`
+	Name          string `json:"name" example:"FREE"`
+	MaxOutlets    int    `json:"max_outlets" example:"1"`
+	MaxUsers      int    `json:"max_users" example:"3"`
+	MaxProducts   int    `json:"max_products" example:"100"`
+	MaxImageBytes int64  `json:"max_image_bytes" example:"52428800"`
+}
+
+func (p PlanRequest) Validate() error {
+	if err := validation.ValidateStruct(&p,
+		validation.Field(&p.Name, validation.Required, validation.Length(1, 100)),
+		validation.Field(&p.MaxOutlets, validation.Min(0)),
+		validation.Field(&p.MaxUsers, validation.Min(0)),
+		validation.Field(&p.MaxProducts, validation.Min(0)),
+		validation.Field(&p.MaxImageBytes, validation.Min(int64(0))),
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+type MerchantPlanRequest struct {
+	MerchantID int `json:"-"`
+	PlanID     int `json:"plan_id" example:"1"`
+}
+
+func (m MerchantPlanRequest) Validate() error {
+	if err := validation.ValidateStruct(&m,
+		validation.Field(&m.PlanID, validation.Required),
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+// MerchantUsage pemakaian resource merchant dibandingkan dengan paketnya
+type MerchantUsage struct {
+	MerchantID int       `json:"merchant_id" example:"1"`
+	Plan       PlanModel `json:"plan"`
+	Outlets    int       `json:"outlets" example:"1"`
+	Users      int       `json:"users" example:"2"`
+	Products   int       `json:"products" example:"40"`
+	ImageBytes int64     `json:"image_bytes" example:"1048576"`
+}
+
+// ApplyFormat mengisi waktu paket sesuai timezone merchant pemakai
+func (m *MerchantUsage) ApplyFormat(f mformat.Formatter) {
+	m.Plan.ApplyFormat(f)
+}
+
+// CheckQuota mengembalikan error apabila penambahan amount pada resource melebihi batas paket
+func (m MerchantUsage) CheckQuota(resource string, amount int64) error {
+	var used, limit int64
+	switch resource {
+	case QuotaOutlet:
+		used, limit = int64(m.Outlets), int64(m.Plan.MaxOutlets)
+	case QuotaUser:
+		used, limit = int64(m.Users), int64(m.Plan.MaxUsers)
+	case QuotaProduct:
+		used, limit = int64(m.Products), int64(m.Plan.MaxProducts)
+	case QuotaImage:
+		used, limit = m.ImageBytes, m.Plan.MaxImageBytes
+	default:
+		return fmt.Errorf("resource %s tidak dikenali", resource)
+	}
+
+	// amount <= 0 tidak menambah pemakaian, misalnya mengganti gambar dengan ukuran lebih kecil
+	if limit == 0 || amount <= 0 || used+amount <= limit {
+		return nil
+	}
+	return fmt.Errorf("kuota %s paket %s sudah habis (terpakai %d dari %d), silahkan upgrade paket", resource, m.Plan.Name, used, limit)
+}
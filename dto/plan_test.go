@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerchantUsageCheckQuota(t *testing.T) {
+	usage := MerchantUsage{
+		Plan: PlanModel{
+			Name:          "FREE",
+			MaxOutlets:    1,
+			MaxUsers:      3,
+			MaxProducts:   0, // tidak terbatas
+			MaxImageBytes: 1000,
+		},
+		Outlets:    1,
+		Users:      2,
+		Products:   5000,
+		ImageBytes: 600,
+	}
+
+	assert.Error(t, usage.CheckQuota(QuotaOutlet, 1))
+	assert.NoError(t, usage.CheckQuota(QuotaUser, 1))
+	assert.Error(t, usage.CheckQuota(QuotaUser, 2))
+	assert.NoError(t, usage.CheckQuota(QuotaProduct, 1))
+	assert.NoError(t, usage.CheckQuota(QuotaImage, 400))
+	assert.Error(t, usage.CheckQuota(QuotaImage, 401))
+
+	// mengganti gambar yang lebih kecil selalu diijinkan
+	assert.NoError(t, usage.CheckQuota(QuotaImage, -100))
+	usage.ImageBytes = 1200 // melebihi batas setelah turun paket
+	assert.NoError(t, usage.CheckQuota(QuotaImage, -100))
+	assert.Error(t, usage.CheckQuota("unknown", 1))
+}
@@ -1,19 +1,51 @@
 package dto
 
-import validation "github.com/go-ozzo/ozzo-validation/v4"
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/muchlist/mini_pos/utils/mformat"
+)
 
 type ProductModel struct {
-	ID              int             `json:"id" example:"1"`
-	MerchantID      int             `json:"merchant_id" example:"20"`
-	Code            UppercaseString `json:"code" example:"CAT-20"` // SKU
-	Name            UppercaseString `json:"name" example:"JAM TANGAN"`
-	MasterBuyPrice  int             `json:"master_buy_price" example:"1000000"`
-	MasterSellPrice int             `json:"master_sell_price" example:"1050000"`
-	BuyPrice        int             `json:"buy_price" example:"1000000"`  // berasal dari table lain
-	SellPrice       int             `json:"sell_price" example:"1000000"` // berasal dari table lain
-	Image           string          `json:"image" example:"image/products/121634211915.jpg"`
-	CreatedAt       int64           `json:"created_at" example:"1631341964"`
-	UpdatedAt       int64           `json:"updated_at" example:"1631341964"`
+	ID                  int             `json:"id" example:"1"`
+	MerchantID          int             `json:"merchant_id" example:"20"`
+	Code                UppercaseString `json:"code" example:"CAT-20"` // SKU
+	Name                UppercaseString `json:"name" example:"JAM TANGAN"`
+	MasterBuyPrice      int             `json:"master_buy_price" example:"1000000"`
+	MasterBuyPriceText  string          `json:"master_buy_price_text" example:"Rp1.000.000"`
+	MasterSellPrice     int             `json:"master_sell_price" example:"1050000"`
+	MasterSellPriceText string          `json:"master_sell_price_text" example:"Rp1.050.000"`
+	BuyPrice            int             `json:"buy_price" example:"1000000"` // berasal dari table lain
+	BuyPriceText        string          `json:"buy_price_text" example:"Rp1.000.000"`
+	SellPrice           int             `json:"sell_price" example:"1000000"`    // berasal dari table lain
+	TaxID               int             `json:"tax_id" example:"1"`              // 0 berarti bebas pajak
+	TaxRate             int             `json:"tax_rate" example:"1100"`         // basis poin, 1100 = 11%
+	SellPriceNet        int             `json:"sell_price_net" example:"900901"` // harga jual sebelum pajak
+	SellPriceNetText    string          `json:"sell_price_net_text" example:"Rp900.901"`
+	SellPriceTax        int             `json:"sell_price_tax" example:"99099"` // nilai pajak dari harga jual
+	SellPriceTaxText    string          `json:"sell_price_tax_text" example:"Rp99.099"`
+	SellPriceGross      int             `json:"sell_price_gross" example:"1000000"`    // harga jual setelah pajak
+	SellPriceText       string          `json:"sell_price_text" example:"Rp1.000.000"` // harga gross sesuai format mata uang merchant
+	CashPrice           int             `json:"cash_price" example:"1000000"`          // harga gross setelah pembulatan tunai
+	CashPriceText       string          `json:"cash_price_text" example:"Rp1.000.000"`
+	Image               string          `json:"image" example:"image/products/121634211915.jpg"`
+	CreatedAt           int64           `json:"created_at" example:"1631341964"`
+	CreatedAtText       string          `json:"created_at_text" example:"11-09-2021 14:32 WITA"`
+	UpdatedAt           int64           `json:"updated_at" example:"1631341964"`
+	UpdatedAtText       string          `json:"updated_at_text" example:"11-09-2021 14:32 WITA"` // sesuai timezone merchant
+}
+
+// ApplyFormat mengisi seluruh harga dalam format mata uang merchant beserta waktu sesuai timezone merchant,
+// dipanggil setelah harga net, pajak dan gross dihitung
+func (p *ProductModel) ApplyFormat(f mformat.Formatter) {
+	p.MasterBuyPriceText = f.Money(p.MasterBuyPrice)
+	p.MasterSellPriceText = f.Money(p.MasterSellPrice)
+	p.BuyPriceText = f.Money(p.BuyPrice)
+	p.SellPriceNetText = f.Money(p.SellPriceNet)
+	p.SellPriceTaxText = f.Money(p.SellPriceTax)
+	p.SellPriceText = f.Money(p.SellPriceGross)
+	p.CashPriceText = f.Money(p.CashPrice)
+	p.CreatedAtText = f.Time(p.CreatedAt)
+	p.UpdatedAtText = f.Time(p.UpdatedAt)
 }
 
 type ProductCreateRequest struct {
@@ -21,6 +53,7 @@ type ProductCreateRequest struct {
 	Name            string `json:"name" example:"JAM TANGAN"`
 	MasterBuyPrice  int    `json:"master_buy_price" example:"1000000"`
 	MasterSellPrice int    `json:"master_sell_price" example:"1050000"`
+	TaxID           int    `json:"tax_id" example:"1"`
 }
 
 func (p ProductCreateRequest) Validate() error {
@@ -38,6 +71,7 @@ type ProductEditRequest struct {
 	Name            string `json:"name" example:"JAM TANGAN"`
 	MasterBuyPrice  int    `json:"master_buy_price" example:"1000000"`
 	MasterSellPrice int    `json:"master_sell_price" example:"1050000"`
+	TaxID           int    `json:"tax_id" example:"1"`
 }
 
 func (p ProductEditRequest) Validate() error {
@@ -56,6 +90,7 @@ type ProductEditModel struct {
 	Name            UppercaseString
 	MasterBuyPrice  int
 	MasterSellPrice int
+	TaxID           int
 }
 
 type ProductPriceModel struct {
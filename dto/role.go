@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/muchlist/mini_pos/configs/permissions"
+	"github.com/muchlist/mini_pos/utils/mformat"
+	"github.com/muchlist/mini_pos/utils/sfunc"
+)
+
+// RoleModel kumpulan permission, merchant_id 0 adalah role bawaan (owner, employee, customer)
+// yang berlaku untuk seluruh merchant dan tidak dapat diubah
+type RoleModel struct {
+	ID            int      `json:"id" example:"1"`
+	MerchantID    int      `json:"merchant_id" example:"1"`
+	Name          string   `json:"name" example:"supervisor"`
+	Permissions   []string `json:"permissions" example:"product.edit,price.set"`
+	CreatedAt     int64    `json:"created_at" example:"1631341964"`
+	CreatedAtText string   `json:"created_at_text" example:"11-09-2021 14:32 WITA"` // sesuai timezone merchant
+	UpdatedAt     int64    `json:"updated_at" example:"1631341964"`
+	UpdatedAtText string   `json:"updated_at_text" example:"11-09-2021 14:32 WITA"`
+}
+
+// ApplyFormat mengisi waktu dibuat dan diubah role sesuai timezone merchant
+func (r *RoleModel) ApplyFormat(f mformat.Formatter) {
+	r.CreatedAtText = f.Time(r.CreatedAt)
+	r.UpdatedAtText = f.Time(r.UpdatedAt)
+}
+
+type RoleRequest struct {
+	Name        string   `json:"name" example:"supervisor"`
+	Permissions []string `json:"permissions" example:"product.edit,price.set"`
+}
+
+func (r RoleRequest) Validate() error {
+	if err := validation.ValidateStruct(&r,
+		validation.Field(&r.Name, validation.Required, validation.Length(1, 50)),
+	); err != nil {
+		return err
+	}
+
+	for _, p := range r.Permissions {
+		if !sfunc.InSlice(p, permissions.GetPermissionsAvailable()) {
+			return errors.New(fmt.Sprintf("Permission %s tidak tersedia, gunakan %v", p, permissions.GetPermissionsAvailable()))
+		}
+	}
+
+	return nil
+}
+
+type RoleEditModel struct {
+	WhereID         int
+	WhereMerchantID int
+	Name            string
+	Permissions     []string
+	UpdatedAt       int64
+}
@@ -0,0 +1,33 @@
+package dto
+
+import "github.com/muchlist/mini_pos/utils/mformat"
+
+// SessionMeta informasi perangkat saat login
+type SessionMeta struct {
+	UserAgent string
+	IP        string
+}
+
+// SessionModel sesi login user, id sesi sama dengan family refresh token
+type SessionModel struct {
+	ID             string `json:"id" example:"9f86d081884c7d659a2feaa0c55ad015"`
+	UserID         int    `json:"user_id" example:"1"`
+	UserAgent      string `json:"user_agent" example:"Mozilla/5.0 (Linux; Android 11)"`
+	IP             string `json:"ip" example:"10.0.0.1"`
+	OutletID       int    `json:"outlet_id" example:"1"`
+	DeviceID       int    `json:"device_id" example:"0"` // terisi apabila login PIN melalui perangkat kasir
+	CreatedAt      int64  `json:"created_at" example:"1631341964"`
+	CreatedAtText  string `json:"created_at_text" example:"11-09-2021 14:32 WITA"` // sesuai timezone merchant
+	LastSeenAt     int64  `json:"last_seen_at" example:"1631341964"`
+	LastSeenAtText string `json:"last_seen_at_text" example:"11-09-2021 14:32 WITA"`
+	ExpiredAt      int64  `json:"expired_at" example:"1631341964"`
+	ExpiredAtText  string `json:"expired_at_text" example:"11-09-2021 14:32 WITA"`
+	Current        bool   `json:"current" example:"true"` // sesi yang sedang dipakai untuk request ini
+}
+
+// ApplyFormat mengisi waktu login, aktivitas terakhir dan kadaluarsa sesi sesuai timezone merchant
+func (s *SessionModel) ApplyFormat(f mformat.Formatter) {
+	s.CreatedAtText = f.Time(s.CreatedAt)
+	s.LastSeenAtText = f.Time(s.LastSeenAt)
+	s.ExpiredAtText = f.Time(s.ExpiredAt)
+}
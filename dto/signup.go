@@ -0,0 +1,60 @@
+package dto
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+// MerchantSignup pendaftaran merchant yang menunggu verifikasi email owner
+type MerchantSignup struct {
+	ID           int
+	MerchantName string
+	Description  string
+	OwnerName    string
+	OwnerEmail   string
+	TokenHash    string
+	CreatedAt    int64
+	ExpiredAt    int64
+	VerifiedAt   int64
+}
+
+type SignupRequest struct {
+	MerchantName string `json:"merchant_name" example:"KUKUS-TOKO"`
+	Description  string `json:"description" example:"penjualan barang barang tidak kasat mata"`
+	OwnerName    string `json:"owner_name" example:"MUCHLIS"`
+	OwnerEmail   string `json:"owner_email" example:"example@gmail.com"`
+}
+
+func (s SignupRequest) Validate() error {
+	if err := validation.ValidateStruct(&s,
+		validation.Field(&s.MerchantName, validation.Required, validation.Length(1, 255)),
+		validation.Field(&s.OwnerName, validation.Required, validation.Length(1, 100)),
+		validation.Field(&s.OwnerEmail, validation.Required, is.Email, validation.Length(1, 100)),
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+type SignupVerifyRequest struct {
+	Token    string `json:"token" example:"5f2b...token dari email"`
+	Password string `json:"password" example:"password123"`
+}
+
+func (s SignupVerifyRequest) Validate() error {
+	if err := validation.ValidateStruct(&s,
+		validation.Field(&s.Token, validation.Required),
+		validation.Field(&s.Password, validation.Required, validation.Length(PasswordMinLength, PasswordMaxLength)),
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+// SignupActivation data yang dipakai untuk mengaktifkan merchant dari pendaftaran
+type SignupActivation struct {
+	TokenHash     string
+	Password      string // sudah dalam bentuk hash
+	OutletName    string
+	OutletAddress string
+}
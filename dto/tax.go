@@ -0,0 +1,86 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/muchlist/mini_pos/utils/mformat"
+	"github.com/muchlist/mini_pos/utils/mtax"
+	"github.com/muchlist/mini_pos/utils/sfunc"
+)
+
+type TaxModel struct {
+	ID            int             `json:"id" example:"1"`
+	MerchantID    int             `json:"merchant_id" example:"1"`
+	Name          UppercaseString `json:"name" example:"PPN"`
+	Rate          int             `json:"rate" example:"1100"` // basis poin, 1100 = 11%
+	CreatedAt     int64           `json:"created_at" example:"1631341964"`
+	CreatedAtText string          `json:"created_at_text" example:"11-09-2021 14:32 WITA"` // sesuai timezone merchant
+	UpdatedAt     int64           `json:"updated_at" example:"1631341964"`
+	UpdatedAtText string          `json:"updated_at_text" example:"11-09-2021 14:32 WITA"`
+}
+
+// ApplyFormat mengisi waktu dibuat dan diubah pajak sesuai timezone merchant
+func (t *TaxModel) ApplyFormat(f mformat.Formatter) {
+	t.CreatedAtText = f.Time(t.CreatedAt)
+	t.UpdatedAtText = f.Time(t.UpdatedAt)
+}
+
+type TaxCreateRequest struct {
+	Name string `json:"name" example:"PPN"`
+	Rate int    `json:"rate" example:"1100"`
+}
+
+func (t TaxCreateRequest) Validate() error {
+	return validation.ValidateStruct(&t,
+		validation.Field(&t.Name, validation.Required),
+		validation.Field(&t.Rate, validation.Min(0), validation.Max(mtax.RateBase)),
+	)
+}
+
+type TaxEditRequest struct {
+	ID   int    `json:"-"`
+	Name string `json:"name" example:"PPN"`
+	Rate int    `json:"rate" example:"1100"`
+}
+
+func (t TaxEditRequest) Validate() error {
+	return validation.ValidateStruct(&t,
+		validation.Field(&t.Name, validation.Required),
+		validation.Field(&t.Rate, validation.Min(0), validation.Max(mtax.RateBase)),
+	)
+}
+
+type TaxEditModel struct {
+	WhereID         int
+	WhereMerchantID int
+	Name            UppercaseString
+	Rate            int
+	UpdatedAt       int64
+}
+
+// TaxSetting pengaturan pajak yang berlaku untuk seluruh product pada merchant
+type TaxSetting struct {
+	MerchantID int    `json:"merchant_id" example:"1"`
+	Inclusive  bool   `json:"inclusive" example:"true"`   // harga jual sudah termasuk pajak
+	Rounding   string `json:"rounding" example:"half_up"` // half_up, up, down
+}
+
+type TaxSettingRequest struct {
+	Inclusive bool   `json:"inclusive" example:"true"`
+	Rounding  string `json:"rounding" example:"half_up"`
+}
+
+func (t TaxSettingRequest) Validate() error {
+	if err := validation.ValidateStruct(&t,
+		validation.Field(&t.Rounding, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	if !sfunc.InSlice(t.Rounding, mtax.GetRoundingAvailable()) {
+		return errors.New(fmt.Sprintf("Rounding yang dimasukkan salah, gunakan %v", mtax.GetRoundingAvailable()))
+	}
+
+	return nil
+}
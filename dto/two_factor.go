@@ -0,0 +1,70 @@
+package dto
+
+import (
+	"errors"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// TotpModel secret TOTP user, EnabledAt 0 berarti enrollment belum dikonfirmasi
+type TotpModel struct {
+	UserID    int
+	Secret    string
+	EnabledAt int64
+	LastStep  int64 // step TOTP terakhir yang dipakai, mencegah kode dipakai ulang
+	CreatedAt int64
+}
+
+func (t TotpModel) IsEnabled() bool {
+	return t.EnabledAt != 0
+}
+
+// TwoFactorEnrollResponse secret dan uri otpauth:// untuk ditampilkan sebagai QR code
+type TwoFactorEnrollResponse struct {
+	Secret          string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	ProvisioningURI string `json:"provisioning_uri" example:"otpauth://totp/Mini%20POS:owner@example.com?secret=JBSWY3DPEHPK3PXP&issuer=Mini+POS"`
+}
+
+// TwoFactorCodeRequest isi salah satu, kode dari aplikasi authenticator atau recovery code
+type TwoFactorCodeRequest struct {
+	Code         string `json:"code" example:"123456"`
+	RecoveryCode string `json:"recovery_code" example:"a1b2c-d3e4f"`
+}
+
+func (t TwoFactorCodeRequest) Validate() error {
+	if t.Code == "" && t.RecoveryCode == "" {
+		return errors.New("code atau recovery_code harus diisi")
+	}
+	return nil
+}
+
+type TwoFactorRecoveryResponse struct {
+	RecoveryCodes []string `json:"recovery_codes" example:"a1b2c-d3e4f,0a9b8-c7d6e"`
+}
+
+type TwoFactorChallengeRequest struct {
+	ChallengeToken string `json:"challenge_token" example:"eyJhbGciOiJIUzI1N.ywibmFtZSI6IkR5cGUiOjB9.aFjz4esDQ4-_K3dMUmo"`
+}
+
+func (t TwoFactorChallengeRequest) Validate() error {
+	if err := validation.ValidateStruct(&t,
+		validation.Field(&t.ChallengeToken, validation.Required),
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" example:"eyJhbGciOiJIUzI1N.ywibmFtZSI6IkR5cGUiOjB9.aFjz4esDQ4-_K3dMUmo"`
+	Code           string `json:"code" example:"123456"`
+	RecoveryCode   string `json:"recovery_code" example:"a1b2c-d3e4f"`
+}
+
+func (t TwoFactorLoginRequest) Validate() error {
+	if err := validation.ValidateStruct(&t,
+		validation.Field(&t.ChallengeToken, validation.Required),
+	); err != nil {
+		return err
+	}
+	return TwoFactorCodeRequest{Code: t.Code, RecoveryCode: t.RecoveryCode}.Validate()
+}
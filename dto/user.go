@@ -6,20 +6,36 @@ import (
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/muchlist/mini_pos/configs/roles"
+	"github.com/muchlist/mini_pos/utils/mformat"
 	"github.com/muchlist/mini_pos/utils/sfunc"
 	"strings"
 )
 
 type UserModel struct {
-	ID         int             `json:"id" example:"1"`
-	Email      LowercaseString `json:"email" example:"example@example.com"`
-	Name       UppercaseString `json:"name" example:"muchlis"`
-	Password   string          `json:"-"`
-	Role       LowercaseString `json:"role" example:"owner,employee"`
-	CreatedAt  int64           `json:"created_at" example:"1631341964"`
-	UpdatedAt  int64           `json:"updated_at" example:"1631341964"`
-	MerchantID int             `json:"merchant_id" example:"1"`
-	DefOutlet  int             `json:"def_outlet" example:"1"`
+	ID            int             `json:"id" example:"1"`
+	Email         LowercaseString `json:"email" example:"example@example.com"`
+	Name          UppercaseString `json:"name" example:"muchlis"`
+	Password      string          `json:"-"`
+	Role          LowercaseString `json:"role" example:"owner,employee"`
+	CreatedAt     int64           `json:"created_at" example:"1631341964"`
+	CreatedAtText string          `json:"created_at_text" example:"11-09-2021 14:32 WITA"` // sesuai timezone merchant
+	UpdatedAt     int64           `json:"updated_at" example:"1631341964"`
+	UpdatedAtText string          `json:"updated_at_text" example:"11-09-2021 14:32 WITA"`
+	MerchantID    int             `json:"merchant_id" example:"1"`
+	DefOutlet     int             `json:"def_outlet" example:"1"`
+	// CustomRoleID role buatan merchant yang menggantikan permission role bawaan, 0 berarti memakai role bawaan
+	CustomRoleID int `json:"custom_role_id" example:"0"`
+	// FailedLogin jumlah login gagal berturut-turut, LockedUntil akun terkunci sampai waktu ini
+	FailedLogin     int    `json:"-"`
+	LockedUntil     int64  `json:"locked_until" example:"0"`
+	LockedUntilText string `json:"locked_until_text"`
+}
+
+// ApplyFormat mengisi waktu dibuat, diubah dan batas penguncian akun sesuai timezone merchant user
+func (u *UserModel) ApplyFormat(f mformat.Formatter) {
+	u.CreatedAtText = f.Time(u.CreatedAt)
+	u.UpdatedAtText = f.Time(u.UpdatedAt)
+	u.LockedUntilText = f.Time(u.LockedUntil)
 }
 
 type UserRegisterRequest struct {
@@ -28,6 +44,8 @@ type UserRegisterRequest struct {
 	Role      string `json:"role" example:"owner,employee"`
 	Password  string `json:"password" example:"password123"`
 	DefOutlet int    `json:"def_outlet" example:"1"`
+	// CustomRoleID opsional, permission user mengikuti custom role ini
+	CustomRoleID int `json:"custom_role_id" example:"0"`
 }
 
 func (u UserRegisterRequest) Validate() error {
@@ -35,7 +53,7 @@ func (u UserRegisterRequest) Validate() error {
 		validation.Field(&u.Email, validation.Required, is.Email),
 		validation.Field(&u.Name, validation.Required),
 		validation.Field(&u.Role, validation.Required),
-		validation.Field(&u.Password, validation.Required, validation.Length(3, 20)),
+		validation.Field(&u.Password, validation.Required, validation.Length(PasswordMinLength, PasswordMaxLength)),
 	); err != nil {
 		return err
 	}
@@ -54,6 +72,8 @@ type UserEditRequest struct {
 	Name      string `json:"name" example:"muchlis"`
 	DefOutlet int    `json:"def_outlet" example:"1"`
 	Role      string `json:"role" example:"employee"`
+	// CustomRoleID opsional, permission user mengikuti custom role ini
+	CustomRoleID int `json:"custom_role_id" example:"0"`
 }
 
 func (u UserEditRequest) Validate() error {
@@ -78,6 +98,7 @@ type UserEditModel struct {
 	Email           LowercaseString
 	Name            UppercaseString
 	Role            LowercaseString
+	CustomRoleID    int
 	UpdatedAt       int64
 	DefOutlet       int
 }
@@ -90,24 +111,61 @@ type UserLoginRequest struct {
 
 // UserLoginResponse balikan user ketika sukses login dengan tambahan AccessToken
 type UserLoginResponse struct {
-	ID           int    `json:"id" example:"1"`
-	Email        string `json:"email" example:"example@example.com"`
-	Name         string `json:"name" example:"muchlis"`
-	MerchantID   int    `json:"merchant_id" example:"1"`
-	DefOutlet    int    `json:"def_outlet" example:"1"`
-	Role         string `json:"role" example:"owner,employee"`
-	AccessToken  string `json:"access_token" example:"eyJhbGciOiJIUzI1N.ywibmFtZSI6IkR5cGUiOjB9.aFjz4esDQ4-_K3dMUmo"`
-	RefreshToken string `json:"refresh_token" example:"eyJhbGciOiJIUzI1N.ywibmFtZSI6IkR5cGUiOjB9.aFjz4esDQ4-_K3dMUmo"`
-	Expired      int64  `json:"expired" example:"1631341964"`
+	ID         int    `json:"id" example:"1"`
+	Email      string `json:"email" example:"example@example.com"`
+	Name       string `json:"name" example:"muchlis"`
+	MerchantID int    `json:"merchant_id" example:"1"`
+	DefOutlet  int    `json:"def_outlet" example:"1"`
+	Role       string `json:"role" example:"owner,employee"`
+	// Permissions permission yang melekat pada access token, "*" berarti seluruh permission
+	Permissions  []string `json:"permissions" example:"product.edit,price.set"`
+	AccessToken  string   `json:"access_token" example:"eyJhbGciOiJIUzI1N.ywibmFtZSI6IkR5cGUiOjB9.aFjz4esDQ4-_K3dMUmo"`
+	RefreshToken string   `json:"refresh_token" example:"eyJhbGciOiJIUzI1N.ywibmFtZSI6IkR5cGUiOjB9.aFjz4esDQ4-_K3dMUmo"`
+	Expired      int64    `json:"expired" example:"1631341964"`
+	ExpiredText  string   `json:"expired_text" example:"11-09-2021 14:32 WITA"` // sesuai timezone merchant
+
+	// TwoFactorRequired bernilai true apabila login masih memerlukan kode 2FA, access token dan
+	// refresh token kosong, kirim ChallengeToken beserta kode ke /login/2fa
+	TwoFactorRequired bool `json:"two_factor_required,omitempty" example:"false"`
+	// TwoFactorSetup bernilai true apabila merchant mewajibkan 2FA namun user belum mendaftar,
+	// lakukan setup melalui /login/2fa/setup terlebih dahulu
+	TwoFactorSetup bool     `json:"two_factor_setup,omitempty" example:"false"`
+	ChallengeToken string   `json:"challenge_token,omitempty" example:"eyJhbGciOiJIUzI1N.ywibmFtZSI6IkR5cGUiOjB9.aFjz4esDQ4-_K3dMUmo"`
+	RecoveryCodes  []string `json:"recovery_codes,omitempty" example:"a1b2c-d3e4f,0a9b8-c7d6e"`
+}
+
+// ApplyFormat mengisi waktu kadaluarsa access token sesuai timezone merchant user
+func (u *UserLoginResponse) ApplyFormat(f mformat.Formatter) {
+	u.ExpiredText = f.Time(u.Expired)
 }
 
 type UserRefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" example:"eyJhbGciOiJIUzI1N.ywibmFtZSI6IkR5cGUiOjB9.aFjz4esDQ4-_K3dMUmo"`
 }
 
-// UserRefreshTokenResponse mengembalikan token dengan claims yang
-// sama dengan token sebelumnya dengan expired yang baru
+// UserRefreshTokenResponse mengembalikan access token dengan data user terbaru beserta
+// refresh token pengganti, refresh token sebelumnya tidak dapat dipakai lagi
 type UserRefreshTokenResponse struct {
-	AccessToken string `json:"access_token" example:"eyJhbGciOiJIUzI1N.ywibmFtZSI6IkR5cGUiOjB9.aFjz4esDQ4-_K3dMUmo"`
-	Expired     int64  `json:"expired" example:"1631341964"`
+	AccessToken  string `json:"access_token" example:"eyJhbGciOiJIUzI1N.ywibmFtZSI6IkR5cGUiOjB9.aFjz4esDQ4-_K3dMUmo"`
+	RefreshToken string `json:"refresh_token" example:"eyJhbGciOiJIUzI1N.ywibmFtZSI6IkR5cGUiOjB9.aFjz4esDQ4-_K3dMUmo"`
+	Expired      int64  `json:"expired" example:"1631341964"`
+	ExpiredText  string `json:"expired_text" example:"11-09-2021 14:32 WITA"` // sesuai timezone merchant
+}
+
+// ApplyFormat mengisi waktu kadaluarsa access token baru sesuai timezone merchant user
+func (u *UserRefreshTokenResponse) ApplyFormat(f mformat.Formatter) {
+	u.ExpiredText = f.Time(u.Expired)
+}
+
+// RefreshTokenModel refresh token yang tersimpan, token asli tidak disimpan hanya hash-nya
+type RefreshTokenModel struct {
+	ID        int
+	TokenID   string // jti
+	FamilyID  string
+	UserID    int
+	TokenHash string
+	CreatedAt int64
+	ExpiredAt int64
+	UsedAt    int64 // terisi ketika token sudah dirotasi
+	RevokedAt int64 // terisi ketika logout, user dihapus, role berubah atau reuse terdeteksi
 }
@@ -0,0 +1,48 @@
+package dto
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/muchlist/mini_pos/utils/mformat"
+)
+
+// UserOutletModel daftar outlet yang boleh diakses user tanpa permission outlet.manage
+type UserOutletModel struct {
+	UserID     int
+	MerchantID int
+	OutletIDs  []int
+	CreatedAt  int64
+}
+
+type UserOutletRequest struct {
+	OutletIDs []int `json:"outlet_ids" example:"1,2"`
+}
+
+func (u UserOutletRequest) Validate() error {
+	return validation.ValidateStruct(&u,
+		validation.Field(&u.OutletIDs, validation.Required),
+	)
+}
+
+type SwitchOutletRequest struct {
+	OutletID int `json:"outlet_id" example:"2"`
+}
+
+func (s SwitchOutletRequest) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.OutletID, validation.Required),
+	)
+}
+
+// SwitchOutletResponse access token baru dengan outlet aktif yang diganti,
+// refresh token tetap dipakai dan refresh berikutnya memakai outlet sesi ini
+type SwitchOutletResponse struct {
+	OutletID    int    `json:"outlet_id" example:"2"`
+	AccessToken string `json:"access_token" example:"eyJhbGciOiJIUzI1N.ywibmFtZSI6IkR5cGUiOjB9.aFjz4esDQ4-_K3dMUmo"`
+	Expired     int64  `json:"expired" example:"1631341964"`
+	ExpiredText string `json:"expired_text" example:"11-09-2021 14:32 WITA"` // sesuai timezone merchant
+}
+
+// ApplyFormat mengisi waktu kadaluarsa access token dengan outlet baru sesuai timezone merchant
+func (s *SwitchOutletResponse) ApplyFormat(f mformat.Formatter) {
+	s.ExpiredText = f.Time(s.Expired)
+}
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/service/api_key_serv"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/wrap"
+)
+
+func NewAPIKeyHandler(apiKeyService api_key_serv.APIKeyServiceAssumer) *APIKeyHandler {
+	return &APIKeyHandler{
+		service: apiKeyService,
+	}
+}
+
+type APIKeyHandler struct {
+	service api_key_serv.APIKeyServiceAssumer
+}
+
+// Create membuat api key untuk integrasi pihak ketiga
+// @Summary create api key
+// @Description membuat api key merchant dengan scope dan batasan outlet opsional, key hanya ditampilkan sekali dan dikirim melalui header Authorization: ApiKey {key}. Scope tidak boleh melebihi permission pembuat, memerlukan fresh token
+// @ID api-key-create
+// @Accept json
+// @Produce json
+// @Tags ApiKey
+// @Security bearerAuth
+// @Param ReqBody body dto.APIKeyRequest true "Body raw JSON"
+// @Success 200 {object} wrap.Resp{data=dto.APIKeyCreateResponse}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /api-keys [post]
+func (a *APIKeyHandler) Create(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	var req dto.APIKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	res, apiErr := a.service.CreateAPIKey(c.UserContext(), *claims, req)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  res,
+		Error: nil,
+	})
+}
+
+// Find menampilkan api key merchant
+// @Summary find api key
+// @Description menampilkan seluruh api key milik merchant termasuk yang sudah dicabut, secret tidak pernah ditampilkan
+// @ID api-key-find
+// @Accept json
+// @Produce json
+// @Tags ApiKey
+// @Security bearerAuth
+// @Success 200 {object} wrap.Resp{data=[]dto.APIKeyModel}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /api-keys [get]
+func (a *APIKeyHandler) Find(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	apiKeys, apiErr := a.service.FindAPIKeys(c.UserContext(), *claims)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  apiKeys,
+		Error: nil,
+	})
+}
+
+// Revoke mencabut api key
+// @Summary revoke api key
+// @Description mencabut api key, request berikutnya yang memakai key tersebut langsung ditolak
+// @ID api-key-revoke
+// @Accept json
+// @Produce json
+// @Tags ApiKey
+// @Security bearerAuth
+// @Param id path int true "Api Key ID"
+// @Success 200 {object} wrap.Resp{data=string}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /api-keys/{id} [delete]
+func (a *APIKeyHandler) Revoke(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	apiKeyID, err := c.ParamsInt("id")
+	if err != nil {
+		apiErr := rest_err.NewBadRequestError("kesalahan input, id harus berupa angka")
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	apiErr := a.service.RevokeAPIKey(c.UserContext(), *claims, apiKeyID)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  fmt.Sprintf("api key %d berhasil dicabut", apiKeyID),
+		Error: nil,
+	})
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/muchlist/mini_pos/dao/audit_dao"
+	"github.com/muchlist/mini_pos/service/audit_serv"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/utils/sfunc"
+	"github.com/muchlist/mini_pos/wrap"
+	"strings"
+)
+
+func NewAuditHandler(auditService audit_serv.AuditServiceAssumer) *AuditHandler {
+	return &AuditHandler{
+		service: auditService,
+	}
+}
+
+type AuditHandler struct {
+	service audit_serv.AuditServiceAssumer
+}
+
+// Find menampilkan audit log merchant
+// @Summary find audit logs
+// @Description menampilkan catatan create/edit/delete entitas merchant beserta pelaku dan perubahan field, terbaru lebih dulu
+// @ID audit-log-find
+// @Accept json
+// @Produce json
+// @Tags Audit
+// @Security bearerAuth
+// @Param entity_type query string false "merchant, merchant_setting, merchant_plan, plan, outlet, user, user_outlet, role, product, product_price, tax, tax_setting, device, api_key"
+// @Param entity_id query int false "ID entitas"
+// @Param actor query int false "ID user pelaku"
+// @Param action query string false "create, edit, delete, revoke"
+// @Param from query int false "unix time awal"
+// @Param to query int false "unix time akhir"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset cursor untuk skip data sebanyak offsite"
+// @Success 200 {object} wrap.Resp{data=[]dto.AuditLogModel}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /audit-logs [get]
+func (a *AuditHandler) Find(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	params := audit_dao.FindParams{
+		EntityType: strings.ToLower(c.Query("entity_type")),
+		EntityID:   sfunc.StrToInt(c.Query("entity_id"), 0),
+		ActorID:    sfunc.StrToInt(c.Query("actor"), 0),
+		Action:     strings.ToLower(c.Query("action")),
+		From:       int64(sfunc.StrToInt(c.Query("from"), 0)),
+		To:         int64(sfunc.StrToInt(c.Query("to"), 0)),
+		Limit:      sfunc.StrToInt(c.Query("limit"), 10),
+		Offset:     sfunc.StrToInt(c.Query("offset"), 0),
+	}
+
+	auditLogs, apiErr := a.service.FindAuditLogs(c.UserContext(), *claims, params)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  auditLogs,
+		Error: nil,
+	})
+}
@@ -0,0 +1,289 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/service/device_serv"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/wrap"
+)
+
+const headerDeviceToken = "X-Device-Token"
+
+func NewDeviceHandler(deviceService device_serv.DeviceServiceAssumer) *DeviceHandler {
+	return &DeviceHandler{
+		service: deviceService,
+	}
+}
+
+type DeviceHandler struct {
+	service device_serv.DeviceServiceAssumer
+}
+
+// RegisterDevice memasangkan perangkat kasir ke outlet
+// @Summary register device
+// @Description owner memasangkan perangkat kasir ke outlet, device_token hanya ditampilkan sekali dan dikirim melalui header X-Device-Token
+// @ID device-register
+// @Accept json
+// @Produce json
+// @Tags Device
+// @Security bearerAuth
+// @Param ReqBody body dto.DeviceRequest true "Body raw JSON"
+// @Success 200 {object} wrap.Resp{data=dto.DeviceRegisterResponse}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /devices [post]
+func (d *DeviceHandler) RegisterDevice(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	var req dto.DeviceRequest
+	if err := c.BodyParser(&req); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	res, apiErr := d.service.RegisterDevice(c.UserContext(), *claims, req)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  res,
+		Error: nil,
+	})
+}
+
+// Find menampilkan perangkat kasir merchant
+// @Summary find device
+// @Description menampilkan perangkat kasir aktif milik merchant
+// @ID device-find
+// @Accept json
+// @Produce json
+// @Tags Device
+// @Security bearerAuth
+// @Success 200 {object} wrap.Resp{data=[]dto.DeviceModel}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /devices [get]
+func (d *DeviceHandler) Find(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	devices, apiErr := d.service.FindDevices(c.UserContext(), *claims)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  devices,
+		Error: nil,
+	})
+}
+
+// Revoke mencabut perangkat kasir
+// @Summary revoke device
+// @Description mencabut perangkat kasir, seluruh sesi login PIN dari perangkat tersebut ikut berakhir
+// @ID device-revoke
+// @Accept json
+// @Produce json
+// @Tags Device
+// @Security bearerAuth
+// @Param id path int true "Device ID"
+// @Success 200 {object} wrap.Resp{data=string}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /devices/{id} [delete]
+func (d *DeviceHandler) Revoke(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	deviceID, err := c.ParamsInt("id")
+	if err != nil {
+		apiErr := rest_err.NewBadRequestError("kesalahan input, id harus berupa angka")
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	apiErr := d.service.RevokeDevice(c.UserContext(), *claims, deviceID)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  fmt.Sprintf("device %d berhasil dicabut", deviceID),
+		Error: nil,
+	})
+}
+
+// SetPin mengatur PIN user yang login
+// @Summary set pin
+// @Description mengatur PIN 4 sampai 6 digit untuk login cepat pada perangkat kasir, memerlukan fresh token
+// @ID device-set-pin
+// @Accept json
+// @Produce json
+// @Tags Device
+// @Security bearerAuth
+// @Param ReqBody body dto.PinRequest true "Body raw JSON"
+// @Success 200 {object} wrap.Resp{data=string}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /profile/pin [put]
+func (d *DeviceHandler) SetPin(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	var req dto.PinRequest
+	if err := c.BodyParser(&req); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	apiErr := d.service.SetPin(c.UserContext(), *claims, req)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  "pin berhasil diatur",
+		Error: nil,
+	})
+}
+
+// FindUsers menampilkan user yang dapat login PIN pada perangkat
+// @Summary find device users
+// @Description menampilkan employee outlet perangkat yang sudah mengatur PIN, memerlukan header X-Device-Token
+// @ID device-users
+// @Accept json
+// @Produce json
+// @Tags Device
+// @Param X-Device-Token header string true "Device Token"
+// @Success 200 {object} wrap.Resp{data=[]dto.DeviceUserModel}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /device/users [get]
+func (d *DeviceHandler) FindUsers(c *fiber.Ctx) error {
+	users, apiErr := d.service.FindDeviceUsers(c.UserContext(), c.Get(headerDeviceToken))
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  users,
+		Error: nil,
+	})
+}
+
+// PinLogin login cepat menggunakan PIN pada perangkat kasir
+// @Summary pin login
+// @Description berganti user pada perangkat kasir menggunakan PIN, token tidak fresh, tanpa refresh token dan hanya berlaku untuk outlet perangkat
+// @ID device-pin-login
+// @Accept json
+// @Produce json
+// @Tags Access
+// @Param X-Device-Token header string true "Device Token"
+// @Param ReqBody body dto.PinLoginRequest true "Body raw JSON"
+// @Success 200 {object} wrap.Resp{data=dto.UserLoginResponse}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /pin-login [post]
+func (d *DeviceHandler) PinLogin(c *fiber.Ctx) error {
+	var req dto.PinLoginRequest
+	if err := c.BodyParser(&req); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	response, apiErr := d.service.PinLogin(c.UserContext(), c.Get(headerDeviceToken), req, dto.SessionMeta{
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+		IP:        c.IP(),
+	})
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  response,
+		Error: nil,
+	})
+}
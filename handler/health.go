@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/service/health_serv"
+	"github.com/muchlist/mini_pos/utils/buildinfo"
+	"net/http"
+)
+
+func NewHealthHandler(healthService health_serv.HealthServiceAssumer) *HealthHandler {
+	return &HealthHandler{
+		service: healthService,
+	}
+}
+
+type HealthHandler struct {
+	service health_serv.HealthServiceAssumer
+}
+
+// Healthz menandakan proses masih hidup tanpa memeriksa dependency
+// @Summary liveness probe
+// @Description selalu 200 selama proses dapat melayani request. Endpoint berada di luar /api/v1
+// @ID healthz
+// @Produce json
+// @Tags Health
+// @Success 200 {object} dto.ReadinessResponse
+// @Router /healthz [get]
+func (h *HealthHandler) Healthz(c *fiber.Ctx) error {
+	return c.JSON(dto.ReadinessResponse{Status: dto.HealthOk, Checks: []dto.HealthCheck{}})
+}
+
+// Readyz memeriksa apakah instance siap menerima request
+// @Summary readiness probe
+// @Description memeriksa koneksi database, versi migrasi dan direktori gambar, 503 apabila salah satu gagal atau aplikasi sedang shutdown. Endpoint berada di luar /api/v1
+// @ID readyz
+// @Produce json
+// @Tags Health
+// @Success 200 {object} dto.ReadinessResponse
+// @Failure 503 {object} dto.ReadinessResponse
+// @Router /readyz [get]
+func (h *HealthHandler) Readyz(c *fiber.Ctx) error {
+	res, ready := h.service.Readiness(c.UserContext())
+	if !ready {
+		return c.Status(http.StatusServiceUnavailable).JSON(res)
+	}
+	return c.JSON(res)
+}
+
+// Version menampilkan commit, waktu build dan versi go binary
+// @Summary build info
+// @Description commit dan waktu build diisi melalui ldflags saat build. Endpoint berada di luar /api/v1
+// @ID version
+// @Produce json
+// @Tags Health
+// @Success 200 {object} buildinfo.Info
+// @Router /version [get]
+func (h *HealthHandler) Version(c *fiber.Ctx) error {
+	return c.JSON(buildinfo.Get())
+}
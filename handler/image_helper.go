@@ -1,12 +1,16 @@
 package handler
 
 import (
+	"context"
 	"fmt"
 	"github.com/gofiber/fiber/v2"
 	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/mcrypt"
+	"github.com/muchlist/mini_pos/utils/metrics"
 	"github.com/muchlist/mini_pos/utils/mjwt"
 	"github.com/muchlist/mini_pos/utils/rest_err"
 	"net/http"
+	"os"
 	"path/filepath"
 	"strings"
 )
@@ -15,14 +19,24 @@ const (
 	jpgExtension  = ".jpg"
 	pngExtension  = ".png"
 	jpegExtension = ".jpeg"
+
+	// imagePathPrefix awalan path gambar di db, sisanya relatif terhadap ImageStorage.Dir
+	imagePathPrefix = "image/"
 )
 
-// saveImage return path to save in db
-func saveImage(c *fiber.Ctx, claims mjwt.CustomClaim, folder string, imageName string) (string, rest_err.APIError) {
+// ImageStorage direktori penyimpanan dan ukuran maksimal gambar yang diupload
+type ImageStorage struct {
+	Dir     string
+	MaxSize int64
+}
+
+// saveImage return path to save in db. imageName diberi akhiran acak agar upload bersamaan dengan nama
+// yang sama tidak saling menimpa, sehingga file upload yang gagal dapat dihapus tanpa menghapus file lain
+func saveImage(c *fiber.Ctx, claims mjwt.CustomClaim, storage ImageStorage, folder string, imageName string) (string, rest_err.APIError) {
 	file, err := c.FormFile("image")
 	if err != nil {
 		apiErr := rest_err.NewAPIError("File gagal di upload", http.StatusBadRequest, "bad_request", []interface{}{err.Error()})
-		logger.Info(fmt.Sprintf("u: %s | formfile | %s", claims.Name, err.Error()))
+		logger.InfoCtx(c.UserContext(), fmt.Sprintf("u: %s | formfile | %s", claims.Name, err.Error()))
 		return "", apiErr
 	}
 
@@ -30,28 +44,46 @@ func saveImage(c *fiber.Ctx, claims mjwt.CustomClaim, folder string, imageName s
 	fileExtension := strings.ToLower(filepath.Ext(fileName))
 	if !(fileExtension == jpgExtension || fileExtension == pngExtension || fileExtension == jpegExtension) {
 		apiErr := rest_err.NewBadRequestError("Ektensi file tidak di support")
-		logger.Info(fmt.Sprintf("u: %s | validate | %s", claims.Name, apiErr.Error()))
+		logger.InfoCtx(c.UserContext(), fmt.Sprintf("u: %s | validate | %s", claims.Name, apiErr.Error()))
 		return "", apiErr
 	}
 
-	if file.Size > 2*1024*1024 { // 1 MB
-		apiErr := rest_err.NewBadRequestError("Ukuran file tidak dapat melebihi 2MB")
-		logger.Info(fmt.Sprintf("u: %s | validate | %s", claims.Name, apiErr.Error()))
+	if file.Size > storage.MaxSize {
+		apiErr := rest_err.NewBadRequestError(fmt.Sprintf("Ukuran file tidak dapat melebihi %gMB", float64(storage.MaxSize)/1024/1024))
+		logger.InfoCtx(c.UserContext(), fmt.Sprintf("u: %s | validate | %s", claims.Name, apiErr.Error()))
 		return "", apiErr
 	}
 
 	// rename image
+	suffix, apiErr := mcrypt.NewID()
+	if apiErr != nil {
+		return "", apiErr
+	}
+	imageName = fmt.Sprintf("%s_%s", imageName, suffix)
 	// path := filepath.Join("static", "image", folder, imageName + fileExtension)
 	// pathInDB := filepath.Join("image", folder, imageName + fileExtension)
-	path := fmt.Sprintf("static/image/%s/%s", folder, imageName+fileExtension)
-	pathInDB := fmt.Sprintf("image/%s/%s", folder, imageName+fileExtension)
+	path := filepath.Join(storage.Dir, folder, imageName+fileExtension)
+	pathInDB := fmt.Sprintf("%s%s/%s", imagePathPrefix, folder, imageName+fileExtension)
 
 	err = c.SaveFile(file, path)
 	if err != nil {
-		logger.Error(fmt.Sprintf("%s gagal mengupload file", claims.Name), err)
+		logger.ErrorCtx(c.UserContext(), fmt.Sprintf("%s gagal mengupload file", claims.Name), err)
 		apiErr := rest_err.NewInternalServerError("File gagal di upload", err)
 		return "", apiErr
 	}
+	metrics.ImageUploaded(claims.Merchant, folder, file.Size)
 
 	return pathInDB, nil
 }
+
+// deleteImage menghapus file gambar dari path yang tersimpan di db, dipanggil setelah path baru
+// tersimpan atau ketika file baru gagal dipakai. Kegagalan hanya dicatat karena data sudah konsisten
+func deleteImage(ctx context.Context, storage ImageStorage, pathInDB string) {
+	relative := strings.TrimPrefix(pathInDB, imagePathPrefix)
+	if relative == pathInDB || strings.Contains(relative, "..") {
+		return
+	}
+	if err := os.Remove(filepath.Join(storage.Dir, filepath.FromSlash(relative))); err != nil && !os.IsNotExist(err) {
+		logger.ErrorCtx(ctx, fmt.Sprintf("error saat menghapus gambar %s (deleteImage:0)", pathInDB), err)
+	}
+}
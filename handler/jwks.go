@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+)
+
+// JWKS menampilkan kunci publik verifikasi access token
+// @Summary jwks
+// @Description kunci publik (RFC 7517) untuk memverifikasi access token RS256/EdDSA berdasarkan header kid, kunci lama tetap ditampilkan selama rotasi. Endpoint berada di luar /api/v1
+// @ID jwks
+// @Produce json
+// @Tags Access
+// @Success 200 {object} mjwt.JWKS
+// @Router /.well-known/jwks.json [get]
+func JWKS(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.JSON(mjwt.GetJWKS())
+}
@@ -50,7 +50,7 @@ func (m *MerchantHandler) CreateMerchant(c *fiber.Ctx) error {
 		})
 	}
 
-	response, apiErr := m.service.CreateMerchant(c.Context(), req)
+	response, apiErr := m.service.CreateMerchant(c.UserContext(), req)
 	if apiErr != nil {
 		return c.Status(apiErr.Status()).JSON(fiber.Map{"error": apiErr, "data": nil})
 	}
@@ -92,7 +92,7 @@ func (m *MerchantHandler) EditMerchant(c *fiber.Ctx) error {
 		})
 	}
 
-	response, apiErr := m.service.Edit(c.Context(), req)
+	response, apiErr := m.service.Edit(c.UserContext(), req)
 	if apiErr != nil {
 		return c.Status(apiErr.Status()).JSON(wrap.Resp{
 			Data:  nil,
@@ -129,7 +129,7 @@ func (m *MerchantHandler) DeleteMerchant(c *fiber.Ctx) error {
 		})
 	}
 
-	apiErr := m.service.Delete(c.Context(), id)
+	apiErr := m.service.Delete(c.UserContext(), id)
 	if apiErr != nil {
 		return c.Status(apiErr.Status()).JSON(wrap.Resp{
 			Data:  nil,
@@ -167,7 +167,7 @@ func (m *MerchantHandler) GetMerchant(c *fiber.Ctx) error {
 		})
 	}
 
-	response, apiErr := m.service.Get(c.Context(), id)
+	response, apiErr := m.service.Get(c.UserContext(), id)
 	if apiErr != nil {
 		return c.Status(apiErr.Status()).JSON(wrap.Resp{
 			Data:  nil,
@@ -201,7 +201,7 @@ func (m *MerchantHandler) FindMerchant(c *fiber.Ctx) error {
 	limit := sfunc.StrToInt(c.Query("limit"), 100)
 	cursor := sfunc.StrToInt(c.Query("cursor"), 0)
 
-	response, apiErr := m.service.FindMerchant(c.Context(), search, limit, cursor)
+	response, apiErr := m.service.FindMerchant(c.UserContext(), search, limit, cursor)
 	if apiErr != nil {
 		return c.Status(apiErr.Status()).JSON(wrap.Resp{
 			Data:  nil,
@@ -34,6 +34,7 @@ type OutletHandler struct {
 // @Param ReqBody body dto.OutletCreateRequest true "Body raw JSON"
 // @Success 200 {object} wrap.Resp{data=wrap.RespMsgExample}
 // @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 402 {object} wrap.Resp{error=wrap.ErrorExample402}
 // @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
 // @Router /outlets [post]
 func (u *OutletHandler) CreateOutlet(c *fiber.Ctx) error {
@@ -63,7 +64,7 @@ func (u *OutletHandler) CreateOutlet(c *fiber.Ctx) error {
 		})
 	}
 
-	createdID, apiErr := u.service.CreateOutlet(c.Context(), *claims, dto.OutletModel{
+	createdID, apiErr := u.service.CreateOutlet(c.UserContext(), *claims, dto.OutletModel{
 		MerchantID: claims.Merchant,
 		OutletName: dto.UppercaseString(outlet.OutletName),
 		Address:    outlet.Address,
@@ -138,7 +139,7 @@ func (u *OutletHandler) Edit(c *fiber.Ctx) error {
 
 	req.ID = outletID
 
-	outletEdited, apiErr := u.service.EditOutlet(c.Context(), *claims, req)
+	outletEdited, apiErr := u.service.EditOutlet(c.UserContext(), *claims, req)
 	if apiErr != nil {
 		return c.Status(apiErr.Status()).JSON(wrap.Resp{
 			Data:  nil,
@@ -193,7 +194,7 @@ func (u *OutletHandler) Delete(c *fiber.Ctx) error {
 		})
 	}
 
-	apiErr := u.service.DeleteOutlet(c.Context(), *claims, outletID)
+	apiErr := u.service.DeleteOutlet(c.UserContext(), *claims, outletID)
 	if apiErr != nil {
 		return c.Status(apiErr.Status()).JSON(wrap.Resp{
 			Data:  nil,
@@ -240,7 +241,7 @@ func (u *OutletHandler) Get(c *fiber.Ctx) error {
 		})
 	}
 
-	outlet, apiErr := u.service.GetOutletByID(c.Context(), *claims, outletID)
+	outlet, apiErr := u.service.GetOutletByID(c.UserContext(), *claims, outletID)
 	if apiErr != nil {
 		return c.Status(apiErr.Status()).JSON(wrap.Resp{
 			Data:  nil,
@@ -282,7 +283,7 @@ func (u *OutletHandler) Find(c *fiber.Ctx) error {
 	offset := sfunc.StrToInt(c.Query("offset"), 0)
 	search := c.Query("search")
 
-	outletList, apiErr := u.service.FindOutlets(c.Context(), *claims, search, limit, offset)
+	outletList, apiErr := u.service.FindOutlets(c.UserContext(), *claims, search, limit, offset)
 	if apiErr != nil {
 		return c.Status(apiErr.Status()).JSON(wrap.Resp{
 			Data:  nil,
@@ -330,7 +331,7 @@ func (u *OutletHandler) GetCurrentOutlet(c *fiber.Ctx) error {
 		})
 	}
 
-	outlet, apiErr := u.service.GetOutletByID(c.Context(), *claims, claims.Identity)
+	outlet, apiErr := u.service.GetOutletByID(c.UserContext(), *claims, outletID)
 	if apiErr != nil {
 		return c.Status(apiErr.Status()).JSON(wrap.Resp{
 			Data:  nil,
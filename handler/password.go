@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/service/password_serv"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/wrap"
+)
+
+func NewPasswordHandler(passwordService password_serv.PasswordServiceAssumer) *PasswordHandler {
+	return &PasswordHandler{
+		service: passwordService,
+	}
+}
+
+type PasswordHandler struct {
+	service password_serv.PasswordServiceAssumer
+}
+
+// ChangePassword mengganti password user yang login
+// @Summary change password
+// @Description mengganti password user yang login, memerlukan password lama dan fresh token
+// @ID password-change
+// @Accept json
+// @Produce json
+// @Tags Password
+// @Security bearerAuth
+// @Param ReqBody body dto.ChangePasswordRequest true "Body raw JSON"
+// @Success 200 {object} wrap.Resp{data=string}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /change-password [put]
+func (p *PasswordHandler) ChangePassword(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	var req dto.ChangePasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	res, apiErr := p.service.ChangePassword(c.UserContext(), *claims, req)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  res,
+		Error: nil,
+	})
+}
+
+// ForgotPassword meminta link reset password
+// @Summary forgot password
+// @Description mengirim link reset password ke email apabila email terdaftar
+// @ID password-forgot
+// @Accept json
+// @Produce json
+// @Tags Password
+// @Param ReqBody body dto.ForgotPasswordRequest true "Body raw JSON"
+// @Success 200 {object} wrap.Resp{data=string}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /forgot-password [post]
+func (p *PasswordHandler) ForgotPassword(c *fiber.Ctx) error {
+	var req dto.ForgotPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	res, apiErr := p.service.ForgotPassword(c.UserContext(), req)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  res,
+		Error: nil,
+	})
+}
+
+// ResetPassword membuat password baru dengan token reset
+// @Summary reset password
+// @Description mengganti password memakai token dari link reset password, token hanya berlaku sekali
+// @ID password-reset
+// @Accept json
+// @Produce json
+// @Tags Password
+// @Param ReqBody body dto.ResetPasswordRequest true "Body raw JSON"
+// @Success 200 {object} wrap.Resp{data=string}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /reset-password [post]
+func (p *PasswordHandler) ResetPassword(c *fiber.Ctx) error {
+	var req dto.ResetPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	res, apiErr := p.service.ResetPassword(c.UserContext(), req)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  res,
+		Error: nil,
+	})
+}
+
+// OwnerResetPassword owner mengirim link reset password untuk employee
+// @Summary reset employee password
+// @Description owner mengirim link reset password ke email employee pada merchant yang sama
+// @ID password-owner-reset
+// @Accept json
+// @Produce json
+// @Tags Password
+// @Security bearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} wrap.Resp{data=string}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /users/{id}/reset-password [post]
+func (p *PasswordHandler) OwnerResetPassword(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	userID, err := c.ParamsInt("id")
+	if err != nil {
+		apiErr := rest_err.NewBadRequestError("kesalahan input, id harus berupa angka")
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	res, apiErr := p.service.OwnerResetPassword(c.UserContext(), *claims, userID)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  res,
+		Error: nil,
+	})
+}
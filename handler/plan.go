@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/service/plan_serv"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/wrap"
+)
+
+func NewPlanHandler(planService plan_serv.PlanServiceAssumer) *PlanHandler {
+	return &PlanHandler{
+		service: planService,
+	}
+}
+
+type PlanHandler struct {
+	service plan_serv.PlanServiceAssumer
+}
+
+// GetUsage menampilkan pemakaian kuota merchant
+// @Summary get merchant usage
+// @Description menampilkan jumlah outlet, user, product dan ukuran gambar yang terpakai beserta batas paket merchant
+// @ID plan-usage
+// @Accept json
+// @Produce json
+// @Tags Plan
+// @Security bearerAuth
+// @Success 200 {object} wrap.Resp{data=dto.MerchantUsage}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /usage [get]
+func (p *PlanHandler) GetUsage(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	usage, apiErr := p.service.GetUsage(c.UserContext(), *claims)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  usage,
+		Error: nil,
+	})
+}
+
+// Find menampilkan daftar paket
+// @Summary find plan
+// @Description menampilkan daftar paket langganan, nilai maksimal 0 berarti tidak terbatas
+// @ID plan-find
+// @Accept json
+// @Produce json
+// @Tags Plan
+// @Security bearerAuth
+// @Success 200 {object} wrap.Resp{data=[]dto.PlanModel}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /plans [get]
+func (p *PlanHandler) Find(c *fiber.Ctx) error {
+	plans, apiErr := p.service.FindPlans(c.UserContext())
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  plans,
+		Error: nil,
+	})
+}
+
+// CreatePlan menambahkan paket
+// @Summary create plan
+// @Description menambahkan paket langganan, nilai maksimal 0 berarti tidak terbatas. khusus super user
+// @ID plan-create
+// @Accept json
+// @Produce json
+// @Tags Plan
+// @Security bearerAuth
+// @Param ReqBody body dto.PlanRequest true "Body raw JSON"
+// @Success 200 {object} wrap.Resp{data=wrap.RespMsgExample}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /plans [post]
+func (p *PlanHandler) CreatePlan(c *fiber.Ctx) error {
+	var req dto.PlanRequest
+	if err := c.BodyParser(&req); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	createdID, apiErr := p.service.CreatePlan(c.UserContext(), req)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  fmt.Sprintf("Paket dengan ID %d berhasil dibuat", createdID),
+		Error: nil,
+	})
+}
+
+// Edit
+// @Summary edit plan
+// @Description merubah batas paket langganan. khusus super user
+// @ID plan-edit
+// @Accept json
+// @Produce json
+// @Tags Plan
+// @Security bearerAuth
+// @Param id path int true "Plan ID"
+// @Param ReqBody body dto.PlanRequest true "Body raw JSON"
+// @Success 200 {object} wrap.Resp{data=dto.PlanModel}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /plans/{id} [put]
+func (p *PlanHandler) Edit(c *fiber.Ctx) error {
+	planID, err := c.ParamsInt("id")
+	if err != nil {
+		apiErr := rest_err.NewBadRequestError("kesalahan input, id harus berupa angka")
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	var req dto.PlanRequest
+	if err := c.BodyParser(&req); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	req.ID = planID
+
+	plan, apiErr := p.service.EditPlan(c.UserContext(), req)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  plan,
+		Error: nil,
+	})
+}
+
+// SetMerchantPlan
+// @Summary set merchant plan
+// @Description memindahkan merchant ke paket lain. khusus super user
+// @ID plan-set-merchant
+// @Accept json
+// @Produce json
+// @Tags Plan
+// @Security bearerAuth
+// @Param id path int true "Merchant ID"
+// @Param ReqBody body dto.MerchantPlanRequest true "Body raw JSON"
+// @Success 200 {object} wrap.Resp{data=dto.MerchantUsage}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /merchant/{id}/plan [put]
+func (p *PlanHandler) SetMerchantPlan(c *fiber.Ctx) error {
+	merchantID, err := c.ParamsInt("id")
+	if err != nil {
+		apiErr := rest_err.NewBadRequestError("kesalahan input, id harus berupa angka")
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	var req dto.MerchantPlanRequest
+	if err := c.BodyParser(&req); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	req.MerchantID = merchantID
+
+	usage, apiErr := p.service.SetMerchantPlan(c.UserContext(), req)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  usage,
+		Error: nil,
+	})
+}
@@ -10,17 +10,20 @@ import (
 	"github.com/muchlist/mini_pos/utils/rest_err"
 	"github.com/muchlist/mini_pos/utils/sfunc"
 	"github.com/muchlist/mini_pos/wrap"
+	"net/http"
 	"time"
 )
 
-func NewProductHandler(productService product_serv.ProductServiceAssumer) *ProductHandler {
+func NewProductHandler(productService product_serv.ProductServiceAssumer, images ImageStorage) *ProductHandler {
 	return &ProductHandler{
 		service: productService,
+		images:  images,
 	}
 }
 
 type ProductHandler struct {
 	service product_serv.ProductServiceAssumer
+	images  ImageStorage
 }
 
 // CreateProduct menambahkan outlets
@@ -34,6 +37,7 @@ type ProductHandler struct {
 // @Param ReqBody body dto.ProductCreateRequest true "Body raw JSON"
 // @Success 200 {object} wrap.Resp{data=wrap.RespMsgExample}
 // @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 402 {object} wrap.Resp{error=wrap.ErrorExample402}
 // @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
 // @Router /products [post]
 func (u *ProductHandler) CreateProduct(c *fiber.Ctx) error {
@@ -63,12 +67,13 @@ func (u *ProductHandler) CreateProduct(c *fiber.Ctx) error {
 		})
 	}
 
-	createdID, apiErr := u.service.CreateProduct(c.Context(), *claims, dto.ProductModel{
+	createdID, apiErr := u.service.CreateProduct(c.UserContext(), *claims, dto.ProductModel{
 		MerchantID:      claims.Merchant,
 		Code:            dto.UppercaseString(product.Code),
 		Name:            dto.UppercaseString(product.Name),
 		MasterBuyPrice:  product.MasterBuyPrice,
 		MasterSellPrice: product.MasterSellPrice,
+		TaxID:           product.TaxID,
 		Image:           "",
 		CreatedAt:       time.Now().Unix(),
 		UpdatedAt:       time.Now().Unix(),
@@ -141,7 +146,7 @@ func (u *ProductHandler) Edit(c *fiber.Ctx) error {
 
 	req.ID = productID
 
-	productEdited, apiErr := u.service.EditProduct(c.Context(), *claims, req)
+	productEdited, apiErr := u.service.EditProduct(c.UserContext(), *claims, req)
 	if apiErr != nil {
 		return c.Status(apiErr.Status()).JSON(wrap.Resp{
 			Data:  nil,
@@ -158,7 +163,7 @@ func (u *ProductHandler) Edit(c *fiber.Ctx) error {
 
 // Delete menghapus product
 // @Summary delete product by ID
-// @Description menghapus product berdasarkan ID
+// @Description menghapus product berdasarkan ID beserta file gambarnya
 // @ID product-delete
 // @Accept json
 // @Produce json
@@ -196,13 +201,17 @@ func (u *ProductHandler) Delete(c *fiber.Ctx) error {
 		})
 	}
 
-	apiErr := u.service.DeleteProduct(c.Context(), *claims, productID)
+	imagePath, apiErr := u.service.DeleteProduct(c.UserContext(), *claims, productID)
 	if apiErr != nil {
 		return c.Status(apiErr.Status()).JSON(wrap.Resp{
 			Data:  nil,
 			Error: apiErr,
 		})
 	}
+	// gambar dihapus setelah product berhasil dihapus
+	if imagePath != "" {
+		deleteImage(c.UserContext(), u.images, imagePath)
+	}
 
 	return c.JSON(
 		wrap.Resp{
@@ -251,7 +260,7 @@ func (u *ProductHandler) SetCustomPrice(c *fiber.Ctx) error {
 		})
 	}
 
-	result, apiErr := u.service.SetCustomPrice(c.Context(), *claims, req)
+	result, apiErr := u.service.SetCustomPrice(c.UserContext(), *claims, req)
 	if apiErr != nil {
 		return c.Status(apiErr.Status()).JSON(wrap.Resp{
 			Data:  nil,
@@ -300,7 +309,7 @@ func (u *ProductHandler) Get(c *fiber.Ctx) error {
 		})
 	}
 
-	product, apiErr := u.service.Get(c.Context(), *claims, productID, outletID)
+	product, apiErr := u.service.Get(c.UserContext(), *claims, productID, outletID)
 	if apiErr != nil {
 		return c.Status(apiErr.Status()).JSON(wrap.Resp{
 			Data:  nil,
@@ -345,7 +354,7 @@ func (u *ProductHandler) Find(c *fiber.Ctx) error {
 	search := c.Query("search")
 	outlet := sfunc.StrToInt(c.Query("outlet"), 0)
 
-	productList, apiErr := u.service.FindProducts(c.Context(), *claims, product_serv.FindProductsParams{
+	productList, apiErr := u.service.FindProducts(c.UserContext(), *claims, product_serv.FindProductsParams{
 		Search:         search,
 		Limit:          limit,
 		Offset:         offset,
@@ -378,6 +387,7 @@ func (u *ProductHandler) Find(c *fiber.Ctx) error {
 // @Param image formData file true "file gambar"
 // @Success 200 {object} wrap.Resp{data=dto.ProductModel}
 // @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 402 {object} wrap.Resp{error=wrap.ErrorExample402}
 // @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
 // @Router /products-image/{id} [post]
 func (u *ProductHandler) UploadImage(c *fiber.Ctx) error {
@@ -399,7 +409,24 @@ func (u *ProductHandler) UploadImage(c *fiber.Ctx) error {
 	}
 
 	// cek apakah ID cctv && branch ada
-	_, apiErr := u.service.Get(c.Context(), *claims, id, 0)
+	_, apiErr := u.service.Get(c.UserContext(), *claims, id, 0)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	// cek kuota penyimpanan gambar merchant
+	file, err := c.FormFile("image")
+	if err != nil {
+		apiErr := rest_err.NewAPIError("File gagal di upload", http.StatusBadRequest, "bad_request", []interface{}{err.Error()})
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+	apiErr = u.service.CheckImageQuota(c.UserContext(), *claims, id, file.Size)
 	if apiErr != nil {
 		return c.Status(apiErr.Status()).JSON(wrap.Resp{
 			Data:  nil,
@@ -409,7 +436,7 @@ func (u *ProductHandler) UploadImage(c *fiber.Ctx) error {
 
 	randomName := fmt.Sprintf("%d%v", id, time.Now().Unix())
 	// simpan image
-	pathInDb, apiErr := saveImage(c, *claims, "products", randomName)
+	pathInDb, apiErr := saveImage(c, *claims, u.images, "products", randomName)
 	if apiErr != nil {
 		return c.Status(apiErr.Status()).JSON(wrap.Resp{
 			Data:  nil,
@@ -418,13 +445,18 @@ func (u *ProductHandler) UploadImage(c *fiber.Ctx) error {
 	}
 
 	// update path image di database
-	result, apiErr := u.service.SetImagePath(c.Context(), id, pathInDb)
+	result, oldPath, apiErr := u.service.SetImagePath(c.UserContext(), *claims, id, pathInDb, file.Size)
 	if apiErr != nil {
+		// file yang baru disimpan tidak dipakai
+		deleteImage(c.UserContext(), u.images, pathInDb)
 		return c.Status(apiErr.Status()).JSON(wrap.Resp{
 			Data:  nil,
 			Error: apiErr,
 		})
 	}
+	if oldPath != "" && oldPath != pathInDb {
+		deleteImage(c.UserContext(), u.images, oldPath)
+	}
 
 	return c.JSON(wrap.Resp{
 		Data:  result,
@@ -0,0 +1,243 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/service/role_serv"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/wrap"
+)
+
+func NewRoleHandler(roleService role_serv.RoleServiceAssumer) *RoleHandler {
+	return &RoleHandler{
+		service: roleService,
+	}
+}
+
+type RoleHandler struct {
+	service role_serv.RoleServiceAssumer
+}
+
+// FindPermissions menampilkan permission yang tersedia
+// @Summary find permissions
+// @Description menampilkan daftar permission yang dapat dipakai pada custom role
+// @ID permission-find
+// @Accept json
+// @Produce json
+// @Tags Role
+// @Security bearerAuth
+// @Success 200 {object} wrap.Resp{data=[]string}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /permissions [get]
+func (r *RoleHandler) FindPermissions(c *fiber.Ctx) error {
+	return c.JSON(wrap.Resp{
+		Data:  r.service.FindPermissions(),
+		Error: nil,
+	})
+}
+
+// CreateRole menambahkan custom role
+// @Summary create custom role
+// @Description menambahkan custom role berisi kumpulan permission untuk merchant user, permission harus dimiliki pembuat
+// @ID role-create
+// @Accept json
+// @Produce json
+// @Tags Role
+// @Security bearerAuth
+// @Param ReqBody body dto.RoleRequest true "Body raw JSON"
+// @Success 200 {object} wrap.Resp{data=wrap.RespMsgExample}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /roles [post]
+func (r *RoleHandler) CreateRole(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	var req dto.RoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	createdID, apiErr := r.service.CreateRole(c.UserContext(), *claims, req)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(
+		wrap.Resp{
+			Data:  fmt.Sprintf("Role dengan ID %d berhasil dibuat", createdID),
+			Error: nil,
+		})
+}
+
+// Edit
+// @Summary edit custom role
+// @Description mengubah nama dan permission custom role, permission harus dimiliki pengubah. Berlaku pada user setelah access token diperbarui
+// @ID role-edit
+// @Accept json
+// @Produce json
+// @Tags Role
+// @Security bearerAuth
+// @Param id path int true "Role ID"
+// @Param ReqBody body dto.RoleRequest true "Body raw JSON"
+// @Success 200 {object} wrap.Resp{data=dto.RoleModel}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /roles/{id} [put]
+func (r *RoleHandler) Edit(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	roleID, err := c.ParamsInt("id")
+	if err != nil {
+		apiErr := rest_err.NewBadRequestError("kesalahan input, id harus berupa angka")
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	var req dto.RoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	roleEdited, apiErr := r.service.EditRole(c.UserContext(), *claims, roleID, req)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(
+		wrap.Resp{
+			Data:  roleEdited,
+			Error: nil,
+		})
+}
+
+// Delete menghapus custom role
+// @Summary delete custom role by ID
+// @Description menghapus custom role, user yang memakai role ini kembali memakai role bawaan
+// @ID role-delete
+// @Accept json
+// @Produce json
+// @Tags Role
+// @Security bearerAuth
+// @Param id path int true "Role ID"
+// @Success 200 {object} wrap.RespMsgExample
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /roles/{id} [delete]
+func (r *RoleHandler) Delete(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	roleID, err := c.ParamsInt("id")
+	if err != nil {
+		apiErr := rest_err.NewBadRequestError("kesalahan input, id harus berupa angka")
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	apiErr := r.service.DeleteRole(c.UserContext(), *claims, roleID)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(
+		wrap.Resp{
+			Data:  fmt.Sprintf("role %d berhasil dihapus", roleID),
+			Error: nil,
+		})
+}
+
+// Find menampilkan list role
+// @Summary find roles
+// @Description menampilkan role bawaan (merchant_id 0) dan custom role milik merchant
+// @ID role-find
+// @Accept json
+// @Produce json
+// @Tags Role
+// @Security bearerAuth
+// @Success 200 {object} wrap.Resp{data=[]dto.RoleModel}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /roles [get]
+func (r *RoleHandler) Find(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	roleList, apiErr := r.service.FindRoles(c.UserContext(), *claims)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  roleList,
+		Error: nil,
+	})
+}
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/service/setting_serv"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/wrap"
+	"time"
+)
+
+func NewSettingHandler(settingService setting_serv.SettingServiceAssumer, images ImageStorage) *SettingHandler {
+	return &SettingHandler{
+		service: settingService,
+		images:  images,
+	}
+}
+
+type SettingHandler struct {
+	service setting_serv.SettingServiceAssumer
+	images  ImageStorage
+}
+
+// Get menampilkan pengaturan merchant
+// @Summary get merchant setting
+// @Description menampilkan pengaturan mata uang, timezone, pembulatan tunai dan struk merchant user yang login
+// @ID setting-get
+// @Accept json
+// @Produce json
+// @Tags Setting
+// @Security bearerAuth
+// @Success 200 {object} wrap.Resp{data=dto.MerchantSetting}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /merchant-settings [get]
+func (s *SettingHandler) Get(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	setting, apiErr := s.service.GetSetting(c.UserContext(), *claims)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  setting,
+		Error: nil,
+	})
+}
+
+// Edit
+// @Summary edit merchant setting
+// @Description merubah pengaturan mata uang (kode dan minor unit), timezone, pembulatan tunai (0, 100, 500) dan header footer struk, minor unit ditolak apabila merchant sudah memiliki product
+// @ID setting-edit
+// @Accept json
+// @Produce json
+// @Tags Setting
+// @Security bearerAuth
+// @Param ReqBody body dto.MerchantSettingRequest true "Body raw JSON"
+// @Success 200 {object} wrap.Resp{data=dto.MerchantSetting}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /merchant-settings [put]
+func (s *SettingHandler) Edit(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	var req dto.MerchantSettingRequest
+	if err := c.BodyParser(&req); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	setting, apiErr := s.service.EditSetting(c.UserContext(), *claims, req)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  setting,
+		Error: nil,
+	})
+}
+
+// UploadLogo
+// @Summary menambahkan logo merchant
+// @Description menambahkan logo merchant yang dipakai pada struk
+// @ID setting-upload-logo
+// @Accept json
+// @Produce json
+// @Tags Setting
+// @Security bearerAuth
+// @Param image formData file true "file gambar"
+// @Success 200 {object} wrap.Resp{data=dto.MerchantSetting}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /merchant-settings/logo [post]
+func (s *SettingHandler) UploadLogo(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	randomName := fmt.Sprintf("%d%v", claims.Merchant, time.Now().Unix())
+	// simpan image
+	pathInDb, apiErr := saveImage(c, *claims, s.images, "merchants", randomName)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	// update path logo di database
+	result, apiErr := s.service.SetLogoPath(c.UserContext(), *claims, pathInDb)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  result,
+		Error: nil,
+	})
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/service/signup_serv"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/wrap"
+)
+
+func NewSignupHandler(signupService signup_serv.SignupServiceAssumer) *SignupHandler {
+	return &SignupHandler{
+		service: signupService,
+	}
+}
+
+type SignupHandler struct {
+	service signup_serv.SignupServiceAssumer
+}
+
+// Signup pendaftaran merchant mandiri
+// @Summary signup merchant
+// @Description mendaftarkan merchant baru, link verifikasi akan dikirim ke email owner. Merchant aktif setelah verifikasi. Response sama untuk email yang sudah terdaftar, namun email tidak dikirim
+// @ID signup
+// @Accept json
+// @Produce json
+// @Tags Signup
+// @Param ReqBody body dto.SignupRequest true "Body raw JSON"
+// @Success 200 {object} wrap.Resp{data=string}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /signup [post]
+func (s *SignupHandler) Signup(c *fiber.Ctx) error {
+	var req dto.SignupRequest
+	if err := c.BodyParser(&req); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	res, apiErr := s.service.Signup(c.UserContext(), req)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  res,
+		Error: nil,
+	})
+}
+
+// Verify verifikasi email pendaftaran merchant
+// @Summary verify signup merchant
+// @Description memverifikasi token dari email dan membuat password owner, merchant beserta outlet pertama yang ditugaskan kepada owner akan dibuat
+// @ID signup-verify
+// @Accept json
+// @Produce json
+// @Tags Signup
+// @Param ReqBody body dto.SignupVerifyRequest true "Body raw JSON"
+// @Success 200 {object} wrap.Resp{data=dto.MerchantCreateRes}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /signup/verify [post]
+func (s *SignupHandler) Verify(c *fiber.Ctx) error {
+	var req dto.SignupVerifyRequest
+	if err := c.BodyParser(&req); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	res, apiErr := s.service.Verify(c.UserContext(), req)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  res,
+		Error: nil,
+	})
+}
@@ -0,0 +1,370 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/service/tax_serv"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/wrap"
+)
+
+func NewTaxHandler(taxService tax_serv.TaxServiceAssumer) *TaxHandler {
+	return &TaxHandler{
+		service: taxService,
+	}
+}
+
+type TaxHandler struct {
+	service tax_serv.TaxServiceAssumer
+}
+
+// CreateTax menambahkan tarif pajak
+// @Summary create tax for merchant user
+// @Description Menambahkan tarif pajak sesuai dengan ID merchant yang melekat di user, rate dalam basis poin (1100 = 11%)
+// @ID tax-create
+// @Accept json
+// @Produce json
+// @Tags Tax
+// @Security bearerAuth
+// @Param ReqBody body dto.TaxCreateRequest true "Body raw JSON"
+// @Success 200 {object} wrap.Resp{data=wrap.RespMsgExample}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /taxes [post]
+func (t *TaxHandler) CreateTax(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	var req dto.TaxCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	createdID, apiErr := t.service.CreateTax(c.UserContext(), *claims, dto.TaxModel{
+		MerchantID: claims.Merchant,
+		Name:       dto.UppercaseString(req.Name),
+		Rate:       req.Rate,
+	})
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(
+		wrap.Resp{
+			Data:  fmt.Sprintf("Tax dengan ID %d berhasil dibuat", createdID),
+			Error: nil,
+		})
+}
+
+// Edit
+// @Summary edit tax
+// @Description melakukan perubahan data pada tarif pajak
+// @ID tax-edit
+// @Accept json
+// @Produce json
+// @Tags Tax
+// @Security bearerAuth
+// @Param id path int true "Tax ID"
+// @Param ReqBody body dto.TaxEditRequest true "Body raw JSON"
+// @Success 200 {object} wrap.Resp{data=dto.TaxModel}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /taxes/{id} [put]
+func (t *TaxHandler) Edit(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	taxID, err := c.ParamsInt("id")
+	if err != nil {
+		apiErr := rest_err.NewBadRequestError("kesalahan input, id harus berupa angka")
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	var req dto.TaxEditRequest
+	if err := c.BodyParser(&req); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	req.ID = taxID
+
+	taxEdited, apiErr := t.service.EditTax(c.UserContext(), *claims, req)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(
+		wrap.Resp{
+			Data:  taxEdited,
+			Error: nil,
+		})
+}
+
+// Delete menghapus tax
+// @Summary delete tax by ID
+// @Description menghapus tarif pajak berdasarkan ID, product yang memakai tarif ini menjadi bebas pajak
+// @ID tax-delete
+// @Accept json
+// @Produce json
+// @Tags Tax
+// @Security bearerAuth
+// @Param id path int true "Tax ID"
+// @Success 200 {object} wrap.RespMsgExample
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /taxes/{id} [delete]
+func (t *TaxHandler) Delete(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	taxID, err := c.ParamsInt("id")
+	if err != nil {
+		apiErr := rest_err.NewBadRequestError("kesalahan input, id harus berupa angka")
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	apiErr := t.service.DeleteTax(c.UserContext(), *claims, taxID)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(
+		wrap.Resp{
+			Data:  fmt.Sprintf("tax %d berhasil dihapus", taxID),
+			Error: nil,
+		})
+}
+
+// Get menampilkan tax berdasarkan id
+// @Summary get tax by ID
+// @Description menampilkan tarif pajak berdasarkan ID
+// @ID tax-get
+// @Accept json
+// @Produce json
+// @Tags Tax
+// @Security bearerAuth
+// @Param id path int true "Tax ID"
+// @Success 200 {object} wrap.Resp{data=dto.TaxModel}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /taxes/{id} [get]
+func (t *TaxHandler) Get(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	taxID, err := c.ParamsInt("id")
+	if err != nil {
+		apiErr := rest_err.NewBadRequestError("kesalahan input, id harus berupa angka")
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	tax, apiErr := t.service.GetTaxByID(c.UserContext(), *claims, taxID)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  tax,
+		Error: nil,
+	})
+}
+
+// Find menampilkan list tax
+// @Summary find tax
+// @Description menampilkan seluruh tarif pajak milik merchant
+// @ID tax-find
+// @Accept json
+// @Produce json
+// @Tags Tax
+// @Security bearerAuth
+// @Success 200 {object} wrap.Resp{data=[]dto.TaxModel}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /taxes [get]
+func (t *TaxHandler) Find(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	taxList, apiErr := t.service.FindTaxes(c.UserContext(), *claims)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	if taxList == nil {
+		taxList = []dto.TaxModel{}
+	}
+	return c.JSON(wrap.Resp{
+		Data:  taxList,
+		Error: nil,
+	})
+}
+
+// GetSetting menampilkan pengaturan pajak merchant
+// @Summary get tax setting
+// @Description menampilkan pengaturan harga termasuk pajak (inclusive) atau belum (exclusive) serta aturan pembulatan
+// @ID tax-setting-get
+// @Accept json
+// @Produce json
+// @Tags Tax
+// @Security bearerAuth
+// @Success 200 {object} wrap.Resp{data=dto.TaxSetting}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /tax-setting [get]
+func (t *TaxHandler) GetSetting(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	setting, apiErr := t.service.GetSetting(c.UserContext(), *claims)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  setting,
+		Error: nil,
+	})
+}
+
+// EditSetting
+// @Summary edit tax setting
+// @Description merubah pengaturan inclusive/exclusive dan pembulatan (half_up, up, down) pajak merchant
+// @ID tax-setting-edit
+// @Accept json
+// @Produce json
+// @Tags Tax
+// @Security bearerAuth
+// @Param ReqBody body dto.TaxSettingRequest true "Body raw JSON"
+// @Success 200 {object} wrap.Resp{data=dto.TaxSetting}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /tax-setting [put]
+func (t *TaxHandler) EditSetting(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	var req dto.TaxSettingRequest
+	if err := c.BodyParser(&req); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	setting, apiErr := t.service.EditSetting(c.UserContext(), *claims, req)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  setting,
+		Error: nil,
+	})
+}
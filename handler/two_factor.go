@@ -0,0 +1,245 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/wrap"
+)
+
+// EnrollTwoFactor mendaftarkan 2FA
+// @Summary enroll 2fa
+// @Description membuat secret TOTP dan provisioning uri (tampilkan sebagai QR code), 2FA aktif setelah dikonfirmasi melalui /profile/2fa/confirm
+// @ID two-factor-enroll
+// @Accept json
+// @Produce json
+// @Tags TwoFactor
+// @Security bearerAuth
+// @Success 200 {object} wrap.Resp{data=dto.TwoFactorEnrollResponse}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /profile/2fa [post]
+func (u *UserHandler) EnrollTwoFactor(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	res, apiErr := u.service.EnrollTwoFactor(c.UserContext(), *claims)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  res,
+		Error: nil,
+	})
+}
+
+// ConfirmTwoFactor mengaktifkan 2FA
+// @Summary confirm 2fa
+// @Description mengaktifkan 2FA dengan kode dari aplikasi authenticator, response berisi recovery code yang hanya ditampilkan sekali
+// @ID two-factor-confirm
+// @Accept json
+// @Produce json
+// @Tags TwoFactor
+// @Security bearerAuth
+// @Param ReqBody body dto.TwoFactorCodeRequest true "Body raw JSON"
+// @Success 200 {object} wrap.Resp{data=dto.TwoFactorRecoveryResponse}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /profile/2fa/confirm [post]
+func (u *UserHandler) ConfirmTwoFactor(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	var req dto.TwoFactorCodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	res, apiErr := u.service.ConfirmTwoFactor(c.UserContext(), *claims, req)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  res,
+		Error: nil,
+	})
+}
+
+// DisableTwoFactor menonaktifkan 2FA
+// @Summary disable 2fa
+// @Description menonaktifkan 2FA dengan kode atau recovery code, ditolak apabila merchant mewajibkan 2FA untuk owner
+// @ID two-factor-disable
+// @Accept json
+// @Produce json
+// @Tags TwoFactor
+// @Security bearerAuth
+// @Param ReqBody body dto.TwoFactorCodeRequest true "Body raw JSON"
+// @Success 200 {object} wrap.Resp{data=string}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /profile/2fa/disable [post]
+func (u *UserHandler) DisableTwoFactor(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	var req dto.TwoFactorCodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	res, apiErr := u.service.DisableTwoFactor(c.UserContext(), *claims, req)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  res,
+		Error: nil,
+	})
+}
+
+// SetupTwoFactorLogin setup 2FA saat login
+// @Summary setup 2fa on login
+// @Description bagi owner yang diwajibkan 2FA namun belum mendaftar (two_factor_setup true), membuat secret TOTP menggunakan challenge token dari /login
+// @ID two-factor-login-setup
+// @Accept json
+// @Produce json
+// @Tags Access
+// @Param ReqBody body dto.TwoFactorChallengeRequest true "Body raw JSON"
+// @Success 200 {object} wrap.Resp{data=dto.TwoFactorEnrollResponse}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /login/2fa/setup [post]
+func (u *UserHandler) SetupTwoFactorLogin(c *fiber.Ctx) error {
+	var req dto.TwoFactorChallengeRequest
+	if err := c.BodyParser(&req); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	res, apiErr := u.service.SetupTwoFactorLogin(c.UserContext(), req)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  res,
+		Error: nil,
+	})
+}
+
+// LoginTwoFactor langkah kedua login
+// @Summary login 2fa
+// @Description menukar challenge token dari /login dan kode 2FA (atau recovery code) dengan access token dan refresh token
+// @ID two-factor-login
+// @Accept json
+// @Produce json
+// @Tags Access
+// @Param ReqBody body dto.TwoFactorLoginRequest true "Body raw JSON"
+// @Success 200 {object} wrap.Resp{data=dto.UserLoginResponse}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /login/2fa [post]
+func (u *UserHandler) LoginTwoFactor(c *fiber.Ctx) error {
+	var req dto.TwoFactorLoginRequest
+	if err := c.BodyParser(&req); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	res, apiErr := u.service.LoginTwoFactor(c.UserContext(), req, dto.SessionMeta{
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+		IP:        c.IP(),
+	})
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  res,
+		Error: nil,
+	})
+}
@@ -24,7 +24,7 @@ type UserHandler struct {
 
 // Login login
 // @Summary login
-// @Description login menggunakan userID dan password untuk mendapatkan JWT Token
+// @Description login menggunakan userID dan password untuk mendapatkan JWT Token, login gagal berulang akan dikenai jeda dan penguncian akun sementara
 // @ID user-login
 // @Accept json
 // @Produce json
@@ -32,6 +32,7 @@ type UserHandler struct {
 // @Param ReqBody body dto.UserLoginRequest true "Body raw JSON"
 // @Success 200 {object} wrap.Resp{data=dto.UserLoginResponse}
 // @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 429 {object} wrap.Resp{error=wrap.ErrorExample429}
 // @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
 // @Router /login [post]
 func (u *UserHandler) Login(c *fiber.Ctx) error {
@@ -52,7 +53,10 @@ func (u *UserHandler) Login(c *fiber.Ctx) error {
 		})
 	}
 
-	response, apiErr := u.service.Login(c.Context(), login)
+	response, apiErr := u.service.Login(c.UserContext(), login, dto.SessionMeta{
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+		IP:        c.IP(),
+	})
 	if apiErr != nil {
 		return c.Status(apiErr.Status()).JSON(wrap.Resp{
 			Data:  nil,
@@ -78,6 +82,7 @@ func (u *UserHandler) Login(c *fiber.Ctx) error {
 // @Param ReqBody body dto.UserRegisterRequest true "Body raw JSON"
 // @Success 200 {object} wrap.RespMsgExample
 // @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 402 {object} wrap.Resp{error=wrap.ErrorExample402}
 // @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
 // @Router /users [post]
 func (u *UserHandler) Register(c *fiber.Ctx) error {
@@ -107,11 +112,13 @@ func (u *UserHandler) Register(c *fiber.Ctx) error {
 		})
 	}
 
-	responseMsg, apiErr := u.service.InsertUser(c.Context(), *claims, dto.UserModel{
-		Email:    dto.LowercaseString(user.Email),
-		Name:     dto.UppercaseString(user.Name),
-		Password: user.Password,
-		Role:     dto.LowercaseString(user.Role),
+	responseMsg, apiErr := u.service.InsertUser(c.UserContext(), *claims, dto.UserModel{
+		Email:        dto.LowercaseString(user.Email),
+		Name:         dto.UppercaseString(user.Name),
+		Password:     user.Password,
+		Role:         dto.LowercaseString(user.Role),
+		DefOutlet:    user.DefOutlet,
+		CustomRoleID: user.CustomRoleID,
 	})
 	if apiErr != nil {
 		return c.Status(apiErr.Status()).JSON(wrap.Resp{
@@ -180,7 +187,7 @@ func (u *UserHandler) Edit(c *fiber.Ctx) error {
 
 	req.ID = userID
 
-	userEdited, apiErr := u.service.EditUser(c.Context(), *claims, req)
+	userEdited, apiErr := u.service.EditUser(c.UserContext(), *claims, req)
 	if apiErr != nil {
 		return c.Status(apiErr.Status()).JSON(wrap.Resp{
 			Data:  nil,
@@ -217,7 +224,7 @@ func (u *UserHandler) RefreshToken(c *fiber.Ctx) error {
 		})
 	}
 
-	response, apiErr := u.service.Refresh(c.Context(), req)
+	response, apiErr := u.service.Refresh(c.UserContext(), req)
 	if apiErr != nil {
 		return c.Status(apiErr.Status()).JSON(wrap.Resp{
 			Data:  nil,
@@ -268,7 +275,7 @@ func (u *UserHandler) Delete(c *fiber.Ctx) error {
 		})
 	}
 
-	apiErr := u.service.DeleteUser(c.Context(), *claims, userID)
+	apiErr := u.service.DeleteUser(c.UserContext(), *claims, userID)
 	if apiErr != nil {
 		return c.Status(apiErr.Status()).JSON(wrap.Resp{
 			Data:  nil,
@@ -306,7 +313,7 @@ func (u *UserHandler) Get(c *fiber.Ctx) error {
 		})
 	}
 
-	user, apiErr := u.service.GetUserByID(c.Context(), userID)
+	user, apiErr := u.service.GetUserByID(c.UserContext(), userID)
 	if apiErr != nil {
 		return c.Status(apiErr.Status()).JSON(wrap.Resp{
 			Data:  nil,
@@ -340,7 +347,7 @@ func (u *UserHandler) Find(c *fiber.Ctx) error {
 	offset := sfunc.StrToInt(c.Query("offset"), 0)
 	search := c.Query("search")
 
-	userList, apiErr := u.service.FindUsers(c.Context(), search, limit, offset)
+	userList, apiErr := u.service.FindUsers(c.UserContext(), search, limit, offset)
 	if apiErr != nil {
 		return c.Status(apiErr.Status()).JSON(wrap.Resp{
 			Data:  nil,
@@ -377,7 +384,7 @@ func (u *UserHandler) GetProfile(c *fiber.Ctx) error {
 		})
 	}
 
-	user, apiErr := u.service.GetUserByID(c.Context(), claims.Identity)
+	user, apiErr := u.service.GetUserByID(c.UserContext(), claims.Identity)
 	if apiErr != nil {
 		return c.Status(apiErr.Status()).JSON(wrap.Resp{
 			Data:  nil,
@@ -390,3 +397,392 @@ func (u *UserHandler) GetProfile(c *fiber.Ctx) error {
 		Error: nil,
 	})
 }
+
+// Logout
+// @Summary logout
+// @Description mencabut refresh token pada perangkat yang sedang dipakai
+// @ID user-logout
+// @Accept json
+// @Produce json
+// @Tags Access
+// @Security bearerAuth
+// @Success 200 {object} wrap.Resp{data=string}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /logout [post]
+func (u *UserHandler) Logout(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	res, apiErr := u.service.Logout(c.UserContext(), *claims)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  res,
+		Error: nil,
+	})
+}
+
+// LogoutAll
+// @Summary logout all devices
+// @Description mencabut seluruh refresh token user pada semua perangkat
+// @ID user-logout-all
+// @Accept json
+// @Produce json
+// @Tags Access
+// @Security bearerAuth
+// @Success 200 {object} wrap.Resp{data=string}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /logout-all [post]
+func (u *UserHandler) LogoutAll(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	res, apiErr := u.service.LogoutAll(c.UserContext(), *claims)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  res,
+		Error: nil,
+	})
+}
+
+// GetProfileSessions
+// @Summary get my sessions
+// @Description menampilkan sesi login aktif milik user yang login beserta user agent, ip dan outlet
+// @ID user-profile-sessions
+// @Accept json
+// @Produce json
+// @Tags Access
+// @Security bearerAuth
+// @Success 200 {object} wrap.Resp{data=[]dto.SessionModel}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /profile/sessions [get]
+func (u *UserHandler) GetProfileSessions(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	sessions, apiErr := u.service.FindSessions(c.UserContext(), *claims, claims.Identity)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  sessions,
+		Error: nil,
+	})
+}
+
+// DeleteProfileSession
+// @Summary terminate my session
+// @Description mengakhiri salah satu sesi login milik user yang login
+// @ID user-profile-session-delete
+// @Accept json
+// @Produce json
+// @Tags Access
+// @Security bearerAuth
+// @Param sid path string true "Session ID"
+// @Success 200 {object} wrap.Resp{data=string}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /profile/sessions/{sid} [delete]
+func (u *UserHandler) DeleteProfileSession(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	sessionID := c.Params("sid")
+	apiErr := u.service.RevokeSession(c.UserContext(), *claims, claims.Identity, sessionID)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  fmt.Sprintf("sesi %s berhasil diakhiri", sessionID),
+		Error: nil,
+	})
+}
+
+// GetUserSessions
+// @Summary get user sessions
+// @Description owner menampilkan sesi login aktif user pada merchant yang sama
+// @ID user-sessions
+// @Accept json
+// @Produce json
+// @Tags User
+// @Security bearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} wrap.Resp{data=[]dto.SessionModel}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /users/{id}/sessions [get]
+func (u *UserHandler) GetUserSessions(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	userID, err := c.ParamsInt("id")
+	if err != nil {
+		apiErr := rest_err.NewBadRequestError("kesalahan input, id harus berupa angka")
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	sessions, apiErr := u.service.FindSessions(c.UserContext(), *claims, userID)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  sessions,
+		Error: nil,
+	})
+}
+
+// DeleteUserSession
+// @Summary terminate user session
+// @Description owner mengakhiri sesi login user pada merchant yang sama
+// @ID user-session-delete
+// @Accept json
+// @Produce json
+// @Tags User
+// @Security bearerAuth
+// @Param id path int true "User ID"
+// @Param sid path string true "Session ID"
+// @Success 200 {object} wrap.Resp{data=string}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /users/{id}/sessions/{sid} [delete]
+func (u *UserHandler) DeleteUserSession(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	userID, err := c.ParamsInt("id")
+	if err != nil {
+		apiErr := rest_err.NewBadRequestError("kesalahan input, id harus berupa angka")
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	sessionID := c.Params("sid")
+	apiErr := u.service.RevokeSession(c.UserContext(), *claims, userID, sessionID)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  fmt.Sprintf("sesi %s berhasil diakhiri", sessionID),
+		Error: nil,
+	})
+}
+
+// Unlock membuka kunci akun user
+// @Summary unlock user
+// @Description owner membuka kunci akun user pada merchant yang sama yang terkunci karena login gagal berulang
+// @ID user-unlock
+// @Accept json
+// @Produce json
+// @Tags User
+// @Security bearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} wrap.Resp{data=string}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /users/{id}/unlock [post]
+func (u *UserHandler) Unlock(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	userID, err := c.ParamsInt("id")
+	if err != nil {
+		apiErr := rest_err.NewBadRequestError("kesalahan input, id harus berupa angka")
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	apiErr := u.service.UnlockUser(c.UserContext(), *claims, userID)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  fmt.Sprintf("user %d berhasil dibuka", userID),
+		Error: nil,
+	})
+}
+
+// Impersonate masuk sebagai owner merchant
+// @Summary impersonate merchant owner
+// @Description super user mendapatkan token akses berumur 15 menit atas nama owner merchant, setiap impersonate dicatat beserta alasannya
+// @ID user-impersonate
+// @Accept json
+// @Produce json
+// @Tags Merchant
+// @Security bearerAuth
+// @Param id path int true "Merchant ID"
+// @Param ReqBody body dto.ImpersonateRequest true "Body raw JSON"
+// @Success 200 {object} wrap.Resp{data=dto.ImpersonateResponse}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /merchant/{id}/impersonate [post]
+func (u *UserHandler) Impersonate(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	merchantID, err := c.ParamsInt("id")
+	if err != nil {
+		apiErr := rest_err.NewBadRequestError("kesalahan input, id harus berupa angka")
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	var req dto.ImpersonateRequest
+	if err := c.BodyParser(&req); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	req.MerchantID = merchantID
+
+	response, apiErr := u.service.Impersonate(c.UserContext(), *claims, req)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  response,
+		Error: nil,
+	})
+}
+
+// FindImpersonations menampilkan catatan impersonate
+// @Summary find impersonation log
+// @Description menampilkan catatan impersonate yang dilakukan super user
+// @ID user-impersonation-find
+// @Accept json
+// @Produce json
+// @Tags Merchant
+// @Security bearerAuth
+// @Param merchant query int false "filter merchant ID"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset cursor untuk skip data sebanyak offsite"
+// @Success 200 {object} wrap.Resp{data=[]dto.ImpersonationModel}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /impersonations [get]
+func (u *UserHandler) FindImpersonations(c *fiber.Ctx) error {
+	merchantID := sfunc.StrToInt(c.Query("merchant"), 0)
+	limit := sfunc.StrToInt(c.Query("limit"), 10)
+	offset := sfunc.StrToInt(c.Query("offset"), 0)
+
+	impersonationList, apiErr := u.service.FindImpersonations(c.UserContext(), merchantID, limit, offset)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	if impersonationList == nil {
+		impersonationList = []dto.ImpersonationModel{}
+	}
+	return c.JSON(wrap.Resp{
+		Data:  impersonationList,
+		Error: nil,
+	})
+}
@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/wrap"
+)
+
+// GetUserOutlets menampilkan outlet yang ditugaskan kepada user
+// @Summary get user outlets
+// @Description menampilkan outlet yang ditugaskan kepada user pada merchant yang sama
+// @ID user-outlet-get
+// @Accept json
+// @Produce json
+// @Tags User
+// @Security bearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} wrap.Resp{data=[]dto.OutletModel}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /users/{id}/outlets [get]
+func (u *UserHandler) GetUserOutlets(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	userID, err := c.ParamsInt("id")
+	if err != nil {
+		apiErr := rest_err.NewBadRequestError("kesalahan input, id harus berupa angka")
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	outletList, apiErr := u.service.FindUserOutlets(c.UserContext(), *claims, userID)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  outletList,
+		Error: nil,
+	})
+}
+
+// SetUserOutlets mengganti outlet yang ditugaskan kepada user
+// @Summary set user outlets
+// @Description mengganti seluruh outlet yang ditugaskan kepada user, user tanpa permission outlet.manage hanya dapat mengakses outlet tersebut. Outlet default user harus termasuk
+// @ID user-outlet-set
+// @Accept json
+// @Produce json
+// @Tags User
+// @Security bearerAuth
+// @Param id path int true "User ID"
+// @Param ReqBody body dto.UserOutletRequest true "Body raw JSON"
+// @Success 200 {object} wrap.Resp{data=[]dto.OutletModel}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /users/{id}/outlets [put]
+func (u *UserHandler) SetUserOutlets(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	userID, err := c.ParamsInt("id")
+	if err != nil {
+		apiErr := rest_err.NewBadRequestError("kesalahan input, id harus berupa angka")
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	var req dto.UserOutletRequest
+	if err := c.BodyParser(&req); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	outletList, apiErr := u.service.SetUserOutlets(c.UserContext(), *claims, userID, req)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  outletList,
+		Error: nil,
+	})
+}
+
+// SwitchOutlet mengganti outlet aktif user yang login
+// @Summary switch active outlet
+// @Description mengganti outlet aktif dan mengembalikan access token baru dengan outlet tersebut, refresh token tetap dipakai. Outlet harus ditugaskan kepada user kecuali memiliki permission outlet.manage
+// @ID profile-outlet-switch
+// @Accept json
+// @Produce json
+// @Tags User
+// @Security bearerAuth
+// @Param ReqBody body dto.SwitchOutletRequest true "Body raw JSON"
+// @Success 200 {object} wrap.Resp{data=dto.SwitchOutletResponse}
+// @Failure 400 {object} wrap.Resp{error=wrap.ErrorExample400}
+// @Failure 500 {object} wrap.Resp{error=wrap.ErrorExample500}
+// @Router /profile/outlet [post]
+func (u *UserHandler) SwitchOutlet(c *fiber.Ctx) error {
+	claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim)
+	if !ok {
+		apiErr := rest_err.NewInternalServerError("internal error", errors.New("claims assert failed"))
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	var req dto.SwitchOutletRequest
+	if err := c.BodyParser(&req); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		apiErr := rest_err.NewBadRequestError(err.Error())
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	res, apiErr := u.service.SwitchOutlet(c.UserContext(), *claims, req)
+	if apiErr != nil {
+		return c.Status(apiErr.Status()).JSON(wrap.Resp{
+			Data:  nil,
+			Error: apiErr,
+		})
+	}
+
+	return c.JSON(wrap.Resp{
+		Data:  res,
+		Error: nil,
+	})
+}
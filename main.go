@@ -2,8 +2,9 @@ package main
 
 import (
 	"github.com/muchlist/mini_pos/app"
+	"os"
 )
 
 func main() {
-	app.RunApp()
+	app.RunCLI(os.Args[1:])
 }
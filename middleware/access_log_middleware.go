@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"go.uber.org/zap"
+	"time"
+)
+
+// AccessLog menulis satu baris log JSON melalui zap untuk setiap request, termasuk request id,
+// user, merchant dan trace id. Dipasang setelah middleware tracing agar trace id ikut tercatat
+func AccessLog() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			// error yang belum ditulis ke response akan diubah oleh error handler fiber
+			if fiberErr, ok := err.(*fiber.Error); ok {
+				status = fiberErr.Code
+			} else {
+				status = fiber.StatusInternalServerError
+			}
+		}
+
+		fields := []zap.Field{
+			zap.String("method", c.Method()),
+			zap.String("path", c.Path()),
+			zap.String("route", c.Route().Path),
+			zap.Int("status", status),
+			zap.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			zap.String("ip", c.IP()),
+			zap.Int("bytes", len(c.Response().Body())),
+			zap.String("user_agent", c.Get(fiber.HeaderUserAgent)),
+		}
+		// claims dipasang middleware jwt setelah middleware ini, namun tetap terbaca karena
+		// context request diturunkan dari locals fiber
+		if status >= fiber.StatusInternalServerError {
+			logger.ErrorCtx(c.UserContext(), "access", err, fields...)
+		} else {
+			logger.InfoCtx(c.UserContext(), "access", fields...)
+		}
+		return err
+	}
+}
@@ -0,0 +1,15 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/muchlist/mini_pos/utils/audit"
+)
+
+// AuditContext menyimpan ip client pada locals agar dapat dicatat audit log di lapisan dao,
+// request id dipasang oleh middleware RequestID
+func AuditContext() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals(audit.KeyIP, c.IP())
+		return c.Next()
+	}
+}
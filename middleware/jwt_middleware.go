@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"fmt"
 	"github.com/gofiber/fiber/v2"
 	"github.com/muchlist/mini_pos/utils/mjwt"
@@ -10,20 +11,43 @@ import (
 )
 
 var (
-	jwt = mjwt.NewJwt()
+	jwt                 = mjwt.NewJwt()
+	sessionChecker      SessionChecker
+	apiKeyAuthenticator APIKeyAuthenticator
 )
 
+// SessionChecker memeriksa apakah sesi login masih aktif
+type SessionChecker interface {
+	IsActive(ctx context.Context, sessionID string) (bool, rest_err.APIError)
+}
+
+// SetSessionChecker mengaktifkan pengecekan sesi, access token dari sesi yang
+// sudah diakhiri akan ditolak meskipun belum kadaluarsa
+func SetSessionChecker(checker SessionChecker) {
+	sessionChecker = checker
+}
+
+// APIKeyAuthenticator memetakan api key menjadi identitas setara access token
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (*mjwt.CustomClaim, rest_err.APIError)
+}
+
+// SetAPIKeyAuthenticator mengaktifkan header Authorization: ApiKey <key> sebagai alternatif Bearer
+func SetAPIKeyAuthenticator(authenticator APIKeyAuthenticator) {
+	apiKeyAuthenticator = authenticator
+}
+
 const (
 	headerKey = "Authorization"
 	bearerKey = "Bearer"
+	apiKeyKey = "ApiKey"
 )
 
 // NormalAuth memerlukan salah satu role inputan agar diloloskan ke proses berikutnya
 // token tidak perlu fresh
 func NormalAuth(rolesReq ...string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		authHeader := c.Get(headerKey)
-		claims, err := authHaveRoleValidator(authHeader, false, rolesReq)
+		claims, err := authHaveRoleValidator(c, false, rolesReq)
 		if err != nil {
 			return c.Status(err.Status()).JSON(fiber.Map{"error": err, "data": nil})
 		}
@@ -36,8 +60,7 @@ func NormalAuth(rolesReq ...string) fiber.Handler {
 // token harus fresh (tidak hasil dari refresh token)
 func FreshAuth(rolesReq ...string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		authHeader := c.Get(headerKey)
-		claims, err := authHaveRoleValidator(authHeader, true, rolesReq)
+		claims, err := authHaveRoleValidator(c, true, rolesReq)
 		if err != nil {
 			return c.Status(err.Status()).JSON(fiber.Map{"error": err, "data": nil})
 		}
@@ -47,24 +70,51 @@ func FreshAuth(rolesReq ...string) fiber.Handler {
 	}
 }
 
-func authHaveRoleValidator(authHeader string, mustFresh bool, rolesAllowed []string) (*mjwt.CustomClaim, rest_err.APIError) {
-	if !strings.Contains(authHeader, bearerKey) {
-		apiErr := rest_err.NewUnauthorizedError("Unauthorized")
-		return nil, apiErr
+// PermissionAuth memerlukan seluruh permission inputan agar diloloskan ke proses berikutnya
+// token tidak perlu fresh
+func PermissionAuth(permissionsReq ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, err := authHavePermissionValidator(c, false, permissionsReq)
+		if err != nil {
+			return c.Status(err.Status()).JSON(fiber.Map{"error": err, "data": nil})
+		}
+		c.Locals(mjwt.CLAIMS, claims)
+		return c.Next()
 	}
-	tokenString := strings.Split(authHeader, " ")
-	if len(tokenString) != 2 {
-		apiErr := rest_err.NewUnauthorizedError("Unauthorized")
-		return nil, apiErr
+}
+
+// FreshPermissionAuth memerlukan seluruh permission inputan agar diloloskan ke proses berikutnya
+// token harus fresh (tidak hasil dari refresh token)
+func FreshPermissionAuth(permissionsReq ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, err := authHavePermissionValidator(c, true, permissionsReq)
+		if err != nil {
+			return c.Status(err.Status()).JSON(fiber.Map{"error": err, "data": nil})
+		}
+		c.Locals(mjwt.CLAIMS, claims)
+		return c.Next()
 	}
-	token, apiErr := jwt.ValidateToken(tokenString[1])
+}
+
+func authHavePermissionValidator(c *fiber.Ctx, mustFresh bool, permissionsRequired []string) (*mjwt.CustomClaim, rest_err.APIError) {
+	claims, apiErr := authHaveRoleValidator(c, mustFresh, nil)
 	if apiErr != nil {
 		return nil, apiErr
 	}
-	claims, apiErr := jwt.ReadToken(token)
+	for _, permission := range permissionsRequired {
+		if !claims.Can(permission) {
+			return nil, rest_err.NewUnauthorizedError(fmt.Sprintf("Unauthorized, memerlukan permission %s", permission))
+		}
+	}
+	return claims, nil
+}
+
+func authHaveRoleValidator(c *fiber.Ctx, mustFresh bool, rolesAllowed []string) (*mjwt.CustomClaim, rest_err.APIError) {
+	claims, apiErr := authenticate(c)
 	if apiErr != nil {
 		return nil, apiErr
 	}
+
 	if mustFresh {
 		if !claims.Fresh {
 			apiErr := rest_err.NewUnauthorizedError("Memerlukan token yang baru untuk mengakses halaman ini")
@@ -83,3 +133,56 @@ func authHaveRoleValidator(authHeader string, mustFresh bool, rolesAllowed []str
 	apiErr = rest_err.NewUnauthorizedError(fmt.Sprintf("Unauthorized, memerlukan hak akses %s", rolesAllowed))
 	return nil, apiErr
 }
+
+// authenticate memakai claims yang sudah divalidasi middleware sebelumnya (RateLimit) agar
+// token dan sesi tidak diperiksa dua kali dalam satu request
+func authenticate(c *fiber.Ctx) (*mjwt.CustomClaim, rest_err.APIError) {
+	if claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim); ok && claims != nil {
+		return claims, nil
+	}
+	return readClaims(c.UserContext(), c.Get(headerKey))
+}
+
+// readClaims memvalidasi header Authorization berisi api key maupun bearer token
+func readClaims(ctx context.Context, authHeader string) (*mjwt.CustomClaim, rest_err.APIError) {
+	if apiKeyAuthenticator != nil && strings.HasPrefix(authHeader, apiKeyKey+" ") {
+		return apiKeyAuthenticator.Authenticate(ctx, strings.TrimPrefix(authHeader, apiKeyKey+" "))
+	}
+	return readBearerClaims(ctx, authHeader)
+}
+
+// readBearerClaims membaca dan memvalidasi access token dari header Authorization: Bearer <token>
+func readBearerClaims(ctx context.Context, authHeader string) (*mjwt.CustomClaim, rest_err.APIError) {
+	if !strings.Contains(authHeader, bearerKey) {
+		apiErr := rest_err.NewUnauthorizedError("Unauthorized")
+		return nil, apiErr
+	}
+	tokenString := strings.Split(authHeader, " ")
+	if len(tokenString) != 2 {
+		apiErr := rest_err.NewUnauthorizedError("Unauthorized")
+		return nil, apiErr
+	}
+	token, apiErr := jwt.ValidateToken(tokenString[1])
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	claims, apiErr := jwt.ReadToken(token)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	// refresh token maupun challenge token 2FA tidak dapat dipakai untuk mengakses endpoint
+	if claims.Type != mjwt.Access {
+		return nil, rest_err.NewUnauthorizedError("Unauthorized, memerlukan access token")
+	}
+	// token tanpa sesi (impersonate) tidak dicek
+	if sessionChecker != nil && claims.Family != "" {
+		active, apiErr := sessionChecker.IsActive(ctx, claims.Family)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		if !active {
+			return nil, rest_err.NewUnauthorizedError("Sesi sudah berakhir, silahkan login kembali")
+		}
+	}
+	return claims, nil
+}
@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/muchlist/mini_pos/utils/ratelimit"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"go.uber.org/zap"
+	"math"
+	"strconv"
+	"time"
+)
+
+const (
+	headerRateLimitLimit     = "RateLimit-Limit"
+	headerRateLimitRemaining = "RateLimit-Remaining"
+	headerRateLimitReset     = "RateLimit-Reset"
+)
+
+// RateLimit membatasi request dengan token bucket untuk setiap rule, request ditolak dengan 429
+// apabila salah satu bucket kosong. Header RateLimit-* diisi dari bucket dengan sisa token paling sedikit.
+// Token pada header Authorization divalidasi di sini agar key user dan api key dapat dipakai sebelum
+// middleware auth, claims yang valid disimpan pada locals sehingga tidak divalidasi ulang
+func RateLimit(store ratelimit.Store, rules ...ratelimit.Rule) fiber.Handler {
+	active := make([]ratelimit.Rule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Enabled() {
+			active = append(active, rule)
+		}
+	}
+
+	return func(c *fiber.Ctx) error {
+		if len(active) == 0 {
+			return c.Next()
+		}
+
+		var tightest *ratelimit.Result
+		for _, rule := range active {
+			key, ok := rateLimitKey(c, rule.By)
+			if !ok {
+				continue
+			}
+			result, err := store.Take(c.UserContext(), rule, key)
+			if err != nil {
+				// store bersama yang gagal tidak boleh menghentikan seluruh api
+				logger.ErrorCtx(c.UserContext(), "error saat mengambil token rate limit (RateLimit:0)", err, zap.String("rule", rule.Name))
+				continue
+			}
+			if !result.Allowed {
+				setRateLimitHeaders(c, result)
+				c.Set(fiber.HeaderRetryAfter, strconv.Itoa(ceilSeconds(result.RetryAfter)))
+				logger.WarnCtx(c.UserContext(), "request ditolak rate limit", zap.String("rule", rule.Name), zap.String("key", key))
+				apiErr := rest_err.NewTooManyRequestsError(fmt.Sprintf("Terlalu banyak request, coba lagi dalam %d detik", ceilSeconds(result.RetryAfter)))
+				return c.Status(apiErr.Status()).JSON(fiber.Map{"error": apiErr, "data": nil})
+			}
+			if tightest == nil || result.Remaining < tightest.Remaining {
+				r := result
+				tightest = &r
+			}
+		}
+		if tightest != nil {
+			setRateLimitHeaders(c, *tightest)
+		}
+		return c.Next()
+	}
+}
+
+// rateLimitKey menentukan key bucket sesuai sumber rule, false apabila rule tidak berlaku untuk request
+func rateLimitKey(c *fiber.Ctx, by string) (string, bool) {
+	switch by {
+	case ratelimit.ByIP:
+		return "ip:" + c.IP(), true
+	case ratelimit.ByMerchant:
+		claims := rateLimitClaims(c)
+		if claims == nil || claims.Merchant == 0 {
+			return "", false
+		}
+		return "merchant:" + strconv.Itoa(claims.Merchant), true
+	default:
+		claims := rateLimitClaims(c)
+		if claims == nil {
+			return "ip:" + c.IP(), true
+		}
+		if claims.APIKey != 0 {
+			return "apikey:" + strconv.Itoa(claims.APIKey), true
+		}
+		return "user:" + strconv.Itoa(claims.Identity), true
+	}
+}
+
+// rateLimitClaims membaca claims dari header Authorization, token yang tidak valid dianggap
+// request tanpa token dan tetap ditolak oleh middleware auth setelahnya
+func rateLimitClaims(c *fiber.Ctx) *mjwt.CustomClaim {
+	if claims, ok := c.Locals(mjwt.CLAIMS).(*mjwt.CustomClaim); ok && claims != nil {
+		return claims
+	}
+	authHeader := c.Get(headerKey)
+	if authHeader == "" {
+		return nil
+	}
+	claims, apiErr := readClaims(c.UserContext(), authHeader)
+	if apiErr != nil {
+		return nil
+	}
+	c.Locals(mjwt.CLAIMS, claims)
+	return claims
+}
+
+func setRateLimitHeaders(c *fiber.Ctx, result ratelimit.Result) {
+	c.Set(headerRateLimitLimit, strconv.Itoa(result.Limit))
+	c.Set(headerRateLimitRemaining, strconv.Itoa(result.Remaining))
+	c.Set(headerRateLimitReset, strconv.Itoa(ceilSeconds(result.Reset)))
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
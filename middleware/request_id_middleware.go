@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/muchlist/mini_pos/utils/audit"
+)
+
+// maxRequestIDLength batas panjang X-Request-ID dari client, id yang lebih panjang diganti
+const maxRequestIDLength = 128
+
+// RequestID memakai header X-Request-ID dari client atau proxy agar log dapat dikorelasikan
+// antar service, id baru dibuat apabila header kosong atau berisi karakter yang tidak aman
+// untuk ditulis ke log. Id disimpan pada locals requestid dan dikembalikan pada header response
+func RequestID() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		requestID := c.Get(fiber.HeaderXRequestID)
+		if !validRequestID(requestID) {
+			requestID = utils.UUIDv4()
+		}
+		c.Set(fiber.HeaderXRequestID, requestID)
+		c.Locals(audit.KeyRequestID, requestID)
+		return c.Next()
+	}
+}
+
+// validRequestID hanya menerima huruf, angka, titik, titik dua, garis bawah dan tanda hubung
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range requestID {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
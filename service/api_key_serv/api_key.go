@@ -0,0 +1,158 @@
+package api_key_serv
+
+import (
+	"context"
+	"fmt"
+	"github.com/muchlist/mini_pos/dao/api_key_dao"
+	"github.com/muchlist/mini_pos/dao/merchant_dao"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/service/outlet_serv"
+	"github.com/muchlist/mini_pos/service/setting_serv"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/mcrypt"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/utils/sfunc"
+	"go.uber.org/zap"
+	"time"
+)
+
+type APIKeyServiceAssumer interface {
+	APIKeyServiceModifier
+	APIKeyServiceReader
+	APIKeyServiceAccess
+}
+
+type APIKeyServiceModifier interface {
+	CreateAPIKey(ctx context.Context, claims mjwt.CustomClaim, request dto.APIKeyRequest) (*dto.APIKeyCreateResponse, rest_err.APIError)
+	RevokeAPIKey(ctx context.Context, claims mjwt.CustomClaim, apiKeyID int) rest_err.APIError
+}
+
+type APIKeyServiceReader interface {
+	FindAPIKeys(ctx context.Context, claims mjwt.CustomClaim) ([]dto.APIKeyModel, rest_err.APIError)
+}
+
+type APIKeyServiceAccess interface {
+	Authenticate(ctx context.Context, key string) (*mjwt.CustomClaim, rest_err.APIError)
+}
+
+func NewAPIKeyService(
+	dao api_key_dao.APIKeyDaoAssumer,
+	outletAccess outlet_serv.OutletServiceAccess,
+	settingDao merchant_dao.MerchantSettingLoader) APIKeyServiceAssumer {
+	return &apiKeyService{
+		dao:          dao,
+		outletAccess: outletAccess,
+		settingDao:   settingDao,
+	}
+}
+
+type apiKeyService struct {
+	dao          api_key_dao.APIKeyDaoAssumer
+	outletAccess outlet_serv.OutletServiceAccess
+	settingDao   merchant_dao.MerchantSettingLoader
+}
+
+// CreateAPIKey membuat api key untuk merchant pembuat. Scope tidak boleh melebihi permission pembuat
+// dan key hanya dikembalikan sekali
+func (a *apiKeyService) CreateAPIKey(ctx context.Context, claims mjwt.CustomClaim, request dto.APIKeyRequest) (*dto.APIKeyCreateResponse, rest_err.APIError) {
+	scopes := sfunc.Unique(request.Scopes)
+	for _, scope := range scopes {
+		if !claims.Can(scope) {
+			return nil, rest_err.NewForbiddenError(fmt.Sprintf("Tidak dapat memberikan scope %s yang tidak dimiliki pembuat", scope))
+		}
+	}
+
+	if request.OutletID != 0 {
+		if err := a.outletAccess.CheckOutletAccess(ctx, claims, request.OutletID); err != nil {
+			return nil, err
+		}
+	}
+
+	key, prefix, secretHash, err := mcrypt.GenerateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := dto.APIKeyModel{
+		MerchantID: claims.Merchant,
+		OutletID:   request.OutletID,
+		Name:       request.Name,
+		Prefix:     prefix,
+		SecretHash: secretHash,
+		Scopes:     scopes,
+		CreatedBy:  claims.Identity,
+		CreatedAt:  time.Now().Unix(),
+		ExpiredAt:  request.ExpiredAt,
+	}
+
+	apiKeyID, err := a.dao.Insert(ctx, apiKey)
+	if err != nil {
+		return nil, err
+	}
+	apiKey.ID = apiKeyID
+	apiKey.ApplyFormat(setting_serv.LoadFormatter(ctx, a.settingDao, claims.Merchant))
+
+	return &dto.APIKeyCreateResponse{
+		APIKeyModel: apiKey,
+		Key:         key,
+	}, nil
+}
+
+// RevokeAPIKey mencabut api key milik merchant
+func (a *apiKeyService) RevokeAPIKey(ctx context.Context, claims mjwt.CustomClaim, apiKeyID int) rest_err.APIError {
+	return a.dao.Revoke(ctx, apiKeyID, claims.Merchant, time.Now().Unix())
+}
+
+func (a *apiKeyService) FindAPIKeys(ctx context.Context, claims mjwt.CustomClaim) ([]dto.APIKeyModel, rest_err.APIError) {
+	apiKeys, err := a.dao.Find(ctx, claims.Merchant)
+	if err != nil {
+		return nil, err
+	}
+	formatter := setting_serv.LoadFormatter(ctx, a.settingDao, claims.Merchant)
+	for i := range apiKeys {
+		apiKeys[i].ApplyFormat(formatter)
+	}
+	return apiKeys, nil
+}
+
+// Authenticate memetakan api key menjadi identitas setara access token. Identitas tidak terikat user
+// (Identity 0), tidak memiliki role, tidak pernah fresh dan permission-nya adalah scope api key
+func (a *apiKeyService) Authenticate(ctx context.Context, key string) (*mjwt.CustomClaim, rest_err.APIError) {
+	invalidErr := rest_err.NewUnauthorizedError("Api key tidak valid atau sudah dicabut")
+
+	prefix, secret, ok := mcrypt.SplitAPIKey(key)
+	if !ok {
+		return nil, invalidErr
+	}
+
+	apiKey, err := a.dao.GetByPrefix(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	if !mcrypt.IsSecretMatch(secret, apiKey.SecretHash) {
+		logger.WarnCtx(ctx, "api key ditolak, secret tidak sesuai", zap.Int("api_key_id", apiKey.ID))
+		return nil, invalidErr
+	}
+
+	timeNow := time.Now().Unix()
+	if apiKey.ExpiredAt != 0 && apiKey.ExpiredAt <= timeNow {
+		return nil, rest_err.NewUnauthorizedError("Api key sudah kadaluarsa")
+	}
+
+	if err := a.dao.SetLastUsed(ctx, apiKey.ID, timeNow); err != nil {
+		// tidak menggagalkan request
+		logger.ErrorCtx(ctx, fmt.Sprintf("gagal memperbarui last used api key %d", apiKey.ID), err)
+	}
+
+	return &mjwt.CustomClaim{
+		Name:        apiKey.Name,
+		Exp:         apiKey.ExpiredAt,
+		Type:        mjwt.Access,
+		Fresh:       false,
+		Merchant:    apiKey.MerchantID,
+		Outlet:      apiKey.OutletID,
+		Permissions: apiKey.Scopes,
+		APIKey:      apiKey.ID,
+	}, nil
+}
@@ -0,0 +1,49 @@
+package audit_serv
+
+import (
+	"context"
+	"github.com/muchlist/mini_pos/dao/audit_dao"
+	"github.com/muchlist/mini_pos/dao/merchant_dao"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/service/setting_serv"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+)
+
+type AuditServiceAssumer interface {
+	AuditServiceReader
+}
+
+type AuditServiceReader interface {
+	FindAuditLogs(ctx context.Context, claims mjwt.CustomClaim, params audit_dao.FindParams) ([]dto.AuditLogModel, rest_err.APIError)
+}
+
+func NewAuditService(dao audit_dao.AuditDaoAssumer, settingDao merchant_dao.MerchantSettingLoader) AuditServiceAssumer {
+	return &auditService{
+		dao:        dao,
+		settingDao: settingDao,
+	}
+}
+
+type auditService struct {
+	dao        audit_dao.AuditDaoAssumer
+	settingDao merchant_dao.MerchantSettingLoader
+}
+
+// FindAuditLogs menampilkan audit log milik merchant pemanggil, filter merchant pada params diabaikan
+func (a *auditService) FindAuditLogs(ctx context.Context, claims mjwt.CustomClaim, params audit_dao.FindParams) ([]dto.AuditLogModel, rest_err.APIError) {
+	params.MerchantID = claims.Merchant
+	if params.From != 0 && params.To != 0 && params.From > params.To {
+		return nil, rest_err.NewBadRequestError("from tidak boleh melebihi to")
+	}
+	logs, err := a.dao.FindWithPagination(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	// isi changes tetap nilai mentah sesuai yang tersimpan, hanya waktu pencatatan yang diformat
+	formatter := setting_serv.LoadFormatter(ctx, a.settingDao, claims.Merchant)
+	for i := range logs {
+		logs[i].ApplyFormat(formatter)
+	}
+	return logs, nil
+}
@@ -0,0 +1,305 @@
+package device_serv
+
+import (
+	"context"
+	"fmt"
+	"github.com/muchlist/mini_pos/configs/roles"
+	"github.com/muchlist/mini_pos/dao/device_dao"
+	"github.com/muchlist/mini_pos/dao/merchant_dao"
+	"github.com/muchlist/mini_pos/dao/outlet_dao"
+	"github.com/muchlist/mini_pos/dao/role_dao"
+	"github.com/muchlist/mini_pos/dao/session_dao"
+	"github.com/muchlist/mini_pos/dao/user_dao"
+	"github.com/muchlist/mini_pos/dao/user_outlet_dao"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/service/setting_serv"
+	"github.com/muchlist/mini_pos/utils/bruteforce"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/mcrypt"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/utils/sfunc"
+	"go.uber.org/zap"
+	"math"
+	"time"
+)
+
+const (
+	expiredPinToken = 60 * 8 // 8 jam, satu shift kasir
+	maxPinFailure   = 5      // sama dengan batas login password, kegagalan PIN ikut mengunci akun
+	pinLockDuration = 15 * time.Minute
+)
+
+type DeviceServiceAssumer interface {
+	DeviceServiceModifier
+	DeviceServiceReader
+	DeviceServiceAccess
+}
+
+type DeviceServiceModifier interface {
+	RegisterDevice(ctx context.Context, claims mjwt.CustomClaim, request dto.DeviceRequest) (*dto.DeviceRegisterResponse, rest_err.APIError)
+	RevokeDevice(ctx context.Context, claims mjwt.CustomClaim, deviceID int) rest_err.APIError
+	SetPin(ctx context.Context, claims mjwt.CustomClaim, request dto.PinRequest) rest_err.APIError
+}
+
+type DeviceServiceReader interface {
+	FindDevices(ctx context.Context, claims mjwt.CustomClaim) ([]dto.DeviceModel, rest_err.APIError)
+	FindDeviceUsers(ctx context.Context, deviceToken string) ([]dto.DeviceUserModel, rest_err.APIError)
+}
+
+type DeviceServiceAccess interface {
+	PinLogin(ctx context.Context, deviceToken string, request dto.PinLoginRequest, meta dto.SessionMeta) (*dto.UserLoginResponse, rest_err.APIError)
+}
+
+func NewDeviceService(
+	dao device_dao.DeviceDaoAssumer,
+	userDao user_dao.UserDaoAssumer,
+	userOutletDao user_outlet_dao.UserOutletLoader,
+	outletDao outlet_dao.OutletLoader,
+	sessionDao session_dao.SessionSaver,
+	roleDao role_dao.RoleLoader,
+	settingDao merchant_dao.MerchantSettingLoader,
+	crypto mcrypt.BcryptAssumer,
+	jwt mjwt.JWTAssumer,
+	guard bruteforce.GuardAssumer) DeviceServiceAssumer {
+	return &deviceService{
+		dao:           dao,
+		userDao:       userDao,
+		userOutletDao: userOutletDao,
+		outletDao:     outletDao,
+		sessionDao:    sessionDao,
+		roleDao:       roleDao,
+		settingDao:    settingDao,
+		crypto:        crypto,
+		jwt:           jwt,
+		guard:         guard,
+	}
+}
+
+type deviceService struct {
+	dao           device_dao.DeviceDaoAssumer
+	userDao       user_dao.UserDaoAssumer
+	userOutletDao user_outlet_dao.UserOutletLoader
+	outletDao     outlet_dao.OutletLoader
+	sessionDao    session_dao.SessionSaver
+	roleDao       role_dao.RoleLoader
+	settingDao    merchant_dao.MerchantSettingLoader
+	crypto        mcrypt.BcryptAssumer
+	jwt           mjwt.JWTAssumer
+	guard         bruteforce.GuardAssumer
+}
+
+// RegisterDevice owner memasangkan perangkat kasir ke outlet, device token hanya dikembalikan sekali
+func (d *deviceService) RegisterDevice(ctx context.Context, claims mjwt.CustomClaim, request dto.DeviceRequest) (*dto.DeviceRegisterResponse, rest_err.APIError) {
+	// memastikan outlet milik merchant yang sama
+	if _, err := d.outletDao.Get(ctx, request.OutletID, claims.Merchant); err != nil {
+		return nil, err
+	}
+
+	token, tokenHash, err := mcrypt.GenerateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	device := dto.DeviceModel{
+		MerchantID: claims.Merchant,
+		OutletID:   request.OutletID,
+		Name:       dto.UppercaseString(request.Name),
+		TokenHash:  tokenHash,
+		CreatedBy:  claims.Identity,
+		CreatedAt:  time.Now().Unix(),
+	}
+
+	deviceID, err := d.dao.Insert(ctx, device)
+	if err != nil {
+		return nil, err
+	}
+	device.ID = deviceID
+	device.ApplyFormat(setting_serv.LoadFormatter(ctx, d.settingDao, claims.Merchant))
+
+	return &dto.DeviceRegisterResponse{
+		DeviceModel: device,
+		DeviceToken: token,
+	}, nil
+}
+
+// RevokeDevice mencabut perangkat, sesi login PIN dari perangkat tersebut ikut berakhir
+func (d *deviceService) RevokeDevice(ctx context.Context, claims mjwt.CustomClaim, deviceID int) rest_err.APIError {
+	return d.dao.Revoke(ctx, deviceID, claims.Merchant)
+}
+
+// SetPin mengatur PIN milik user yang sedang login
+func (d *deviceService) SetPin(ctx context.Context, claims mjwt.CustomClaim, request dto.PinRequest) rest_err.APIError {
+	pinHash, err := d.crypto.GenerateHash(request.Pin)
+	if err != nil {
+		return err
+	}
+	return d.userDao.SetPin(ctx, claims.Identity, pinHash, time.Now().Unix())
+}
+
+func (d *deviceService) FindDevices(ctx context.Context, claims mjwt.CustomClaim) ([]dto.DeviceModel, rest_err.APIError) {
+	devices, err := d.dao.Find(ctx, claims.Merchant)
+	if err != nil {
+		return nil, err
+	}
+	formatter := setting_serv.LoadFormatter(ctx, d.settingDao, claims.Merchant)
+	for i := range devices {
+		devices[i].ApplyFormat(formatter)
+	}
+	return devices, nil
+}
+
+// FindDeviceUsers menampilkan employee yang dapat login PIN pada perangkat
+func (d *deviceService) FindDeviceUsers(ctx context.Context, deviceToken string) ([]dto.DeviceUserModel, rest_err.APIError) {
+	device, err := d.getDevice(ctx, deviceToken)
+	if err != nil {
+		return nil, err
+	}
+	return d.dao.FindUsers(ctx, device.MerchantID, device.OutletID)
+}
+
+// PinLogin berganti user pada perangkat kasir menggunakan PIN. Token yang dihasilkan tidak fresh,
+// tidak memiliki refresh token dan hanya berlaku untuk outlet perangkat
+func (d *deviceService) PinLogin(ctx context.Context, deviceToken string, request dto.PinLoginRequest, meta dto.SessionMeta) (*dto.UserLoginResponse, rest_err.APIError) {
+	device, err := d.getDevice(ctx, deviceToken)
+	if err != nil {
+		return nil, err
+	}
+
+	invalidErr := rest_err.NewUnauthorizedError("user atau pin tidak valid")
+
+	// PIN hanya 4-6 digit sehingga jeda bertingkat per user dan per ip wajib diterapkan
+	pinKey := fmt.Sprint(request.UserID)
+	for _, check := range []struct {
+		rule bruteforce.Rule
+		key  string
+	}{{bruteforce.RulePin, pinKey}, {bruteforce.RuleIP, meta.IP}} {
+		if wait, blocked := d.guard.Check(check.rule, check.key); blocked {
+			logger.WarnCtx(ctx, "login PIN ditolak, dalam masa jeda",
+				zap.Int("user_id", request.UserID), zap.Int("device_id", device.ID), zap.String("ip", meta.IP))
+			return nil, rest_err.NewTooManyRequestsError(fmt.Sprintf("Terlalu banyak percobaan login, coba lagi dalam %d detik", int(math.Ceil(wait.Seconds()))))
+		}
+	}
+
+	user, err := d.userDao.GetByID(ctx, request.UserID)
+	if err != nil {
+		return nil, invalidErr
+	}
+	if user.MerchantID != device.MerchantID || string(user.Role) != roles.RoleEmployee {
+		return nil, invalidErr
+	}
+	// user dapat ditugaskan pada beberapa outlet, tidak hanya outlet default
+	assigned, err := d.userOutletDao.IsAssigned(ctx, user.ID, device.OutletID)
+	if err != nil {
+		return nil, err
+	}
+	if !assigned {
+		return nil, invalidErr
+	}
+	if user.LockedUntil > time.Now().Unix() {
+		logger.WarnCtx(ctx, "login PIN ditolak, akun terkunci", zap.Int("user_id", user.ID), zap.Int("device_id", device.ID))
+		return nil, rest_err.NewTooManyRequestsError("Akun dikunci sementara karena terlalu banyak login gagal, hubungi owner")
+	}
+
+	pinHash, err := d.userDao.GetPinByID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if pinHash == "" || !d.crypto.IsPWAndHashPWMatch(request.Pin, pinHash) {
+		d.pinFailed(ctx, *user, device.ID, meta.IP)
+		return nil, invalidErr
+	}
+	d.guard.Reset(bruteforce.RulePin, pinKey)
+	if user.FailedLogin > 0 {
+		_ = d.userDao.ResetLoginFailure(ctx, user.ID, 0)
+	}
+
+	sessionID, err := mcrypt.NewID()
+	if err != nil {
+		return nil, err
+	}
+	perms, err := d.roleDao.GetUserPermissions(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	accessClaims := mjwt.CustomClaim{
+		Identity:    user.ID,
+		Name:        string(user.Name),
+		ExtraMinute: expiredPinToken,
+		Type:        mjwt.Access,
+		Fresh:       false,
+		Role:        string(user.Role),
+		Merchant:    device.MerchantID,
+		Outlet:      device.OutletID,
+		Family:      sessionID,
+		Device:      device.ID,
+		Permissions: perms,
+	}
+
+	accessToken, err := d.jwt.GenerateToken(accessClaims)
+	if err != nil {
+		return nil, err
+	}
+
+	timeNow := time.Now()
+	expired := timeNow.Add(time.Minute * time.Duration(expiredPinToken)).Unix()
+	err = d.sessionDao.Insert(ctx, dto.SessionModel{
+		ID:         sessionID,
+		UserID:     user.ID,
+		UserAgent:  sfunc.Truncate(meta.UserAgent, 255),
+		IP:         meta.IP,
+		OutletID:   device.OutletID,
+		DeviceID:   device.ID,
+		CreatedAt:  timeNow.Unix(),
+		LastSeenAt: timeNow.Unix(),
+		ExpiredAt:  expired,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.dao.SetLastUsed(ctx, device.ID, timeNow.Unix()); err != nil {
+		// tidak menggagalkan login
+		logger.ErrorCtx(ctx, fmt.Sprintf("gagal memperbarui last used device %d", device.ID), err)
+	}
+
+	response := &dto.UserLoginResponse{
+		ID:          user.ID,
+		Email:       string(user.Email),
+		Name:        string(user.Name),
+		MerchantID:  user.MerchantID,
+		DefOutlet:   device.OutletID,
+		Role:        string(user.Role),
+		Permissions: perms,
+		AccessToken: accessToken,
+		Expired:     expired,
+	}
+	response.ApplyFormat(setting_serv.LoadFormatter(ctx, d.settingDao, user.MerchantID))
+	return response, nil
+}
+
+// pinFailed mencatat PIN salah pada guard dan pada akun
+func (d *deviceService) pinFailed(ctx context.Context, user dto.UserModel, deviceID int, ip string) {
+	pinFailures, _ := d.guard.Fail(bruteforce.RulePin, fmt.Sprint(user.ID))
+	d.guard.Fail(bruteforce.RuleIP, ip)
+
+	fields := []zap.Field{zap.Int("user_id", user.ID), zap.Int("device_id", deviceID), zap.String("ip", ip), zap.Int("pin_failures", pinFailures)}
+	failures, lockedUntil, err := d.userDao.RecordLoginFailure(ctx, user.ID, maxPinFailure, time.Now().Add(pinLockDuration).Unix())
+	if err != nil {
+		return
+	}
+	fields = append(fields, zap.Int("account_failures", failures))
+	if failures >= maxPinFailure && lockedUntil > time.Now().Unix() {
+		logger.WarnCtx(ctx, "akun dikunci karena login PIN gagal berulang", fields...)
+		return
+	}
+	logger.WarnCtx(ctx, "login PIN gagal", fields...)
+}
+
+func (d *deviceService) getDevice(ctx context.Context, deviceToken string) (*dto.DeviceModel, rest_err.APIError) {
+	if deviceToken == "" {
+		return nil, rest_err.NewUnauthorizedError("Header X-Device-Token diperlukan")
+	}
+	return d.dao.GetByTokenHash(ctx, mcrypt.HashToken(deviceToken))
+}
@@ -0,0 +1,128 @@
+package health_serv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/muchlist/mini_pos/db"
+	"github.com/muchlist/mini_pos/dto"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// checkTimeout batas waktu setiap pemeriksaan agar probe tidak menggantung
+const checkTimeout = 2 * time.Second
+
+type HealthServiceAssumer interface {
+	HealthServiceReader
+	HealthServiceModifier
+}
+
+type HealthServiceReader interface {
+	Readiness(ctx context.Context) (dto.ReadinessResponse, bool)
+}
+
+type HealthServiceModifier interface {
+	StartShutdown()
+}
+
+// Pinger dipenuhi oleh *pgxpool.Pool
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// MigrationChecker dipenuhi oleh *db.Migrator
+type MigrationChecker interface {
+	Pending(ctx context.Context) ([]db.Migration, error)
+}
+
+// NewHealthService imageDirs adalah direktori yang harus dapat ditulisi untuk upload gambar
+func NewHealthService(pool Pinger, migrator MigrationChecker, imageDirs []string) HealthServiceAssumer {
+	return &healthService{
+		pool:      pool,
+		migrator:  migrator,
+		imageDirs: imageDirs,
+	}
+}
+
+type healthService struct {
+	pool      Pinger
+	migrator  MigrationChecker
+	imageDirs []string
+	// shuttingDown bernilai 1 setelah shutdown dimulai
+	shuttingDown int32
+}
+
+// StartShutdown membuat readiness gagal sehingga load balancer berhenti mengirim request baru
+func (h *healthService) StartShutdown() {
+	atomic.StoreInt32(&h.shuttingDown, 1)
+}
+
+// Readiness memeriksa koneksi database, versi migrasi dan direktori gambar.
+// Mengembalikan false apabila salah satu pemeriksaan gagal atau aplikasi sedang shutdown
+func (h *healthService) Readiness(ctx context.Context) (dto.ReadinessResponse, bool) {
+	checks := []dto.HealthCheck{
+		h.check(ctx, "shutdown", func(ctx context.Context) error {
+			if atomic.LoadInt32(&h.shuttingDown) == 1 {
+				return errors.New("aplikasi sedang shutdown")
+			}
+			return nil
+		}),
+		h.check(ctx, "database", h.pool.Ping),
+		h.check(ctx, "migration", h.checkMigration),
+		h.check(ctx, "image_dir", h.checkImageDirs),
+	}
+
+	ready := true
+	for _, c := range checks {
+		if c.Status != dto.HealthOk {
+			ready = false
+		}
+	}
+
+	res := dto.ReadinessResponse{Status: dto.HealthOk, Checks: checks}
+	if !ready {
+		res.Status = dto.HealthFail
+	}
+	return res, ready
+}
+
+func (h *healthService) check(ctx context.Context, name string, fn func(ctx context.Context) error) dto.HealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	if err := fn(ctx); err != nil {
+		return dto.HealthCheck{Name: name, Status: dto.HealthFail, Error: err.Error()}
+	}
+	return dto.HealthCheck{Name: name, Status: dto.HealthOk}
+}
+
+func (h *healthService) checkMigration(ctx context.Context) error {
+	pending, err := h.migrator.Pending(ctx)
+	if err != nil {
+		return err
+	}
+	if len(pending) != 0 {
+		names := make([]string, 0, len(pending))
+		for _, mig := range pending {
+			names = append(names, fmt.Sprintf("%d_%s", mig.Version, mig.Name))
+		}
+		return fmt.Errorf("migrasi belum diterapkan: %s", strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// checkImageDirs membuat lalu menghapus file sementara pada setiap direktori gambar
+func (h *healthService) checkImageDirs(_ context.Context) error {
+	for _, dir := range h.imageDirs {
+		file, err := os.CreateTemp(dir, ".readyz-*")
+		if err != nil {
+			return fmt.Errorf("direktori %s tidak dapat ditulisi: %w", dir, err)
+		}
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+	}
+	return nil
+}
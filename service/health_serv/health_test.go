@@ -0,0 +1,56 @@
+package health_serv
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/muchlist/mini_pos/db"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+type pingerMock struct{ err error }
+
+func (p pingerMock) Ping(_ context.Context) error { return p.err }
+
+type migrationMock struct{ pending []db.Migration }
+
+func (m migrationMock) Pending(_ context.Context) ([]db.Migration, error) { return m.pending, nil }
+
+func statusOf(res dto.ReadinessResponse, name string) string {
+	for _, c := range res.Checks {
+		if c.Name == name {
+			return c.Status
+		}
+	}
+	return ""
+}
+
+func TestReadiness(t *testing.T) {
+	service := NewHealthService(pingerMock{}, migrationMock{}, []string{t.TempDir()})
+
+	res, ready := service.Readiness(context.Background())
+	assert.True(t, ready)
+	assert.Equal(t, dto.HealthOk, res.Status)
+
+	service.StartShutdown()
+	res, ready = service.Readiness(context.Background())
+	assert.False(t, ready)
+	assert.Equal(t, dto.HealthFail, statusOf(res, "shutdown"))
+}
+
+func TestReadinessFailingChecks(t *testing.T) {
+	service := NewHealthService(
+		pingerMock{err: errors.New("connection refused")},
+		migrationMock{pending: []db.Migration{{Version: 3, Name: "add_column"}}},
+		[]string{"/path/yang/tidak/ada"},
+	)
+
+	res, ready := service.Readiness(context.Background())
+	assert.False(t, ready)
+	assert.Equal(t, dto.HealthOk, statusOf(res, "shutdown"))
+	assert.Equal(t, dto.HealthFail, statusOf(res, "database"))
+	assert.Equal(t, dto.HealthFail, statusOf(res, "migration"))
+	assert.Equal(t, dto.HealthFail, statusOf(res, "image_dir"))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/muchlist/mini_pos/dao/merchant_dao"
 	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/service/setting_serv"
 	"github.com/muchlist/mini_pos/utils/mcrypt"
 	"github.com/muchlist/mini_pos/utils/rest_err"
 	"time"
@@ -40,11 +41,16 @@ func (m *merchantService) CreateMerchant(ctx context.Context, req dto.MerchantCr
 }
 
 func (m *merchantService) Edit(ctx context.Context, req dto.MerchantEditReq) (*dto.Merchant, rest_err.APIError) {
-	return m.dao.Edit(ctx, dto.Merchant{
+	merchant, err := m.dao.Edit(ctx, dto.Merchant{
 		Id:           req.Id,
 		MerchantName: req.MerchantName,
 		UpdatedAt:    time.Now().Unix(),
 	})
+	if err != nil {
+		return nil, err
+	}
+	merchant.ApplyFormat(setting_serv.LoadFormatter(ctx, m.dao, merchant.Id))
+	return merchant, nil
 }
 
 func (m *merchantService) Delete(ctx context.Context, id int) rest_err.APIError {
@@ -52,7 +58,12 @@ func (m *merchantService) Delete(ctx context.Context, id int) rest_err.APIError
 }
 
 func (m *merchantService) Get(ctx context.Context, id int) (*dto.Merchant, rest_err.APIError) {
-	return m.dao.Get(ctx, id)
+	merchant, err := m.dao.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	merchant.ApplyFormat(setting_serv.LoadFormatter(ctx, m.dao, merchant.Id))
+	return merchant, nil
 }
 
 func (m *merchantService) FindMerchant(ctx context.Context, search string, limit int, cursor int) ([]dto.Merchant, rest_err.APIError) {
@@ -64,5 +75,10 @@ func (m *merchantService) FindMerchant(ctx context.Context, search string, limit
 	if err != nil {
 		return nil, err
 	}
+	// setiap merchant diformat dengan pengaturannya sendiri
+	formatters := setting_serv.NewFormatters(m.dao)
+	for i := range merchantList {
+		merchantList[i].ApplyFormat(formatters.Get(ctx, merchantList[i].Id))
+	}
 	return merchantList, nil
 }
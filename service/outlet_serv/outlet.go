@@ -2,8 +2,14 @@ package outlet_serv
 
 import (
 	"context"
+	"fmt"
+	"github.com/muchlist/mini_pos/configs/permissions"
+	"github.com/muchlist/mini_pos/dao/merchant_dao"
 	"github.com/muchlist/mini_pos/dao/outlet_dao"
+	"github.com/muchlist/mini_pos/dao/plan_dao"
+	"github.com/muchlist/mini_pos/dao/user_outlet_dao"
 	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/service/setting_serv"
 	"github.com/muchlist/mini_pos/utils/mjwt"
 	"github.com/muchlist/mini_pos/utils/rest_err"
 	"time"
@@ -12,6 +18,11 @@ import (
 type OutletServiceAssumer interface {
 	OutletServiceModifier
 	OutletServiceReader
+	OutletServiceAccess
+}
+
+type OutletServiceAccess interface {
+	CheckOutletAccess(ctx context.Context, claims mjwt.CustomClaim, outletID int) rest_err.APIError
 }
 
 type OutletServiceReader interface {
@@ -25,14 +36,24 @@ type OutletServiceModifier interface {
 	DeleteOutlet(ctx context.Context, claims mjwt.CustomClaim, outletID int) rest_err.APIError
 }
 
-func NewOutletService(dao outlet_dao.OutletDaoAssumer) OutletServiceAssumer {
+func NewOutletService(
+	dao outlet_dao.OutletDaoAssumer,
+	planDao plan_dao.PlanLoader,
+	userOutletDao user_outlet_dao.UserOutletLoader,
+	settingDao merchant_dao.MerchantSettingLoader) OutletServiceAssumer {
 	return &outletService{
-		dao: dao,
+		dao:           dao,
+		planDao:       planDao,
+		userOutletDao: userOutletDao,
+		settingDao:    settingDao,
 	}
 }
 
 type outletService struct {
-	dao outlet_dao.OutletDaoAssumer
+	dao           outlet_dao.OutletDaoAssumer
+	planDao       plan_dao.PlanLoader
+	userOutletDao user_outlet_dao.UserOutletLoader
+	settingDao    merchant_dao.MerchantSettingLoader
 }
 
 // CreateOutlet melakukan register outlet oleh akun owner
@@ -43,6 +64,14 @@ func (u *outletService) CreateOutlet(ctx context.Context, claims mjwt.CustomClai
 	outlet.UpdatedAt = timeNow
 	outlet.MerchantID = claims.Merchant // merchant ID adalah sama dengan merchant id owner
 
+	usage, err := u.planDao.GetUsage(ctx, claims.Merchant)
+	if err != nil {
+		return 0, err
+	}
+	if errQuota := usage.CheckQuota(dto.QuotaOutlet, 1); errQuota != nil {
+		return 0, rest_err.NewQuotaExceededError(errQuota.Error())
+	}
+
 	outletID, err := u.dao.Insert(ctx, outlet)
 	if err != nil {
 		return 0, err
@@ -64,6 +93,7 @@ func (u *outletService) EditOutlet(ctx context.Context, claims mjwt.CustomClaim,
 	if err != nil {
 		return nil, err
 	}
+	result.ApplyFormat(setting_serv.LoadFormatter(ctx, u.settingDao, claims.Merchant))
 	return result, nil
 }
 
@@ -78,22 +108,64 @@ func (u *outletService) DeleteOutlet(ctx context.Context, claims mjwt.CustomClai
 
 // GetOutletByID mendapatkan outlet dari database
 func (u *outletService) GetOutletByID(ctx context.Context, claims mjwt.CustomClaim, outletID int) (*dto.OutletModel, rest_err.APIError) {
+	if err := u.CheckOutletAccess(ctx, claims, outletID); err != nil {
+		return nil, err
+	}
 	outlet, err := u.dao.Get(ctx, outletID, claims.Merchant)
 	if err != nil {
 		return nil, err
 	}
+	outlet.ApplyFormat(setting_serv.LoadFormatter(ctx, u.settingDao, claims.Merchant))
 	return outlet, nil
 }
 
 // FindOutlets
 func (u *outletService) FindOutlets(ctx context.Context, claims mjwt.CustomClaim, search string, limit int, offset int) ([]dto.OutletModel, rest_err.APIError) {
-	outletList, err := u.dao.FindWithPagination(ctx, outlet_dao.FindParams{
+	params := outlet_dao.FindParams{
 		Search: search,
 		Limit:  limit,
 		Offset: offset,
-	}, claims.Merchant)
+	}
+	if !claims.Can(permissions.OutletManage) && claims.APIKey == 0 {
+		// user tanpa outlet.manage hanya melihat outlet yang ditugaskan kepadanya,
+		// api key tidak terikat user sehingga melihat seluruh outlet merchant
+		params.AssignedUser = claims.Identity
+	}
+	outletList, err := u.dao.FindWithPagination(ctx, params, claims.Merchant)
 	if err != nil {
 		return nil, err
 	}
+	formatter := setting_serv.LoadFormatter(ctx, u.settingDao, claims.Merchant)
+	for i := range outletList {
+		outletList[i].ApplyFormat(formatter)
+	}
 	return outletList, nil
 }
+
+// CheckOutletAccess memastikan claims boleh bertindak pada outlet. Token perangkat kasir dan api key
+// terbatas outlet hanya berlaku untuk outlet tersebut, user tanpa permission outlet.manage hanya untuk
+// outlet yang ditugaskan kepadanya, selain itu outlet cukup milik merchant yang sama
+func (u *outletService) CheckOutletAccess(ctx context.Context, claims mjwt.CustomClaim, outletID int) rest_err.APIError {
+	if claims.OutletBound() {
+		if outletID != claims.Outlet {
+			return rest_err.NewForbiddenError("Token perangkat kasir atau api key hanya berlaku untuk outlet yang ditentukan")
+		}
+		return nil
+	}
+
+	if claims.Can(permissions.OutletManage) || claims.APIKey != 0 {
+		if _, err := u.dao.Get(ctx, outletID, claims.Merchant); err != nil {
+			return rest_err.NewBadRequestError(fmt.Sprintf("Outlet dengan id %d tidak ditemukan", outletID))
+		}
+		return nil
+	}
+
+	assigned, err := u.userOutletDao.IsAssigned(ctx, claims.Identity, outletID)
+	if err != nil {
+		return err
+	}
+	if !assigned {
+		return rest_err.NewForbiddenError(fmt.Sprintf("Outlet %d tidak ditugaskan kepada user ini", outletID))
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package password_serv
+
+import (
+	"context"
+	"fmt"
+	"github.com/muchlist/mini_pos/configs/roles"
+	"github.com/muchlist/mini_pos/dao/password_reset_dao"
+	"github.com/muchlist/mini_pos/dao/user_dao"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/mcrypt"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/muchlist/mini_pos/utils/notifier"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"strings"
+	"time"
+)
+
+const (
+	expiredResetToken = 30 // 30 minute
+	forgotPasswordMsg = "apabila email terdaftar, link reset password telah dikirim"
+)
+
+type PasswordServiceAssumer interface {
+	ChangePassword(ctx context.Context, claims mjwt.CustomClaim, request dto.ChangePasswordRequest) (string, rest_err.APIError)
+	ForgotPassword(ctx context.Context, request dto.ForgotPasswordRequest) (string, rest_err.APIError)
+	ResetPassword(ctx context.Context, request dto.ResetPasswordRequest) (string, rest_err.APIError)
+	OwnerResetPassword(ctx context.Context, claims mjwt.CustomClaim, userID int) (string, rest_err.APIError)
+	AdminSetPassword(ctx context.Context, email string, newPassword string) (string, rest_err.APIError)
+}
+
+// NewPasswordService appURL dipakai untuk membuat link reset password, contoh http://localhost:3000
+func NewPasswordService(
+	userDao user_dao.UserDaoAssumer,
+	resetDao password_reset_dao.PasswordResetDaoAssumer,
+	crypto mcrypt.BcryptAssumer,
+	notify notifier.Notifier,
+	appURL string) PasswordServiceAssumer {
+	return &passwordService{
+		userDao:  userDao,
+		resetDao: resetDao,
+		crypto:   crypto,
+		notify:   notify,
+		appURL:   strings.TrimRight(appURL, "/"),
+	}
+}
+
+type passwordService struct {
+	userDao  user_dao.UserDaoAssumer
+	resetDao password_reset_dao.PasswordResetDaoAssumer
+	crypto   mcrypt.BcryptAssumer
+	notify   notifier.Notifier
+	appURL   string
+}
+
+// ChangePassword mengganti password user yang login, password lama harus sesuai.
+// Seluruh sesi lain dicabut sedangkan sesi yang dipakai untuk mengganti password tetap berlaku
+func (p *passwordService) ChangePassword(ctx context.Context, claims mjwt.CustomClaim, request dto.ChangePasswordRequest) (string, rest_err.APIError) {
+	currentHash, err := p.userDao.GetPasswordByID(ctx, claims.Identity)
+	if err != nil {
+		return "", err
+	}
+
+	if !p.crypto.IsPWAndHashPWMatch(request.CurrentPassword, currentHash) {
+		return "", rest_err.NewBadRequestError("Password lama tidak valid")
+	}
+
+	newHash, err := p.crypto.GenerateHash(request.NewPassword)
+	if err != nil {
+		return "", err
+	}
+
+	err = p.userDao.ChangePassword(ctx, dto.UserModel{
+		ID:        claims.Identity,
+		Password:  newHash,
+		UpdatedAt: time.Now().Unix(),
+	}, claims.Family)
+	if err != nil {
+		return "", err
+	}
+
+	return "password berhasil diubah", nil
+}
+
+// ForgotPassword mengirim link reset password ke email user. Response selalu sama
+// baik email terdaftar maupun tidak agar tidak dapat dipakai untuk menebak email
+func (p *passwordService) ForgotPassword(ctx context.Context, request dto.ForgotPasswordRequest) (string, rest_err.APIError) {
+	user, err := p.userDao.GetByEmail(ctx, strings.ToLower(request.Email))
+	if err != nil {
+		return forgotPasswordMsg, nil
+	}
+
+	// kegagalan mengirim sudah dicatat pada sendResetToken, response tetap sama agar
+	// status kode tidak membedakan email terdaftar dengan yang tidak
+	_ = p.sendResetToken(ctx, *user, 0)
+
+	return forgotPasswordMsg, nil
+}
+
+// ResetPassword mengganti password memakai token dari link reset, token hanya dapat dipakai sekali
+func (p *passwordService) ResetPassword(ctx context.Context, request dto.ResetPasswordRequest) (string, rest_err.APIError) {
+	newHash, err := p.crypto.GenerateHash(request.NewPassword)
+	if err != nil {
+		return "", err
+	}
+
+	userID, err := p.resetDao.Consume(ctx, mcrypt.HashToken(request.Token), newHash)
+	if err != nil {
+		return "", err
+	}
+	logger.InfoCtx(ctx, fmt.Sprintf("password user %d direset melalui token", userID))
+
+	return "password berhasil direset, silahkan login kembali", nil
+}
+
+// OwnerResetPassword owner mengirim link reset password kepada employee pada merchant yang sama
+func (p *passwordService) OwnerResetPassword(ctx context.Context, claims mjwt.CustomClaim, userID int) (string, rest_err.APIError) {
+	user, err := p.userDao.GetByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	// owner hanya dapat mereset user lain pada merchant yang sama selain owner
+	if user.MerchantID != claims.Merchant || string(user.Role) == roles.RoleOwner || string(user.Role) == roles.RoleSuper {
+		return "", rest_err.NewBadRequestError(fmt.Sprintf("User dengan id %d tidak ditemukan", userID))
+	}
+
+	if err := p.sendResetToken(ctx, *user, claims.Identity); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("link reset password telah dikirim ke %s", user.Email), nil
+}
+
+// AdminSetPassword dipakai operator melalui command line untuk langsung mengganti password user,
+// akun ikut dibuka kuncinya dan seluruh sesi user dicabut
+func (p *passwordService) AdminSetPassword(ctx context.Context, email string, newPassword string) (string, rest_err.APIError) {
+	user, err := p.userDao.GetByEmail(ctx, strings.ToLower(email))
+	if err != nil {
+		return "", err
+	}
+
+	newHash, err := p.crypto.GenerateHash(newPassword)
+	if err != nil {
+		return "", err
+	}
+
+	if err := p.userDao.SetPassword(ctx, user.ID, newHash, time.Now().Unix()); err != nil {
+		return "", err
+	}
+	logger.InfoCtx(ctx, fmt.Sprintf("password user %d diganti melalui command line", user.ID))
+
+	return fmt.Sprintf("password %s berhasil diganti", user.Email), nil
+}
+
+func (p *passwordService) sendResetToken(ctx context.Context, user dto.UserModel, requestedBy int) rest_err.APIError {
+	token, tokenHash, err := mcrypt.GenerateToken()
+	if err != nil {
+		return err
+	}
+
+	timeNow := time.Now()
+	_, err = p.resetDao.Insert(ctx, dto.PasswordResetModel{
+		UserID:      user.ID,
+		TokenHash:   tokenHash,
+		RequestedBy: requestedBy,
+		CreatedAt:   timeNow.Unix(),
+		ExpiredAt:   timeNow.Add(time.Minute * expiredResetToken).Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
+	errNotify := p.notify.Notify(ctx, notifier.Notification{
+		To:      string(user.Email),
+		Subject: "Reset password",
+		Body: fmt.Sprintf("Halo %s,\n\nSilahkan buka link berikut untuk membuat password baru :\n%s/reset-password?token=%s\n\nLink berlaku selama %d menit dan hanya dapat dipakai sekali. Abaikan email ini apabila anda tidak memintanya.",
+			user.Name, p.appURL, token, expiredResetToken),
+	})
+	if errNotify != nil {
+		logger.ErrorCtx(ctx, "error saat mengirim token reset password (sendResetToken:0)", errNotify)
+		return rest_err.NewInternalServerError("gagal mengirim link reset password", errNotify)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package plan_serv
+
+import (
+	"context"
+	"github.com/muchlist/mini_pos/dao/merchant_dao"
+	"github.com/muchlist/mini_pos/dao/plan_dao"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/service/setting_serv"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"strings"
+)
+
+type PlanServiceAssumer interface {
+	PlanServiceModifier
+	PlanServiceReader
+}
+
+type PlanServiceReader interface {
+	GetUsage(ctx context.Context, claims mjwt.CustomClaim) (*dto.MerchantUsage, rest_err.APIError)
+	FindPlans(ctx context.Context) ([]dto.PlanModel, rest_err.APIError)
+}
+
+type PlanServiceModifier interface {
+	CreatePlan(ctx context.Context, request dto.PlanRequest) (int, rest_err.APIError)
+	EditPlan(ctx context.Context, request dto.PlanRequest) (*dto.PlanModel, rest_err.APIError)
+	SetMerchantPlan(ctx context.Context, request dto.MerchantPlanRequest) (*dto.MerchantUsage, rest_err.APIError)
+}
+
+func NewPlanService(dao plan_dao.PlanDaoAssumer, settingDao merchant_dao.MerchantSettingLoader) PlanServiceAssumer {
+	return &planService{
+		dao:        dao,
+		settingDao: settingDao,
+	}
+}
+
+type planService struct {
+	dao        plan_dao.PlanDaoAssumer
+	settingDao merchant_dao.MerchantSettingLoader
+}
+
+// GetUsage menampilkan pemakaian resource merchant user yang login beserta batas paketnya
+func (p *planService) GetUsage(ctx context.Context, claims mjwt.CustomClaim) (*dto.MerchantUsage, rest_err.APIError) {
+	if claims.Merchant == 0 {
+		return nil, rest_err.NewForbiddenError("User tidak terikat dengan merchant manapun")
+	}
+	return p.getUsage(ctx, claims.Merchant)
+}
+
+// FindPlans daftar paket untuk super user, waktu diformat dengan timezone default
+func (p *planService) FindPlans(ctx context.Context) ([]dto.PlanModel, rest_err.APIError) {
+	plans, err := p.dao.Find(ctx)
+	if err != nil {
+		return nil, err
+	}
+	formatter := setting_serv.LoadFormatter(ctx, p.settingDao, 0)
+	for i := range plans {
+		plans[i].ApplyFormat(formatter)
+	}
+	return plans, nil
+}
+
+// CreatePlan menambahkan paket langganan, hanya untuk super user
+func (p *planService) CreatePlan(ctx context.Context, request dto.PlanRequest) (int, rest_err.APIError) {
+	return p.dao.Insert(ctx, dto.PlanModel{
+		Name:          strings.ToUpper(request.Name),
+		MaxOutlets:    request.MaxOutlets,
+		MaxUsers:      request.MaxUsers,
+		MaxProducts:   request.MaxProducts,
+		MaxImageBytes: request.MaxImageBytes,
+	})
+}
+
+// EditPlan merubah batas paket, berlaku langsung untuk semua merchant pada paket tersebut
+func (p *planService) EditPlan(ctx context.Context, request dto.PlanRequest) (*dto.PlanModel, rest_err.APIError) {
+	plan, err := p.dao.Edit(ctx, dto.PlanModel{
+		ID:            request.ID,
+		Name:          strings.ToUpper(request.Name),
+		MaxOutlets:    request.MaxOutlets,
+		MaxUsers:      request.MaxUsers,
+		MaxProducts:   request.MaxProducts,
+		MaxImageBytes: request.MaxImageBytes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	plan.ApplyFormat(setting_serv.LoadFormatter(ctx, p.settingDao, 0))
+	return plan, nil
+}
+
+// SetMerchantPlan memindahkan merchant ke paket lain. Resource yang sudah melebihi batas paket baru
+// tidak dihapus, namun penambahan resource tersebut akan ditolak
+func (p *planService) SetMerchantPlan(ctx context.Context, request dto.MerchantPlanRequest) (*dto.MerchantUsage, rest_err.APIError) {
+	if _, err := p.dao.Get(ctx, request.PlanID); err != nil {
+		return nil, err
+	}
+	if err := p.dao.SetMerchantPlan(ctx, request.MerchantID, request.PlanID); err != nil {
+		return nil, err
+	}
+	return p.getUsage(ctx, request.MerchantID)
+}
+
+// getUsage pemakaian merchant dengan waktu paket sesuai timezone merchant tersebut
+func (p *planService) getUsage(ctx context.Context, merchantID int) (*dto.MerchantUsage, rest_err.APIError) {
+	usage, err := p.dao.GetUsage(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	usage.ApplyFormat(setting_serv.LoadFormatter(ctx, p.settingDao, merchantID))
+	return usage, nil
+}
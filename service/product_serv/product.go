@@ -3,10 +3,17 @@ package product_serv
 import (
 	"context"
 	"fmt"
+	"github.com/muchlist/mini_pos/dao/merchant_dao"
+	"github.com/muchlist/mini_pos/dao/plan_dao"
 	"github.com/muchlist/mini_pos/dao/product_dao"
+	"github.com/muchlist/mini_pos/dao/tax_dao"
 	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/service/outlet_serv"
+	"github.com/muchlist/mini_pos/service/setting_serv"
 	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/metrics"
 	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/muchlist/mini_pos/utils/mtax"
 	"github.com/muchlist/mini_pos/utils/rest_err"
 	"time"
 )
@@ -24,19 +31,33 @@ type ProductServiceReader interface {
 type ProductServiceModifier interface {
 	CreateProduct(ctx context.Context, claims mjwt.CustomClaim, product dto.ProductModel) (int, rest_err.APIError)
 	EditProduct(ctx context.Context, claims mjwt.CustomClaim, request dto.ProductEditRequest) (*dto.ProductModel, rest_err.APIError)
-	DeleteProduct(ctx context.Context, claims mjwt.CustomClaim, productID int) rest_err.APIError
+	DeleteProduct(ctx context.Context, claims mjwt.CustomClaim, productID int) (string, rest_err.APIError)
 	SetCustomPrice(ctx context.Context, claims mjwt.CustomClaim, price dto.ProductPriceRequest) (*dto.ProductModel, rest_err.APIError)
-	SetImagePath(ctx context.Context, productID int, path string) (*dto.ProductModel, rest_err.APIError)
+	SetImagePath(ctx context.Context, claims mjwt.CustomClaim, productID int, path string, size int64) (*dto.ProductModel, string, rest_err.APIError)
+	CheckImageQuota(ctx context.Context, claims mjwt.CustomClaim, productID int, size int64) rest_err.APIError
 }
 
-func NewProductService(dao product_dao.ProductDaoAssumer) ProductServiceAssumer {
+func NewProductService(
+	dao product_dao.ProductDaoAssumer,
+	taxDao tax_dao.TaxLoader,
+	settingDao merchant_dao.MerchantSettingLoader,
+	planDao plan_dao.PlanLoader,
+	outletAccess outlet_serv.OutletServiceAccess) ProductServiceAssumer {
 	return &productService{
-		dao: dao,
+		dao:          dao,
+		taxDao:       taxDao,
+		settingDao:   settingDao,
+		planDao:      planDao,
+		outletAccess: outletAccess,
 	}
 }
 
 type productService struct {
-	dao product_dao.ProductDaoAssumer
+	dao          product_dao.ProductDaoAssumer
+	taxDao       tax_dao.TaxLoader
+	settingDao   merchant_dao.MerchantSettingLoader
+	planDao      plan_dao.PlanLoader
+	outletAccess outlet_serv.OutletServiceAccess
 }
 
 // CreateProduct melakukan register product oleh akun owner
@@ -47,10 +68,23 @@ func (u *productService) CreateProduct(ctx context.Context, claims mjwt.CustomCl
 	product.UpdatedAt = timeNow
 	product.MerchantID = claims.Merchant // merchant ID adalah sama dengan merchant id owner
 
+	if err := u.validateTax(ctx, claims.Merchant, product.TaxID); err != nil {
+		return 0, err
+	}
+
+	usage, err := u.planDao.GetUsage(ctx, claims.Merchant)
+	if err != nil {
+		return 0, err
+	}
+	if errQuota := usage.CheckQuota(dto.QuotaProduct, 1); errQuota != nil {
+		return 0, rest_err.NewQuotaExceededError(errQuota.Error())
+	}
+
 	productID, err := u.dao.Insert(ctx, product)
 	if err != nil {
 		return 0, err
 	}
+	metrics.ProductCreated(claims.Merchant)
 	return productID, nil
 }
 
@@ -63,33 +97,58 @@ func (u *productService) EditProduct(ctx context.Context, claims mjwt.CustomClai
 		Name:            dto.UppercaseString(request.Name),
 		MasterBuyPrice:  request.MasterBuyPrice,
 		MasterSellPrice: request.MasterSellPrice,
+		TaxID:           request.TaxID,
+	}
+
+	if err := u.validateTax(ctx, claims.Merchant, request.TaxID); err != nil {
+		return nil, err
 	}
 
 	result, err := u.dao.Edit(ctx, editParams)
 	if err != nil {
 		return nil, err
 	}
-	return result, nil
+	return u.applyPriceDetailSingle(ctx, result), nil
 }
 
-// EditProduct
-func (u *productService) SetImagePath(ctx context.Context, productID int, path string) (*dto.ProductModel, rest_err.APIError) {
-	result, err := u.dao.SetImagePath(ctx, productID, path)
+// SetImagePath menyimpan path dan ukuran gambar product, kuota penyimpanan diperiksa ulang secara atomic.
+// Mengembalikan path gambar sebelumnya yang dapat dihapus pemanggil
+func (u *productService) SetImagePath(ctx context.Context, claims mjwt.CustomClaim, productID int, path string, size int64) (*dto.ProductModel, string, rest_err.APIError) {
+	result, oldPath, err := u.dao.SetImagePath(ctx, productID, claims.Merchant, path, size)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	return result, nil
+	return u.applyPriceDetailSingle(ctx, result), oldPath, nil
 }
 
-// DeleteProduct
-func (u *productService) DeleteProduct(ctx context.Context, claims mjwt.CustomClaim, productID int) rest_err.APIError {
-	err := u.dao.Delete(ctx, productID, claims.Merchant)
+// CheckImageQuota memastikan gambar baru berukuran size byte masih muat pada kuota penyimpanan merchant,
+// ukuran gambar lama pada product yang sama tidak dihitung karena akan diganti. Dipakai untuk menolak
+// upload lebih awal, batas sebenarnya ditegakkan kembali oleh SetImagePath
+func (u *productService) CheckImageQuota(ctx context.Context, claims mjwt.CustomClaim, productID int, size int64) rest_err.APIError {
+	oldSize, err := u.dao.GetImageSize(ctx, productID, claims.Merchant)
 	if err != nil {
 		return err
 	}
+
+	usage, err := u.planDao.GetUsage(ctx, claims.Merchant)
+	if err != nil {
+		return err
+	}
+	if errQuota := usage.CheckQuota(dto.QuotaImage, size-oldSize); errQuota != nil {
+		return rest_err.NewQuotaExceededError(errQuota.Error())
+	}
 	return nil
 }
 
+// DeleteProduct menghapus product dan mengembalikan path gambar product yang perlu dihapus dari penyimpanan
+func (u *productService) DeleteProduct(ctx context.Context, claims mjwt.CustomClaim, productID int) (string, rest_err.APIError) {
+	imagePath, err := u.dao.Delete(ctx, productID, claims.Merchant)
+	if err != nil {
+		return "", err
+	}
+	return imagePath, nil
+}
+
 // CreateProduct melakukan register product oleh akun owner
 func (u *productService) SetCustomPrice(ctx context.Context, claims mjwt.CustomClaim, price dto.ProductPriceRequest) (*dto.ProductModel, rest_err.APIError) {
 
@@ -103,6 +162,9 @@ func (u *productService) SetCustomPrice(ctx context.Context, claims mjwt.CustomC
 	if product.MerchantID != claims.Merchant {
 		return nil, rest_err.NewBadRequestError("User tidak memeiliki hak akses untuk merubah harga product ini")
 	}
+	if err := u.outletAccess.CheckOutletAccess(ctx, claims, price.OutletID); err != nil {
+		return nil, err
+	}
 
 	// generate ID dari product id dan outletID
 	idGenerated := fmt.Sprintf("%d-%d", price.OutletID, price.ProductID)
@@ -134,14 +196,21 @@ func (u *productService) SetCustomPrice(ctx context.Context, claims mjwt.CustomC
 	if err != nil {
 		return nil, err
 	}
-	return productResult, nil
+	return u.applyPriceDetailSingle(ctx, productResult), nil
 }
 
 // GetProductByID mendapatkan product dari database
 func (u *productService) Get(ctx context.Context, claims mjwt.CustomClaim, productID int, outletID int) (*dto.ProductModel, rest_err.APIError) {
 	var product *dto.ProductModel
 	var err rest_err.APIError
+	if claims.OutletBound() {
+		// token perangkat kasir dan api key terbatas outlet hanya berlaku untuk satu outlet
+		outletID = claims.Outlet
+	}
 	if outletID != 0 {
+		if err := u.outletAccess.CheckOutletAccess(ctx, claims, outletID); err != nil {
+			return nil, err
+		}
 		// tampilkan harga dengan outlet spesifik
 		product, err = u.dao.GetWithCustomPriceOutlet(ctx, productID, outletID)
 	} else {
@@ -151,7 +220,7 @@ func (u *productService) Get(ctx context.Context, claims mjwt.CustomClaim, produ
 		return nil, err
 	}
 
-	return product, nil
+	return u.applyPriceDetailSingle(ctx, product), nil
 }
 
 type FindProductsParams struct {
@@ -163,6 +232,15 @@ type FindProductsParams struct {
 
 // FindProducts
 func (u *productService) FindProducts(ctx context.Context, claims mjwt.CustomClaim, params FindProductsParams) ([]dto.ProductModel, rest_err.APIError) {
+	if claims.OutletBound() {
+		// token perangkat kasir dan api key terbatas outlet hanya berlaku untuk satu outlet
+		params.OutletSpecific = claims.Outlet
+	}
+	if params.OutletSpecific != 0 {
+		if err := u.outletAccess.CheckOutletAccess(ctx, claims, params.OutletSpecific); err != nil {
+			return nil, err
+		}
+	}
 	productList, err := u.dao.FindWithPagination(ctx, product_dao.FindParams{
 		Search: params.Search,
 		Limit:  params.Limit,
@@ -175,7 +253,7 @@ func (u *productService) FindProducts(ctx context.Context, claims mjwt.CustomCla
 	if params.OutletSpecific != 0 {
 		customPrices, err := u.dao.FindCustomPriceOutlet(ctx, params.OutletSpecific)
 		if err != nil {
-			logger.Info("Custom Price gagal didapatkan")
+			logger.InfoCtx(ctx, "Custom Price gagal didapatkan")
 		}
 		if len(customPrices) != 0 {
 			priceMap := make(map[int]dto.ProductPriceModel)
@@ -192,5 +270,56 @@ func (u *productService) FindProducts(ctx context.Context, claims mjwt.CustomCla
 		}
 	}
 
+	u.applyPriceDetail(ctx, claims.Merchant, productList)
+
 	return productList, nil
 }
+
+// validateTax memastikan tax yang dipasang pada product berasal dari merchant yang sama, 0 berarti bebas pajak
+func (u *productService) validateTax(ctx context.Context, merchantID int, taxID int) rest_err.APIError {
+	if taxID == 0 {
+		return nil
+	}
+	if _, err := u.taxDao.Get(ctx, taxID, merchantID); err != nil {
+		return rest_err.NewBadRequestError(fmt.Sprintf("Tax dengan id %d tidak tersedia", taxID))
+	}
+	return nil
+}
+
+func (u *productService) applyPriceDetailSingle(ctx context.Context, product *dto.ProductModel) *dto.ProductModel {
+	products := []dto.ProductModel{*product}
+	u.applyPriceDetail(ctx, product.MerchantID, products)
+	return &products[0]
+}
+
+// applyPriceDetail mengisi harga net, pajak dan gross berdasarkan sell price (sudah sesuai outlet)
+// dan pengaturan pajak merchant, kemudian memformat harga dan waktu sesuai pengaturan merchant
+func (u *productService) applyPriceDetail(ctx context.Context, merchantID int, products []dto.ProductModel) {
+	setting, err := u.taxDao.GetSetting(ctx, merchantID)
+	if err != nil {
+		logger.InfoCtx(ctx, "Tax setting gagal didapatkan")
+		setting = &dto.TaxSetting{MerchantID: merchantID, Inclusive: false, Rounding: mtax.RoundHalfUp}
+	}
+
+	taxes, err := u.taxDao.FindByMerchant(ctx, merchantID)
+	if err != nil {
+		logger.InfoCtx(ctx, "Tax gagal didapatkan")
+	}
+	rateMap := make(map[int]int)
+	for _, tax := range taxes {
+		rateMap[tax.ID] = tax.Rate
+	}
+
+	formatter := setting_serv.LoadFormatter(ctx, u.settingDao, merchantID)
+
+	for i, product := range products {
+		rate := rateMap[product.TaxID]
+		breakdown := mtax.Calculate(product.SellPrice, rate, setting.Inclusive, setting.Rounding)
+		products[i].TaxRate = rate
+		products[i].SellPriceNet = breakdown.Net
+		products[i].SellPriceTax = breakdown.Tax
+		products[i].SellPriceGross = breakdown.Gross
+		products[i].CashPrice = formatter.RoundCash(breakdown.Gross)
+		products[i].ApplyFormat(formatter)
+	}
+}
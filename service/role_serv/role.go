@@ -0,0 +1,130 @@
+package role_serv
+
+import (
+	"context"
+	"fmt"
+	"github.com/muchlist/mini_pos/configs/permissions"
+	"github.com/muchlist/mini_pos/dao/merchant_dao"
+	"github.com/muchlist/mini_pos/dao/role_dao"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/service/setting_serv"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"strings"
+	"time"
+)
+
+type RoleServiceAssumer interface {
+	RoleServiceModifier
+	RoleServiceReader
+}
+
+type RoleServiceReader interface {
+	FindRoles(ctx context.Context, claims mjwt.CustomClaim) ([]dto.RoleModel, rest_err.APIError)
+	FindPermissions() []string
+}
+
+type RoleServiceModifier interface {
+	CreateRole(ctx context.Context, claims mjwt.CustomClaim, request dto.RoleRequest) (int, rest_err.APIError)
+	EditRole(ctx context.Context, claims mjwt.CustomClaim, roleID int, request dto.RoleRequest) (*dto.RoleModel, rest_err.APIError)
+	DeleteRole(ctx context.Context, claims mjwt.CustomClaim, roleID int) rest_err.APIError
+}
+
+func NewRoleService(dao role_dao.RoleDaoAssumer, settingDao merchant_dao.MerchantSettingLoader) RoleServiceAssumer {
+	return &roleService{
+		dao:        dao,
+		settingDao: settingDao,
+	}
+}
+
+type roleService struct {
+	dao        role_dao.RoleDaoAssumer
+	settingDao merchant_dao.MerchantSettingLoader
+}
+
+// CreateRole membuat custom role untuk merchant pembuat
+func (r *roleService) CreateRole(ctx context.Context, claims mjwt.CustomClaim, request dto.RoleRequest) (int, rest_err.APIError) {
+	perms := uniquePermissions(request.Permissions)
+	if err := checkGrantable(claims, perms); err != nil {
+		return 0, err
+	}
+
+	roleID, err := r.dao.Insert(ctx, dto.RoleModel{
+		MerchantID:  claims.Merchant,
+		Name:        strings.ToLower(request.Name),
+		Permissions: perms,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return roleID, nil
+}
+
+// EditRole perubahan permission berlaku pada user ketika access token diperbarui (login atau refresh)
+func (r *roleService) EditRole(ctx context.Context, claims mjwt.CustomClaim, roleID int, request dto.RoleRequest) (*dto.RoleModel, rest_err.APIError) {
+	perms := uniquePermissions(request.Permissions)
+	if err := checkGrantable(claims, perms); err != nil {
+		return nil, err
+	}
+
+	result, err := r.dao.Edit(ctx, dto.RoleEditModel{
+		WhereID:         roleID,
+		WhereMerchantID: claims.Merchant, // <--- role bawaan dan role merchant lain tidak dapat diedit
+		Name:            strings.ToLower(request.Name),
+		Permissions:     perms,
+		UpdatedAt:       time.Now().Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.ApplyFormat(setting_serv.LoadFormatter(ctx, r.settingDao, claims.Merchant))
+	return result, nil
+}
+
+// DeleteRole user yang memakai role ini kembali memakai role bawaan
+func (r *roleService) DeleteRole(ctx context.Context, claims mjwt.CustomClaim, roleID int) rest_err.APIError {
+	return r.dao.Delete(ctx, roleID, claims.Merchant)
+}
+
+// FindRoles menampilkan role bawaan dan custom role milik merchant
+func (r *roleService) FindRoles(ctx context.Context, claims mjwt.CustomClaim) ([]dto.RoleModel, rest_err.APIError) {
+	roleList, err := r.dao.FindByMerchant(ctx, claims.Merchant)
+	if err != nil {
+		return nil, err
+	}
+	formatter := setting_serv.LoadFormatter(ctx, r.settingDao, claims.Merchant)
+	for i := range roleList {
+		roleList[i].ApplyFormat(formatter)
+	}
+	return roleList, nil
+}
+
+// FindPermissions daftar permission yang dapat dipakai pada custom role
+func (r *roleService) FindPermissions() []string {
+	return permissions.GetPermissionsAvailable()
+}
+
+// checkGrantable memastikan role tidak berisi permission yang tidak dimiliki pembuat,
+// tanpa ini pemegang role.manage dapat membuat role dengan user.manage lalu memberikannya kepada dirinya sendiri
+func checkGrantable(claims mjwt.CustomClaim, perms []string) rest_err.APIError {
+	for _, p := range perms {
+		if !claims.Can(p) {
+			return rest_err.NewForbiddenError(fmt.Sprintf("Tidak dapat memberikan permission %s yang tidak dimiliki pembuat", p))
+		}
+	}
+	return nil
+}
+
+// uniquePermissions membuang permission ganda dengan urutan tetap
+func uniquePermissions(perms []string) []string {
+	seen := make(map[string]bool, len(perms))
+	res := make([]string, 0, len(perms))
+	for _, p := range perms {
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		res = append(res, p)
+	}
+	return res
+}
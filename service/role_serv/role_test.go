@@ -0,0 +1,77 @@
+package role_serv
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/muchlist/mini_pos/configs/permissions"
+	"github.com/muchlist/mini_pos/dao/role_dao"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/stretchr/testify/assert"
+)
+
+// roleDaoMock mencatat role yang disimpan, method lain memanggil interface nil dan panic
+type roleDaoMock struct {
+	role_dao.RoleDaoAssumer
+	saved []dto.RoleModel
+}
+
+func (m *roleDaoMock) Insert(_ context.Context, input dto.RoleModel) (int, rest_err.APIError) {
+	m.saved = append(m.saved, input)
+	return len(m.saved), nil
+}
+
+func (m *roleDaoMock) Edit(_ context.Context, input dto.RoleEditModel) (*dto.RoleModel, rest_err.APIError) {
+	role := dto.RoleModel{ID: input.WhereID, MerchantID: input.WhereMerchantID, Name: input.Name, Permissions: input.Permissions}
+	m.saved = append(m.saved, role)
+	return &role, nil
+}
+
+type settingDaoMock struct{}
+
+func (settingDaoMock) GetSetting(_ context.Context, merchantID int) (*dto.MerchantSetting, rest_err.APIError) {
+	setting := dto.DefaultMerchantSetting(merchantID)
+	return &setting, nil
+}
+
+func TestRolePermissionsLimitedToCaller(t *testing.T) {
+	roleManager := mjwt.CustomClaim{Identity: 2, Merchant: 1, Permissions: []string{permissions.RoleManage, permissions.ProductEdit}}
+	owner := mjwt.CustomClaim{Identity: 1, Merchant: 1, Permissions: []string{permissions.All}}
+
+	tests := []struct {
+		name   string
+		claims mjwt.CustomClaim
+		perms  []string
+		status int
+	}{
+		{name: "role.manage membuat role berisi user.manage", claims: roleManager, perms: []string{permissions.ProductEdit, permissions.UserManage}, status: http.StatusForbidden},
+		{name: "role.manage membuat role berisi apikey.manage", claims: roleManager, perms: []string{permissions.APIKeyManage}, status: http.StatusForbidden},
+		{name: "role.manage membuat role dengan permission yang dimiliki", claims: roleManager, perms: []string{permissions.ProductEdit}},
+		{name: "owner membuat role berisi user.manage", claims: owner, perms: []string{permissions.UserManage, permissions.SettingEdit}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dao := &roleDaoMock{}
+			service := NewRoleService(dao, settingDaoMock{})
+			request := dto.RoleRequest{Name: "Supervisor", Permissions: tc.perms}
+
+			_, errCreate := service.CreateRole(context.Background(), tc.claims, request)
+			_, errEdit := service.EditRole(context.Background(), tc.claims, 10, request)
+			if tc.status == 0 {
+				assert.Nil(t, errCreate)
+				assert.Nil(t, errEdit)
+				assert.Equal(t, 2, len(dao.saved))
+				return
+			}
+			if assert.NotNil(t, errCreate) && assert.NotNil(t, errEdit) {
+				assert.Equal(t, tc.status, errCreate.Status())
+				assert.Equal(t, tc.status, errEdit.Status())
+			}
+			assert.Equal(t, 0, len(dao.saved))
+		})
+	}
+}
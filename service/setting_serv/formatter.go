@@ -0,0 +1,51 @@
+package setting_serv
+
+import (
+	"context"
+	"github.com/muchlist/mini_pos/dao/merchant_dao"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/mformat"
+)
+
+// Formatters memuat formatter uang dan waktu sesuai pengaturan merchant untuk mengisi field teks response.
+// Dibuat per request, formatter disimpan per merchant sehingga daftar lintas merchant cukup membaca
+// pengaturan sekali untuk setiap merchant
+type Formatters struct {
+	dao   merchant_dao.MerchantSettingLoader
+	cache map[int]mformat.Formatter
+}
+
+func NewFormatters(dao merchant_dao.MerchantSettingLoader) *Formatters {
+	return &Formatters{
+		dao:   dao,
+		cache: make(map[int]mformat.Formatter),
+	}
+}
+
+// Get merchantID 0 (super user dan data lintas merchant) memakai pengaturan default, pengaturan yang
+// gagal dibaca juga memakai pengaturan default agar response tetap terkirim
+func (f *Formatters) Get(ctx context.Context, merchantID int) mformat.Formatter {
+	if formatter, ok := f.cache[merchantID]; ok {
+		return formatter
+	}
+
+	setting := dto.DefaultMerchantSetting(merchantID)
+	if merchantID != 0 {
+		res, err := f.dao.GetSetting(ctx, merchantID)
+		if err != nil {
+			logger.InfoCtx(ctx, "Merchant setting gagal didapatkan")
+		} else {
+			setting = *res
+		}
+	}
+
+	formatter := setting.Formatter()
+	f.cache[merchantID] = formatter
+	return formatter
+}
+
+// LoadFormatter formatter untuk satu merchant
+func LoadFormatter(ctx context.Context, dao merchant_dao.MerchantSettingLoader, merchantID int) mformat.Formatter {
+	return NewFormatters(dao).Get(ctx, merchantID)
+}
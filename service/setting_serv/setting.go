@@ -0,0 +1,65 @@
+package setting_serv
+
+import (
+	"context"
+	"github.com/muchlist/mini_pos/dao/merchant_dao"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"strings"
+)
+
+type SettingServiceAssumer interface {
+	GetSetting(ctx context.Context, claims mjwt.CustomClaim) (*dto.MerchantSetting, rest_err.APIError)
+	EditSetting(ctx context.Context, claims mjwt.CustomClaim, request dto.MerchantSettingRequest) (*dto.MerchantSetting, rest_err.APIError)
+	SetLogoPath(ctx context.Context, claims mjwt.CustomClaim, path string) (*dto.MerchantSetting, rest_err.APIError)
+}
+
+func NewSettingService(dao merchant_dao.MerchantDaoAssumer) SettingServiceAssumer {
+	return &settingService{
+		dao: dao,
+	}
+}
+
+type settingService struct {
+	dao merchant_dao.MerchantDaoAssumer
+}
+
+// GetSetting mendapatkan pengaturan merchant milik user yang login
+func (s *settingService) GetSetting(ctx context.Context, claims mjwt.CustomClaim) (*dto.MerchantSetting, rest_err.APIError) {
+	setting, err := s.dao.GetSetting(ctx, claims.Merchant)
+	if err != nil {
+		return nil, err
+	}
+	setting.ApplyFormat(setting.Formatter())
+	return setting, nil
+}
+
+// EditSetting merubah pengaturan mata uang, timezone, pembulatan dan struk
+func (s *settingService) EditSetting(ctx context.Context, claims mjwt.CustomClaim, request dto.MerchantSettingRequest) (*dto.MerchantSetting, rest_err.APIError) {
+	setting, err := s.dao.UpsertSetting(ctx, dto.MerchantSetting{
+		MerchantID:      claims.Merchant, // <--- pengaturan yang diubah adalah milik merchant pengedit
+		Currency:        strings.ToUpper(request.Currency),
+		MinorUnit:       request.MinorUnit,
+		Timezone:        request.Timezone,
+		CashRounding:    request.CashRounding,
+		ReceiptHeader:   request.ReceiptHeader,
+		ReceiptFooter:   request.ReceiptFooter,
+		RequireOwner2FA: request.RequireOwner2FA,
+	})
+	if err != nil {
+		return nil, err
+	}
+	setting.ApplyFormat(setting.Formatter())
+	return setting, nil
+}
+
+// SetLogoPath menyimpan path logo yang sudah diupload
+func (s *settingService) SetLogoPath(ctx context.Context, claims mjwt.CustomClaim, path string) (*dto.MerchantSetting, rest_err.APIError) {
+	setting, err := s.dao.SetLogo(ctx, claims.Merchant, path)
+	if err != nil {
+		return nil, err
+	}
+	setting.ApplyFormat(setting.Formatter())
+	return setting, nil
+}
@@ -0,0 +1,109 @@
+package signup_serv
+
+import (
+	"context"
+	"fmt"
+	"github.com/muchlist/mini_pos/dao/signup_dao"
+	"github.com/muchlist/mini_pos/dao/user_dao"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/mailer"
+	"github.com/muchlist/mini_pos/utils/mcrypt"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"strings"
+	"time"
+)
+
+const (
+	expiredSignupToken = 24 // 24 hour
+	defaultOutletName  = "OUTLET UTAMA"
+)
+
+type SignupServiceAssumer interface {
+	Signup(ctx context.Context, request dto.SignupRequest) (string, rest_err.APIError)
+	Verify(ctx context.Context, request dto.SignupVerifyRequest) (*dto.MerchantCreateRes, rest_err.APIError)
+}
+
+// NewSignupService appURL dipakai untuk membuat link verifikasi pada email, contoh http://localhost:3000
+func NewSignupService(
+	dao signup_dao.SignupDaoAssumer,
+	userDao user_dao.UserReader,
+	crypto mcrypt.BcryptAssumer,
+	mail mailer.Mailer,
+	appURL string) SignupServiceAssumer {
+	return &signupService{
+		dao:     dao,
+		userDao: userDao,
+		crypto:  crypto,
+		mail:    mail,
+		appURL:  strings.TrimRight(appURL, "/"),
+	}
+}
+
+type signupService struct {
+	dao     signup_dao.SignupDaoAssumer
+	userDao user_dao.UserReader
+	crypto  mcrypt.BcryptAssumer
+	mail    mailer.Mailer
+	appURL  string
+}
+
+// Signup menyimpan pendaftaran merchant dan mengirim link verifikasi ke email owner,
+// merchant belum dibuat sampai owner melakukan verifikasi dan mengisi password.
+// Response selalu sama baik email sudah terdaftar atau belum agar tidak dapat dipakai untuk menebak email user
+func (s *signupService) Signup(ctx context.Context, request dto.SignupRequest) (string, rest_err.APIError) {
+	email := strings.ToLower(request.OwnerEmail)
+	response := fmt.Sprintf("apabila email tersedia, link verifikasi telah dikirim ke %s", email)
+
+	// email yang sudah terdaftar sebagai user tidak dapat dipakai lagi, pendaftaran tidak disimpan dan email tidak dikirim
+	if user, _ := s.userDao.GetByEmail(ctx, email); user != nil {
+		logger.InfoCtx(ctx, fmt.Sprintf("signup diabaikan, email %s sudah terdaftar", email))
+		return response, nil
+	}
+
+	token, tokenHash, err := mcrypt.GenerateToken()
+	if err != nil {
+		return "", err
+	}
+
+	timeNow := time.Now()
+	_, err = s.dao.Insert(ctx, dto.MerchantSignup{
+		MerchantName: strings.ToUpper(request.MerchantName),
+		Description:  request.Description,
+		OwnerName:    strings.ToUpper(request.OwnerName),
+		OwnerEmail:   email,
+		TokenHash:    tokenHash,
+		CreatedAt:    timeNow.Unix(),
+		ExpiredAt:    timeNow.Add(time.Hour * expiredSignupToken).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	errSend := s.mail.Send(ctx, mailer.Message{
+		To:      email,
+		Subject: "Verifikasi pendaftaran merchant",
+		Body: fmt.Sprintf("Halo %s,\n\nUntuk mengaktifkan merchant %s silahkan buka link berikut dan buat password anda :\n%s/signup/verify?token=%s\n\nLink berlaku selama %d jam.",
+			request.OwnerName, request.MerchantName, s.appURL, token, expiredSignupToken),
+	})
+	if errSend != nil {
+		logger.ErrorCtx(ctx, "error saat mengirim email verifikasi signup (Signup:0)", errSend)
+		return "", rest_err.NewInternalServerError("gagal mengirim email verifikasi", errSend)
+	}
+
+	return response, nil
+}
+
+// Verify mengaktifkan merchant beserta outlet pertama dan owner dengan password yang dibuat sendiri
+func (s *signupService) Verify(ctx context.Context, request dto.SignupVerifyRequest) (*dto.MerchantCreateRes, rest_err.APIError) {
+	hashPw, err := s.crypto.GenerateHash(request.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.dao.Activate(ctx, dto.SignupActivation{
+		TokenHash:  mcrypt.HashToken(request.Token),
+		Password:   hashPw,
+		OutletName: defaultOutletName,
+	})
+}
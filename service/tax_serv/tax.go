@@ -0,0 +1,125 @@
+package tax_serv
+
+import (
+	"context"
+	"github.com/muchlist/mini_pos/dao/merchant_dao"
+	"github.com/muchlist/mini_pos/dao/tax_dao"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/service/setting_serv"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"time"
+)
+
+type TaxServiceAssumer interface {
+	TaxServiceModifier
+	TaxServiceReader
+}
+
+type TaxServiceReader interface {
+	GetTaxByID(ctx context.Context, claims mjwt.CustomClaim, taxID int) (*dto.TaxModel, rest_err.APIError)
+	FindTaxes(ctx context.Context, claims mjwt.CustomClaim) ([]dto.TaxModel, rest_err.APIError)
+	GetSetting(ctx context.Context, claims mjwt.CustomClaim) (*dto.TaxSetting, rest_err.APIError)
+}
+
+type TaxServiceModifier interface {
+	CreateTax(ctx context.Context, claims mjwt.CustomClaim, tax dto.TaxModel) (int, rest_err.APIError)
+	EditTax(ctx context.Context, claims mjwt.CustomClaim, request dto.TaxEditRequest) (*dto.TaxModel, rest_err.APIError)
+	DeleteTax(ctx context.Context, claims mjwt.CustomClaim, taxID int) rest_err.APIError
+	EditSetting(ctx context.Context, claims mjwt.CustomClaim, request dto.TaxSettingRequest) (*dto.TaxSetting, rest_err.APIError)
+}
+
+func NewTaxService(dao tax_dao.TaxDaoAssumer, settingDao merchant_dao.MerchantSettingLoader) TaxServiceAssumer {
+	return &taxService{
+		dao:        dao,
+		settingDao: settingDao,
+	}
+}
+
+type taxService struct {
+	dao        tax_dao.TaxDaoAssumer
+	settingDao merchant_dao.MerchantSettingLoader
+}
+
+// CreateTax menambahkan tarif pajak untuk merchant owner
+func (t *taxService) CreateTax(ctx context.Context, claims mjwt.CustomClaim, tax dto.TaxModel) (int, rest_err.APIError) {
+	tax.MerchantID = claims.Merchant // merchant ID adalah sama dengan merchant id owner
+
+	taxID, err := t.dao.Insert(ctx, tax)
+	if err != nil {
+		return 0, err
+	}
+	return taxID, nil
+}
+
+// EditTax
+func (t *taxService) EditTax(ctx context.Context, claims mjwt.CustomClaim, request dto.TaxEditRequest) (*dto.TaxModel, rest_err.APIError) {
+	editParams := dto.TaxEditModel{
+		WhereID:         request.ID,
+		WhereMerchantID: claims.Merchant, // <--- tax yang diedit harus memiliki merchant id yang sama dengan pengedit
+		Name:            dto.UppercaseString(request.Name),
+		Rate:            request.Rate,
+		UpdatedAt:       time.Now().Unix(),
+	}
+
+	result, err := t.dao.Edit(ctx, editParams)
+	if err != nil {
+		return nil, err
+	}
+	result.ApplyFormat(setting_serv.LoadFormatter(ctx, t.settingDao, claims.Merchant))
+	return result, nil
+}
+
+// DeleteTax product yang memakai tax ini akan menjadi bebas pajak
+func (t *taxService) DeleteTax(ctx context.Context, claims mjwt.CustomClaim, taxID int) rest_err.APIError {
+	err := t.dao.Delete(ctx, taxID, claims.Merchant)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetTaxByID
+func (t *taxService) GetTaxByID(ctx context.Context, claims mjwt.CustomClaim, taxID int) (*dto.TaxModel, rest_err.APIError) {
+	tax, err := t.dao.Get(ctx, taxID, claims.Merchant)
+	if err != nil {
+		return nil, err
+	}
+	tax.ApplyFormat(setting_serv.LoadFormatter(ctx, t.settingDao, claims.Merchant))
+	return tax, nil
+}
+
+// FindTaxes
+func (t *taxService) FindTaxes(ctx context.Context, claims mjwt.CustomClaim) ([]dto.TaxModel, rest_err.APIError) {
+	taxList, err := t.dao.FindByMerchant(ctx, claims.Merchant)
+	if err != nil {
+		return nil, err
+	}
+	formatter := setting_serv.LoadFormatter(ctx, t.settingDao, claims.Merchant)
+	for i := range taxList {
+		taxList[i].ApplyFormat(formatter)
+	}
+	return taxList, nil
+}
+
+// GetSetting mendapatkan pengaturan inclusive/exclusive dan pembulatan pajak merchant
+func (t *taxService) GetSetting(ctx context.Context, claims mjwt.CustomClaim) (*dto.TaxSetting, rest_err.APIError) {
+	setting, err := t.dao.GetSetting(ctx, claims.Merchant)
+	if err != nil {
+		return nil, err
+	}
+	return setting, nil
+}
+
+// EditSetting
+func (t *taxService) EditSetting(ctx context.Context, claims mjwt.CustomClaim, request dto.TaxSettingRequest) (*dto.TaxSetting, rest_err.APIError) {
+	setting, err := t.dao.EditSetting(ctx, dto.TaxSetting{
+		MerchantID: claims.Merchant,
+		Inclusive:  request.Inclusive,
+		Rounding:   request.Rounding,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return setting, nil
+}
@@ -0,0 +1,114 @@
+package user_serv
+
+import (
+	"context"
+	"fmt"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/bruteforce"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/metrics"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"go.uber.org/zap"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	maxLoginFailure   = 5                // jumlah login gagal berturut-turut sebelum akun dikunci
+	loginLockDuration = 15 * time.Minute // lama akun dikunci
+)
+
+type UserServiceLockout interface {
+	UnlockUser(ctx context.Context, claims mjwt.CustomClaim, userID int) rest_err.APIError
+}
+
+// UnlockUser owner membuka kunci akun user pada merchant yang sama
+func (u *userService) UnlockUser(ctx context.Context, claims mjwt.CustomClaim, userID int) rest_err.APIError {
+	user, err := u.dao.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.MerchantID != claims.Merchant {
+		return rest_err.NewBadRequestError(fmt.Sprintf("User dengan id %d tidak ditemukan", userID))
+	}
+
+	if err := u.dao.ResetLoginFailure(ctx, userID, claims.Merchant); err != nil {
+		return err
+	}
+	u.guard.Reset(bruteforce.RuleEmail, strings.ToLower(string(user.Email)))
+	u.guard.Reset(bruteforce.RulePin, fmt.Sprint(user.ID))
+
+	logger.InfoCtx(ctx, "akun dibuka oleh owner",
+		zap.Int("user_id", userID),
+		zap.Int("owner_id", claims.Identity),
+		zap.Int("merchant_id", claims.Merchant))
+	return nil
+}
+
+// checkLoginGuard menolak percobaan login apabila email atau ip sedang dalam masa jeda
+func (u *userService) checkLoginGuard(ctx context.Context, email string, ip string) rest_err.APIError {
+	if wait, blocked := u.guard.Check(bruteforce.RuleEmail, email); blocked {
+		logger.WarnCtx(ctx, "login ditolak, email dalam masa jeda", zap.String("email", email), zap.String("ip", ip))
+		return tooManyAttemptErr(wait)
+	}
+	if wait, blocked := u.guard.Check(bruteforce.RuleIP, ip); blocked {
+		logger.WarnCtx(ctx, "login ditolak, ip dalam masa jeda", zap.String("email", email), zap.String("ip", ip))
+		return tooManyAttemptErr(wait)
+	}
+	return nil
+}
+
+// checkLocked menolak login untuk akun yang sedang dikunci
+func checkLocked(ctx context.Context, user dto.UserModel) rest_err.APIError {
+	wait := time.Until(time.Unix(user.LockedUntil, 0))
+	if wait <= 0 {
+		return nil
+	}
+	logger.WarnCtx(ctx, "login ditolak, akun terkunci", zap.Int("user_id", user.ID))
+	return rest_err.NewTooManyRequestsError(fmt.Sprintf("Akun dikunci sementara karena terlalu banyak login gagal, coba lagi dalam %d menit atau hubungi owner", int(math.Ceil(wait.Minutes()))))
+}
+
+// loginFailed mencatat login gagal pada guard dan pada akun apabila user ditemukan
+func (u *userService) loginFailed(ctx context.Context, user *dto.UserModel, email string, ip string) {
+	emailFailures, _ := u.guard.Fail(bruteforce.RuleEmail, email)
+	ipFailures, ipDelay := u.guard.Fail(bruteforce.RuleIP, ip)
+	if ipDelay > 0 {
+		logger.WarnCtx(ctx, "ip diblokir sementara karena login gagal berulang",
+			zap.String("ip", ip), zap.Int("failures", ipFailures), zap.Duration("delay", ipDelay))
+	}
+
+	fields := []zap.Field{zap.String("email", email), zap.String("ip", ip), zap.Int("email_failures", emailFailures)}
+	if user == nil {
+		metrics.Login(0, metrics.LoginUnknownEmail)
+		logger.WarnCtx(ctx, "login gagal, email tidak terdaftar", fields...)
+		return
+	}
+	metrics.Login(user.MerchantID, metrics.LoginWrongPassword)
+
+	failures, lockedUntil, err := u.dao.RecordLoginFailure(ctx, user.ID, maxLoginFailure, time.Now().Add(loginLockDuration).Unix())
+	if err != nil {
+		return
+	}
+	fields = append(fields, zap.Int("user_id", user.ID), zap.Int("account_failures", failures))
+	if failures >= maxLoginFailure && lockedUntil > time.Now().Unix() {
+		logger.WarnCtx(ctx, "akun dikunci karena login gagal berulang", append(fields, zap.Int64("locked_until", lockedUntil))...)
+		return
+	}
+	logger.WarnCtx(ctx, "login gagal", fields...)
+}
+
+// loginSucceeded menghapus catatan kegagalan setelah login lengkap berhasil, catatan ip
+// tidak dihapus agar penyerang tidak dapat mereset jeda dengan akun miliknya sendiri
+func (u *userService) loginSucceeded(ctx context.Context, user dto.UserModel) {
+	metrics.Login(user.MerchantID, metrics.LoginSuccess)
+	u.guard.Reset(bruteforce.RuleEmail, strings.ToLower(string(user.Email)))
+	if user.FailedLogin > 0 {
+		_ = u.dao.ResetLoginFailure(ctx, user.ID, 0)
+	}
+}
+
+func tooManyAttemptErr(wait time.Duration) rest_err.APIError {
+	return rest_err.NewTooManyRequestsError(fmt.Sprintf("Terlalu banyak percobaan login, coba lagi dalam %d detik", int(math.Ceil(wait.Seconds()))))
+}
@@ -0,0 +1,297 @@
+package user_serv
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/muchlist/mini_pos/configs"
+	"github.com/muchlist/mini_pos/configs/permissions"
+	"github.com/muchlist/mini_pos/configs/roles"
+	"github.com/muchlist/mini_pos/dao/merchant_dao"
+	"github.com/muchlist/mini_pos/dao/refresh_token_dao"
+	"github.com/muchlist/mini_pos/dao/role_dao"
+	"github.com/muchlist/mini_pos/dao/session_dao"
+	"github.com/muchlist/mini_pos/dao/totp_dao"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/bruteforce"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cryptoMock hash password berupa "hash:" + password agar test tidak menunggu bcrypt
+type cryptoMock struct{}
+
+func (cryptoMock) GenerateHash(password string) (string, rest_err.APIError) {
+	return "hash:" + password, nil
+}
+
+func (cryptoMock) IsPWAndHashPWMatch(password string, hashPass string) bool {
+	return "hash:"+password == hashPass
+}
+
+// guardMock tidak pernah memberi jeda sehingga penguncian akun dapat diuji
+type guardMock struct{}
+
+func (guardMock) Check(_ bruteforce.Rule, _ string) (time.Duration, bool) { return 0, false }
+func (guardMock) Fail(_ bruteforce.Rule, _ string) (int, time.Duration)   { return 1, 0 }
+func (guardMock) Reset(_ bruteforce.Rule, _ string)                       {}
+
+type roleDaoMock struct {
+	role_dao.RoleLoader
+	roles map[int]dto.RoleModel
+}
+
+func (m roleDaoMock) Get(_ context.Context, id int, merchantFilter int) (*dto.RoleModel, rest_err.APIError) {
+	role, ok := m.roles[id]
+	if !ok || role.MerchantID != merchantFilter {
+		return nil, rest_err.NewNotFoundError("role tidak ditemukan")
+	}
+	return &role, nil
+}
+
+func (roleDaoMock) GetUserPermissions(_ context.Context, _ int) ([]string, rest_err.APIError) {
+	return []string{permissions.All}, nil
+}
+
+type totpDaoMock struct {
+	totp_dao.TotpDaoAssumer
+	enabled map[int]bool
+}
+
+func (m totpDaoMock) Get(_ context.Context, userID int) (*dto.TotpModel, rest_err.APIError) {
+	totp := dto.TotpModel{UserID: userID}
+	if m.enabled[userID] {
+		totp.EnabledAt = 1631341964
+	}
+	return &totp, nil
+}
+
+type settingDaoMock struct {
+	merchant_dao.MerchantSettingLoader
+	requireOwner2FA bool
+}
+
+func (m settingDaoMock) GetSetting(_ context.Context, merchantID int) (*dto.MerchantSetting, rest_err.APIError) {
+	setting := dto.DefaultMerchantSetting(merchantID)
+	setting.RequireOwner2FA = m.requireOwner2FA
+	return &setting, nil
+}
+
+type sessionDaoMock struct {
+	session_dao.SessionDaoAssumer
+	sessions map[string]*dto.SessionModel
+	revoked  map[string]bool
+}
+
+func (m *sessionDaoMock) Insert(_ context.Context, input dto.SessionModel) rest_err.APIError {
+	m.sessions[input.ID] = &input
+	return nil
+}
+
+func (m *sessionDaoMock) Touch(_ context.Context, sessionID string, lastSeenAt int64, expiredAt int64) (int, rest_err.APIError) {
+	session, ok := m.sessions[sessionID]
+	if !ok || m.revoked[sessionID] {
+		return 0, rest_err.NewUnauthorizedError("Sesi sudah berakhir, silahkan login kembali")
+	}
+	session.LastSeenAt = lastSeenAt
+	session.ExpiredAt = expiredAt
+	return session.OutletID, nil
+}
+
+// refreshDaoMock mengikuti kontrak refresh_token_dao.Rotate: token yang sudah dirotasi
+// lalu dipakai kembali mencabut seluruh family beserta sesinya
+type refreshDaoMock struct {
+	refresh_token_dao.RefreshTokenDaoAssumer
+	tokens   map[string]*dto.RefreshTokenModel
+	sessions *sessionDaoMock
+}
+
+func (m *refreshDaoMock) Insert(_ context.Context, input dto.RefreshTokenModel) rest_err.APIError {
+	m.tokens[input.TokenID] = &input
+	return nil
+}
+
+func (m *refreshDaoMock) Rotate(_ context.Context, tokenID string, tokenHash string, next dto.RefreshTokenModel) rest_err.APIError {
+	invalid := rest_err.NewUnauthorizedError("Refresh token tidak valid, silahkan login kembali")
+	current, ok := m.tokens[tokenID]
+	if !ok || current.TokenHash != tokenHash || current.FamilyID != next.FamilyID || current.RevokedAt != 0 {
+		return invalid
+	}
+	timeNow := time.Now().Unix()
+	if current.UsedAt != 0 {
+		for _, token := range m.tokens {
+			if token.FamilyID == current.FamilyID {
+				token.RevokedAt = timeNow
+			}
+		}
+		m.sessions.revoked[current.FamilyID] = true
+		return invalid
+	}
+	current.UsedAt = timeNow
+	m.tokens[next.TokenID] = &next
+	return nil
+}
+
+// loginFixture user service dengan seluruh dao palsu, user 1 owner dan user 2 employee
+type loginFixture struct {
+	service  UserServiceAssumer
+	users    *userDaoMock
+	sessions *sessionDaoMock
+	refresh  *refreshDaoMock
+}
+
+func newLoginFixture(t *testing.T, totp totpDaoMock, setting settingDaoMock) loginFixture {
+	mjwt.Init("rahasia-test", t.TempDir())
+	// login gagal menulis log warning, logger global harus siap sebelum service dipanggil
+	logger.InitLogger(configs.LogConfig{Level: "error", Output: "stdout"})
+
+	users := &userDaoMock{users: map[int]dto.UserModel{
+		1: {ID: 1, MerchantID: 1, DefOutlet: 1, Email: "owner@example.com", Password: "hash:password", Role: roles.RoleOwner},
+		2: {ID: 2, MerchantID: 1, DefOutlet: 1, Email: "kasir@example.com", Password: "hash:password", Role: roles.RoleEmployee},
+	}}
+	sessions := &sessionDaoMock{sessions: map[string]*dto.SessionModel{}, revoked: map[string]bool{}}
+	refresh := &refreshDaoMock{tokens: map[string]*dto.RefreshTokenModel{}, sessions: sessions}
+	lifetime := TokenLifetime{Access: time.Hour, Refresh: 24 * time.Hour, Impersonate: 15 * time.Minute, Challenge: 5 * time.Minute}
+
+	service := NewUserService(users, nil, planDaoMock{}, refresh, sessions, totp, roleDaoMock{}, nil, setting, nil,
+		cryptoMock{}, mjwt.NewJwt(), guardMock{}, lifetime)
+	return loginFixture{service: service, users: users, sessions: sessions, refresh: refresh}
+}
+
+func TestRefreshTokenReuseRevokesFamily(t *testing.T) {
+	f := newLoginFixture(t, totpDaoMock{}, settingDaoMock{})
+
+	login, err := f.service.Login(context.Background(), dto.UserLoginRequest{Email: "kasir@example.com", Password: "password"}, dto.SessionMeta{IP: "10.0.0.1"})
+	require.Nil(t, err)
+	first := login.RefreshToken
+
+	rotated, err := f.service.Refresh(context.Background(), dto.UserRefreshTokenRequest{RefreshToken: first})
+	require.Nil(t, err)
+	assert.NotEqual(t, first, rotated.RefreshToken)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		// token lama dipakai kembali setelah dirotasi, dianggap dicuri
+		{name: "token yang sudah dirotasi", token: first},
+		// token hasil rotasi ikut dicabut karena satu family
+		{name: "token terbaru pada family yang sama", token: rotated.RefreshToken},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := f.service.Refresh(context.Background(), dto.UserRefreshTokenRequest{RefreshToken: tc.token})
+			require.NotNil(t, err)
+			assert.Equal(t, http.StatusUnauthorized, err.Status())
+		})
+	}
+
+	for _, token := range f.refresh.tokens {
+		assert.NotZero(t, token.RevokedAt, "refresh token %s belum dicabut", token.TokenID)
+	}
+	for id := range f.sessions.sessions {
+		assert.True(t, f.sessions.revoked[id], "sesi %s belum dicabut", id)
+	}
+}
+
+func TestLoginTwoFactorChallenge(t *testing.T) {
+	tests := []struct {
+		name      string
+		email     string
+		totp      totpDaoMock
+		setting   settingDaoMock
+		challenge bool
+		setup     bool
+	}{
+		{name: "2fa aktif", email: "kasir@example.com", totp: totpDaoMock{enabled: map[int]bool{2: true}}, challenge: true},
+		{name: "owner diwajibkan 2fa namun belum mengaktifkan", email: "owner@example.com", setting: settingDaoMock{requireOwner2FA: true}, challenge: true, setup: true},
+		{name: "kewajiban 2fa owner tidak berlaku untuk employee", email: "kasir@example.com", setting: settingDaoMock{requireOwner2FA: true}},
+		{name: "tanpa 2fa", email: "owner@example.com"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newLoginFixture(t, tc.totp, tc.setting)
+
+			res, err := f.service.Login(context.Background(), dto.UserLoginRequest{Email: tc.email, Password: "password"}, dto.SessionMeta{IP: "10.0.0.1"})
+			require.Nil(t, err)
+			assert.Equal(t, tc.challenge, res.TwoFactorRequired)
+			assert.Equal(t, tc.setup, res.TwoFactorSetup)
+
+			if tc.challenge {
+				// password saja tidak menghasilkan token maupun sesi
+				assert.NotEmpty(t, res.ChallengeToken)
+				assert.Empty(t, res.AccessToken)
+				assert.Empty(t, res.RefreshToken)
+				assert.Empty(t, f.sessions.sessions)
+				assert.Empty(t, f.refresh.tokens)
+				return
+			}
+			assert.Empty(t, res.ChallengeToken)
+			assert.NotEmpty(t, res.AccessToken)
+			assert.NotEmpty(t, res.RefreshToken)
+			assert.Len(t, f.sessions.sessions, 1)
+		})
+	}
+}
+
+func TestLoginLockout(t *testing.T) {
+	f := newLoginFixture(t, totpDaoMock{}, settingDaoMock{})
+	login := func(password string) rest_err.APIError {
+		_, err := f.service.Login(context.Background(), dto.UserLoginRequest{Email: "kasir@example.com", Password: password}, dto.SessionMeta{IP: "10.0.0.1"})
+		return err
+	}
+
+	steps := []struct {
+		name     string
+		password string
+		status   int
+		failures int
+		locked   bool
+	}{
+		{name: "gagal pertama", password: "salah", status: http.StatusUnauthorized, failures: 1},
+		{name: "login berhasil menghapus hitungan", password: "password", failures: 0},
+	}
+	for i := 1; i <= maxLoginFailure; i++ {
+		steps = append(steps, struct {
+			name     string
+			password string
+			status   int
+			failures int
+			locked   bool
+		}{name: "gagal berturut-turut", password: "salah", status: http.StatusUnauthorized, failures: i, locked: i == maxLoginFailure})
+	}
+	// password benar tetap ditolak selama akun terkunci
+	steps = append(steps, struct {
+		name     string
+		password string
+		status   int
+		failures int
+		locked   bool
+	}{name: "password benar saat terkunci", password: "password", status: http.StatusTooManyRequests, failures: maxLoginFailure, locked: true})
+
+	for _, step := range steps {
+		err := login(step.password)
+		if step.status == 0 {
+			assert.Nil(t, err, step.name)
+		} else if assert.NotNil(t, err, step.name) {
+			assert.Equal(t, step.status, err.Status(), step.name)
+		}
+		user := f.users.users[2]
+		assert.Equal(t, step.failures, user.FailedLogin, step.name)
+		assert.Equal(t, step.locked, user.LockedUntil > time.Now().Unix(), step.name)
+	}
+
+	// setelah masa kunci habis login berhasil dan hitungan dihapus
+	user := f.users.users[2]
+	user.LockedUntil = time.Now().Add(-time.Minute).Unix()
+	f.users.users[2] = user
+	assert.Nil(t, login("password"))
+	assert.Equal(t, 0, f.users.users[2].FailedLogin)
+	assert.Zero(t, f.users.users[2].LockedUntil)
+}
@@ -0,0 +1,84 @@
+package user_serv
+
+import (
+	"context"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/service/setting_serv"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/utils/sfunc"
+	"time"
+)
+
+type UserServiceOutlet interface {
+	FindUserOutlets(ctx context.Context, claims mjwt.CustomClaim, userID int) ([]dto.OutletModel, rest_err.APIError)
+	SetUserOutlets(ctx context.Context, claims mjwt.CustomClaim, userID int, request dto.UserOutletRequest) ([]dto.OutletModel, rest_err.APIError)
+	SwitchOutlet(ctx context.Context, claims mjwt.CustomClaim, request dto.SwitchOutletRequest) (*dto.SwitchOutletResponse, rest_err.APIError)
+}
+
+// FindUserOutlets menampilkan outlet yang ditugaskan kepada user pada merchant yang sama
+func (u *userService) FindUserOutlets(ctx context.Context, claims mjwt.CustomClaim, userID int) ([]dto.OutletModel, rest_err.APIError) {
+	return u.findUserOutlets(ctx, claims, userID)
+}
+
+// SetUserOutlets mengganti seluruh outlet yang ditugaskan kepada user, outlet default user harus termasuk
+func (u *userService) SetUserOutlets(ctx context.Context, claims mjwt.CustomClaim, userID int, request dto.UserOutletRequest) ([]dto.OutletModel, rest_err.APIError) {
+	err := u.userOutletDao.Set(ctx, dto.UserOutletModel{
+		UserID:     userID,
+		MerchantID: claims.Merchant, // <--- user dan outlet harus memiliki merchant id yang sama
+		OutletIDs:  sfunc.UniqueInt(request.OutletIDs),
+		CreatedAt:  time.Now().Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u.findUserOutlets(ctx, claims, userID)
+}
+
+func (u *userService) findUserOutlets(ctx context.Context, claims mjwt.CustomClaim, userID int) ([]dto.OutletModel, rest_err.APIError) {
+	outlets, err := u.userOutletDao.FindByUser(ctx, userID, claims.Merchant)
+	if err != nil {
+		return nil, err
+	}
+	formatter := setting_serv.LoadFormatter(ctx, u.settingDao, claims.Merchant)
+	for i := range outlets {
+		outlets[i].ApplyFormat(formatter)
+	}
+	return outlets, nil
+}
+
+// SwitchOutlet mengganti outlet aktif dan membuat access token baru dengan claim outlet tersebut.
+// Outlet aktif disimpan pada sesi sehingga refresh token tetap dipakai dan refresh berikutnya
+// menghasilkan token dengan outlet yang sama
+func (u *userService) SwitchOutlet(ctx context.Context, claims mjwt.CustomClaim, request dto.SwitchOutletRequest) (*dto.SwitchOutletResponse, rest_err.APIError) {
+	if claims.Device != 0 {
+		return nil, rest_err.NewForbiddenError("Token perangkat kasir hanya berlaku untuk outlet perangkat")
+	}
+	if claims.Family == "" {
+		return nil, rest_err.NewBadRequestError("Token tanpa sesi tidak dapat berganti outlet")
+	}
+	if err := u.outletAccess.CheckOutletAccess(ctx, claims, request.OutletID); err != nil {
+		return nil, err
+	}
+	if err := u.sessionDao.SetOutlet(ctx, claims.Identity, claims.Family, request.OutletID); err != nil {
+		return nil, err
+	}
+
+	accessClaims := claims
+	accessClaims.ExtraMinute = minutes(u.lifetime.Access)
+	accessClaims.Fresh = false
+	accessClaims.Outlet = request.OutletID
+
+	accessToken, err := u.jwt.GenerateToken(accessClaims)
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.SwitchOutletResponse{
+		OutletID:    request.OutletID,
+		AccessToken: accessToken,
+		Expired:     time.Now().Add(u.lifetime.Access).Unix(),
+	}
+	response.ApplyFormat(setting_serv.LoadFormatter(ctx, u.settingDao, claims.Merchant))
+	return &response, nil
+}
@@ -0,0 +1,284 @@
+package user_serv
+
+import (
+	"context"
+	"fmt"
+	"github.com/muchlist/mini_pos/configs/roles"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/service/setting_serv"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/mcrypt"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/muchlist/mini_pos/utils/mtotp"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"go.uber.org/zap"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	totpIssuer        = "Mini POS"
+	recoveryCodeCount = 10
+)
+
+type UserServiceTwoFactor interface {
+	EnrollTwoFactor(ctx context.Context, claims mjwt.CustomClaim) (*dto.TwoFactorEnrollResponse, rest_err.APIError)
+	ConfirmTwoFactor(ctx context.Context, claims mjwt.CustomClaim, request dto.TwoFactorCodeRequest) (*dto.TwoFactorRecoveryResponse, rest_err.APIError)
+	DisableTwoFactor(ctx context.Context, claims mjwt.CustomClaim, request dto.TwoFactorCodeRequest) (string, rest_err.APIError)
+	SetupTwoFactorLogin(ctx context.Context, request dto.TwoFactorChallengeRequest) (*dto.TwoFactorEnrollResponse, rest_err.APIError)
+	LoginTwoFactor(ctx context.Context, request dto.TwoFactorLoginRequest, meta dto.SessionMeta) (*dto.UserLoginResponse, rest_err.APIError)
+}
+
+// EnrollTwoFactor membuat secret TOTP baru untuk user yang login, 2FA baru aktif setelah dikonfirmasi
+func (u *userService) EnrollTwoFactor(ctx context.Context, claims mjwt.CustomClaim) (*dto.TwoFactorEnrollResponse, rest_err.APIError) {
+	user, err := u.dao.GetByID(ctx, claims.Identity)
+	if err != nil {
+		return nil, err
+	}
+	return u.enroll(ctx, *user)
+}
+
+// ConfirmTwoFactor mengaktifkan 2FA dengan kode pertama dari aplikasi authenticator
+// dan mengembalikan recovery code yang hanya ditampilkan sekali
+func (u *userService) ConfirmTwoFactor(ctx context.Context, claims mjwt.CustomClaim, request dto.TwoFactorCodeRequest) (*dto.TwoFactorRecoveryResponse, rest_err.APIError) {
+	totp, err := u.totpDao.Get(ctx, claims.Identity)
+	if err != nil {
+		return nil, err
+	}
+	if totp.IsEnabled() {
+		return nil, rest_err.NewBadRequestError("2FA sudah aktif")
+	}
+	if totp.Secret == "" {
+		return nil, rest_err.NewBadRequestError("Lakukan pendaftaran 2FA terlebih dahulu")
+	}
+
+	codes, err := u.confirm(ctx, *totp, request.Code)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.TwoFactorRecoveryResponse{RecoveryCodes: codes}, nil
+}
+
+// DisableTwoFactor menonaktifkan 2FA, memerlukan kode atau recovery code yang valid.
+// Owner tidak dapat menonaktifkan apabila merchant mewajibkan 2FA
+func (u *userService) DisableTwoFactor(ctx context.Context, claims mjwt.CustomClaim, request dto.TwoFactorCodeRequest) (string, rest_err.APIError) {
+	if claims.Role == roles.RoleOwner {
+		setting, err := u.settingDao.GetSetting(ctx, claims.Merchant)
+		if err != nil {
+			return "", err
+		}
+		if setting.RequireOwner2FA {
+			return "", rest_err.NewForbiddenError("Merchant mewajibkan 2FA untuk owner")
+		}
+	}
+
+	totp, err := u.totpDao.Get(ctx, claims.Identity)
+	if err != nil {
+		return "", err
+	}
+	if !totp.IsEnabled() {
+		return "", rest_err.NewBadRequestError("2FA belum aktif")
+	}
+	if err := u.verifySecondFactor(ctx, *totp, request.Code, request.RecoveryCode); err != nil {
+		return "", err
+	}
+
+	if err := u.totpDao.Disable(ctx, claims.Identity); err != nil {
+		return "", err
+	}
+	logger.InfoCtx(ctx, "2FA dinonaktifkan", zap.Int("user_id", claims.Identity))
+	return "2FA berhasil dinonaktifkan", nil
+}
+
+// SetupTwoFactorLogin pendaftaran 2FA saat login bagi owner yang diwajibkan 2FA namun belum mendaftar
+func (u *userService) SetupTwoFactorLogin(ctx context.Context, request dto.TwoFactorChallengeRequest) (*dto.TwoFactorEnrollResponse, rest_err.APIError) {
+	claims, err := u.readChallenge(request.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+	user, err := u.dao.GetByID(ctx, claims.Identity)
+	if err != nil {
+		return nil, err
+	}
+	return u.enroll(ctx, *user)
+}
+
+// LoginTwoFactor langkah kedua login, menukar challenge token dan kode 2FA dengan access dan refresh token.
+// Apabila 2FA belum aktif (setup saat login), kode pertama sekaligus mengaktifkan 2FA dan recovery code dikembalikan
+func (u *userService) LoginTwoFactor(ctx context.Context, request dto.TwoFactorLoginRequest, meta dto.SessionMeta) (*dto.UserLoginResponse, rest_err.APIError) {
+	claims, err := u.readChallenge(request.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+	user, err := u.dao.GetByID(ctx, claims.Identity)
+	if err != nil {
+		return nil, err
+	}
+	email := strings.ToLower(string(user.Email))
+	if err := u.checkLoginGuard(ctx, email, meta.IP); err != nil {
+		return nil, err
+	}
+	if err := checkLocked(ctx, *user); err != nil {
+		return nil, err
+	}
+	totp, err := u.totpDao.Get(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var recoveryCodes []string
+	switch {
+	case totp.IsEnabled():
+		if err := u.verifySecondFactor(ctx, *totp, request.Code, request.RecoveryCode); err != nil {
+			logger.WarnCtx(ctx, "verifikasi 2FA gagal", zap.Int("user_id", user.ID), zap.String("ip", meta.IP))
+			u.loginFailed(ctx, user, email, meta.IP)
+			return nil, err
+		}
+	case totp.Secret != "":
+		recoveryCodes, err = u.confirm(ctx, *totp, request.Code)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, rest_err.NewBadRequestError("Lakukan setup 2FA melalui /login/2fa/setup terlebih dahulu")
+	}
+
+	response, err := u.issueLogin(ctx, *user, meta)
+	if err != nil {
+		return nil, err
+	}
+	response.RecoveryCodes = recoveryCodes
+	return response, nil
+}
+
+// twoFactorChallenge mengembalikan response challenge apabila user memerlukan langkah 2FA,
+// nil apabila user dapat langsung login
+func (u *userService) twoFactorChallenge(ctx context.Context, user dto.UserModel) (*dto.UserLoginResponse, rest_err.APIError) {
+	totp, err := u.totpDao.Get(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	required := false
+	if !totp.IsEnabled() && string(user.Role) == roles.RoleOwner {
+		setting, err := u.settingDao.GetSetting(ctx, user.MerchantID)
+		if err != nil {
+			return nil, err
+		}
+		required = setting.RequireOwner2FA
+	}
+	if !totp.IsEnabled() && !required {
+		return nil, nil
+	}
+
+	challengeToken, err := u.jwt.GenerateToken(mjwt.CustomClaim{
+		Identity:    user.ID,
+		Name:        string(user.Name),
+		ExtraMinute: minutes(u.lifetime.Challenge),
+		Type:        mjwt.Challenge,
+		Fresh:       false,
+		Role:        string(user.Role),
+		Merchant:    user.MerchantID,
+		Outlet:      user.DefOutlet,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.UserLoginResponse{
+		ID:                user.ID,
+		Email:             string(user.Email),
+		Name:              string(user.Name),
+		MerchantID:        user.MerchantID,
+		DefOutlet:         user.DefOutlet,
+		Role:              string(user.Role),
+		Expired:           time.Now().Add(u.lifetime.Challenge).Unix(),
+		TwoFactorRequired: true,
+		TwoFactorSetup:    !totp.IsEnabled(),
+		ChallengeToken:    challengeToken,
+	}
+	response.ApplyFormat(setting_serv.LoadFormatter(ctx, u.settingDao, user.MerchantID))
+	return &response, nil
+}
+
+func (u *userService) readChallenge(challengeToken string) (*mjwt.CustomClaim, rest_err.APIError) {
+	token, err := u.jwt.ValidateToken(challengeToken)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := u.jwt.ReadToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Type != mjwt.Challenge {
+		return nil, rest_err.NewAPIError("Token tidak valid", http.StatusUnprocessableEntity, "jwt_error", []interface{}{"not a challenge token"})
+	}
+	return claims, nil
+}
+
+func (u *userService) enroll(ctx context.Context, user dto.UserModel) (*dto.TwoFactorEnrollResponse, rest_err.APIError) {
+	secret, errGen := mtotp.GenerateSecret()
+	if errGen != nil {
+		return nil, rest_err.NewInternalServerError("gagal membuat secret 2FA", errGen)
+	}
+
+	err := u.totpDao.SetPendingSecret(ctx, dto.TotpModel{
+		UserID:    user.ID,
+		Secret:    secret,
+		CreatedAt: time.Now().Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.TwoFactorEnrollResponse{
+		Secret:          secret,
+		ProvisioningURI: mtotp.ProvisioningURI(secret, totpIssuer, string(user.Email)),
+	}, nil
+}
+
+// confirm memvalidasi kode pertama, mengaktifkan 2FA dan membuat recovery code
+func (u *userService) confirm(ctx context.Context, totp dto.TotpModel, code string) ([]string, rest_err.APIError) {
+	if err := u.verifyCode(ctx, totp, code); err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		id, err := mcrypt.NewID()
+		if err != nil {
+			return nil, err
+		}
+		codes[i] = fmt.Sprintf("%s-%s", id[:5], id[5:10])
+		hashes[i] = mcrypt.HashToken(codes[i])
+	}
+
+	if err := u.totpDao.Enable(ctx, totp.UserID, time.Now().Unix(), hashes); err != nil {
+		return nil, err
+	}
+	logger.InfoCtx(ctx, "2FA diaktifkan", zap.Int("user_id", totp.UserID))
+	return codes, nil
+}
+
+// verifySecondFactor memvalidasi kode TOTP atau recovery code
+func (u *userService) verifySecondFactor(ctx context.Context, totp dto.TotpModel, code string, recoveryCode string) rest_err.APIError {
+	if recoveryCode != "" {
+		err := u.totpDao.UseRecoveryCode(ctx, totp.UserID, mcrypt.HashToken(strings.ToLower(strings.TrimSpace(recoveryCode))))
+		if err == nil {
+			logger.WarnCtx(ctx, "recovery code 2FA dipakai", zap.Int("user_id", totp.UserID))
+		}
+		return err
+	}
+	return u.verifyCode(ctx, totp, code)
+}
+
+func (u *userService) verifyCode(ctx context.Context, totp dto.TotpModel, code string) rest_err.APIError {
+	step, ok := mtotp.Match(totp.Secret, code, time.Now())
+	if !ok {
+		return rest_err.NewUnauthorizedError("Kode 2FA tidak valid")
+	}
+	// kode yang sama tidak dapat dipakai dua kali
+	return u.totpDao.SetLastStep(ctx, totp.UserID, step)
+}
@@ -2,24 +2,66 @@ package user_serv
 
 import (
 	"context"
+	"fmt"
+	"github.com/muchlist/mini_pos/configs/permissions"
+	"github.com/muchlist/mini_pos/configs/roles"
+	"github.com/muchlist/mini_pos/dao/impersonation_dao"
+	"github.com/muchlist/mini_pos/dao/merchant_dao"
+	"github.com/muchlist/mini_pos/dao/plan_dao"
+	"github.com/muchlist/mini_pos/dao/refresh_token_dao"
+	"github.com/muchlist/mini_pos/dao/role_dao"
+	"github.com/muchlist/mini_pos/dao/session_dao"
+	"github.com/muchlist/mini_pos/dao/totp_dao"
 	"github.com/muchlist/mini_pos/dao/user_dao"
+	"github.com/muchlist/mini_pos/dao/user_outlet_dao"
 	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/service/outlet_serv"
+	"github.com/muchlist/mini_pos/service/setting_serv"
+	"github.com/muchlist/mini_pos/utils/bruteforce"
+	"github.com/muchlist/mini_pos/utils/logger"
 	"github.com/muchlist/mini_pos/utils/mcrypt"
 	"github.com/muchlist/mini_pos/utils/mjwt"
 	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/muchlist/mini_pos/utils/sfunc"
 	"net/http"
+	"strings"
 	"time"
 )
 
-const (
-	expiredJWTToken        = 60 * 1       // 1 Hour
-	expiredJWTRefreshToken = 15 * 24 * 10 // 15 days
-)
+// TokenLifetime masa berlaku token yang diterbitkan user service
+type TokenLifetime struct {
+	Access      time.Duration
+	Refresh     time.Duration
+	Impersonate time.Duration
+	Challenge   time.Duration
+}
+
+// minutes mengubah durasi menjadi jumlah menit untuk CustomClaim.ExtraMinute
+func minutes(d time.Duration) time.Duration {
+	return d / time.Minute
+}
 
 type UserServiceAssumer interface {
 	UserServiceAccess
 	UserServiceModifier
 	UserServiceReader
+	UserServiceSuper
+	UserServiceSession
+	UserServiceTwoFactor
+	UserServiceLockout
+	UserServiceOutlet
+}
+
+type UserServiceSession interface {
+	FindSessions(ctx context.Context, claims mjwt.CustomClaim, userID int) ([]dto.SessionModel, rest_err.APIError)
+	RevokeSession(ctx context.Context, claims mjwt.CustomClaim, userID int, sessionID string) rest_err.APIError
+}
+
+type UserServiceSuper interface {
+	BootstrapSuperUser(ctx context.Context, user dto.UserModel) (string, rest_err.APIError)
+	CreateSuperUser(ctx context.Context, user dto.UserModel) (string, rest_err.APIError)
+	Impersonate(ctx context.Context, claims mjwt.CustomClaim, request dto.ImpersonateRequest) (*dto.ImpersonateResponse, rest_err.APIError)
+	FindImpersonations(ctx context.Context, merchantID int, limit int, offset int) ([]dto.ImpersonationModel, rest_err.APIError)
 }
 
 type UserServiceReader interface {
@@ -28,8 +70,10 @@ type UserServiceReader interface {
 }
 
 type UserServiceAccess interface {
-	Login(ctx context.Context, login dto.UserLoginRequest) (*dto.UserLoginResponse, rest_err.APIError)
+	Login(ctx context.Context, login dto.UserLoginRequest, meta dto.SessionMeta) (*dto.UserLoginResponse, rest_err.APIError)
 	Refresh(ctx context.Context, payload dto.UserRefreshTokenRequest) (*dto.UserRefreshTokenResponse, rest_err.APIError)
+	Logout(ctx context.Context, claims mjwt.CustomClaim) (string, rest_err.APIError)
+	LogoutAll(ctx context.Context, claims mjwt.CustomClaim) (string, rest_err.APIError)
 }
 
 type UserServiceModifier interface {
@@ -38,63 +82,140 @@ type UserServiceModifier interface {
 	DeleteUser(ctx context.Context, claims mjwt.CustomClaim, userID int) rest_err.APIError
 }
 
-func NewUserService(dao user_dao.UserDaoAssumer, crypto mcrypt.BcryptAssumer, jwt mjwt.JWTAssumer) UserServiceAssumer {
+func NewUserService(
+	dao user_dao.UserDaoAssumer,
+	impersonationDao impersonation_dao.ImpersonationDaoAssumer,
+	planDao plan_dao.PlanLoader,
+	refreshDao refresh_token_dao.RefreshTokenDaoAssumer,
+	sessionDao session_dao.SessionDaoAssumer,
+	totpDao totp_dao.TotpDaoAssumer,
+	roleDao role_dao.RoleLoader,
+	userOutletDao user_outlet_dao.UserOutletDaoAssumer,
+	settingDao merchant_dao.MerchantSettingLoader,
+	outletAccess outlet_serv.OutletServiceAccess,
+	crypto mcrypt.BcryptAssumer,
+	jwt mjwt.JWTAssumer,
+	guard bruteforce.GuardAssumer,
+	lifetime TokenLifetime) UserServiceAssumer {
 	return &userService{
-		dao:    dao,
-		crypto: crypto,
-		jwt:    jwt,
+		dao:              dao,
+		impersonationDao: impersonationDao,
+		planDao:          planDao,
+		refreshDao:       refreshDao,
+		sessionDao:       sessionDao,
+		totpDao:          totpDao,
+		roleDao:          roleDao,
+		userOutletDao:    userOutletDao,
+		settingDao:       settingDao,
+		outletAccess:     outletAccess,
+		crypto:           crypto,
+		jwt:              jwt,
+		guard:            guard,
+		lifetime:         lifetime,
 	}
 }
 
 type userService struct {
-	dao    user_dao.UserDaoAssumer
-	crypto mcrypt.BcryptAssumer
-	jwt    mjwt.JWTAssumer
+	dao              user_dao.UserDaoAssumer
+	impersonationDao impersonation_dao.ImpersonationDaoAssumer
+	planDao          plan_dao.PlanLoader
+	refreshDao       refresh_token_dao.RefreshTokenDaoAssumer
+	sessionDao       session_dao.SessionDaoAssumer
+	totpDao          totp_dao.TotpDaoAssumer
+	roleDao          role_dao.RoleLoader
+	userOutletDao    user_outlet_dao.UserOutletDaoAssumer
+	settingDao       merchant_dao.MerchantSettingLoader
+	outletAccess     outlet_serv.OutletServiceAccess
+	crypto           mcrypt.BcryptAssumer
+	jwt              mjwt.JWTAssumer
+	guard            bruteforce.GuardAssumer
+	lifetime         TokenLifetime
 }
 
 // Login
-func (u *userService) Login(ctx context.Context, login dto.UserLoginRequest) (*dto.UserLoginResponse, rest_err.APIError) {
+func (u *userService) Login(ctx context.Context, login dto.UserLoginRequest, meta dto.SessionMeta) (*dto.UserLoginResponse, rest_err.APIError) {
+	email := strings.ToLower(strings.TrimSpace(login.Email))
+	// jeda bertingkat per email dan per ip
+	if err := u.checkLoginGuard(ctx, email, meta.IP); err != nil {
+		return nil, err
+	}
+
 	user, err := u.dao.GetByEmail(ctx, login.Email)
 	if err != nil {
+		u.loginFailed(ctx, nil, email, meta.IP)
 		return nil, rest_err.NewBadRequestError("Email atau password tidak valid")
 	}
-
-	println(user.MerchantID)
+	if err := checkLocked(ctx, *user); err != nil {
+		return nil, err
+	}
 
 	if !u.crypto.IsPWAndHashPWMatch(login.Password, user.Password) {
+		u.loginFailed(ctx, user, email, meta.IP)
 		return nil, rest_err.NewUnauthorizedError("email atau password tidak valid")
 	}
 
+	// user dengan 2FA aktif atau yang diwajibkan 2FA mendapatkan challenge token terlebih dahulu
+	challenge, err := u.twoFactorChallenge(ctx, *user)
+	if err != nil {
+		return nil, err
+	}
+	if challenge != nil {
+		return challenge, nil
+	}
+
+	return u.issueLogin(ctx, *user, meta)
+}
+
+// issueLogin membuat access token, refresh token dan sesi untuk user yang sudah terverifikasi
+func (u *userService) issueLogin(ctx context.Context, user dto.UserModel, meta dto.SessionMeta) (*dto.UserLoginResponse, rest_err.APIError) {
+	// setiap login memulai sesi baru, id sesi dipakai sebagai family refresh token
+	family, err := mcrypt.NewID()
+	if err != nil {
+		return nil, err
+	}
+	perms, err := u.roleDao.GetUserPermissions(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	AccessClaims := mjwt.CustomClaim{
 		Identity:    user.ID,
 		Name:        string(user.Name),
-		ExtraMinute: expiredJWTToken, // 1 Hour
+		ExtraMinute: minutes(u.lifetime.Access),
 		Type:        mjwt.Access,
 		Fresh:       true,
 		Role:        string(user.Role),
 		Merchant:    user.MerchantID,
 		Outlet:      user.DefOutlet,
-	}
-
-	RefreshClaims := mjwt.CustomClaim{
-		Identity:    user.ID,
-		Name:        string(user.Name),
-		ExtraMinute: expiredJWTRefreshToken, // 15 days
-		Type:        mjwt.Refresh,
-		Fresh:       false,
-		Role:        string(user.Role),
-		Merchant:    user.MerchantID,
-		Outlet:      user.DefOutlet,
+		Family:      family,
+		Permissions: perms,
 	}
 
 	accessToken, err := u.jwt.GenerateToken(AccessClaims)
 	if err != nil {
 		return nil, err
 	}
-	refreshToken, err := u.jwt.GenerateToken(RefreshClaims)
+	refreshToken, refreshModel, err := u.generateRefreshToken(user, family)
 	if err != nil {
 		return nil, err
 	}
+	err = u.sessionDao.Insert(ctx, dto.SessionModel{
+		ID:         family,
+		UserID:     user.ID,
+		UserAgent:  sfunc.Truncate(meta.UserAgent, 255),
+		IP:         meta.IP,
+		OutletID:   user.DefOutlet,
+		CreatedAt:  refreshModel.CreatedAt,
+		LastSeenAt: refreshModel.CreatedAt,
+		ExpiredAt:  refreshModel.ExpiredAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := u.refreshDao.Insert(ctx, *refreshModel); err != nil {
+		return nil, err
+	}
+	u.loginSucceeded(ctx, user)
 
 	userResponse := dto.UserLoginResponse{
 		ID:           user.ID,
@@ -103,10 +224,12 @@ func (u *userService) Login(ctx context.Context, login dto.UserLoginRequest) (*d
 		MerchantID:   user.MerchantID,
 		DefOutlet:    user.DefOutlet,
 		Role:         string(user.Role),
+		Permissions:  perms,
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
-		Expired:      time.Now().Add(time.Minute * time.Duration(expiredJWTToken)).Unix(),
+		Expired:      time.Now().Add(u.lifetime.Access).Unix(),
 	}
+	userResponse.ApplyFormat(setting_serv.LoadFormatter(ctx, u.settingDao, user.MerchantID))
 
 	return &userResponse, nil
 }
@@ -114,6 +237,21 @@ func (u *userService) Login(ctx context.Context, login dto.UserLoginRequest) (*d
 // InsertUser melakukan register user oleh akun owner kepada akun akun dibawah merchant
 func (u *userService) InsertUser(ctx context.Context, claims mjwt.CustomClaim, user dto.UserModel) (string, rest_err.APIError) {
 
+	usage, err := u.planDao.GetUsage(ctx, claims.Merchant)
+	if err != nil {
+		return "", err
+	}
+	if errQuota := usage.CheckQuota(dto.QuotaUser, 1); errQuota != nil {
+		return "", rest_err.NewQuotaExceededError(errQuota.Error())
+	}
+
+	if err := u.checkOwnerRole(ctx, claims, 0, string(user.Role)); err != nil {
+		return "", err
+	}
+	if err := u.checkCustomRole(ctx, claims, user.CustomRoleID); err != nil {
+		return "", err
+	}
+
 	timeNow := time.Now().Unix()
 	hashPassword, err := u.crypto.GenerateHash(user.Password)
 	if err != nil {
@@ -134,12 +272,20 @@ func (u *userService) InsertUser(ctx context.Context, claims mjwt.CustomClaim, u
 
 // EditUser
 func (u *userService) EditUser(ctx context.Context, claims mjwt.CustomClaim, request dto.UserEditRequest) (*dto.UserModel, rest_err.APIError) {
+	if err := u.checkOwnerRole(ctx, claims, request.ID, request.Role); err != nil {
+		return nil, err
+	}
+	if err := u.checkCustomRole(ctx, claims, request.CustomRoleID); err != nil {
+		return nil, err
+	}
+
 	editParams := dto.UserEditModel{
 		WhereID:         request.ID,
 		WhereMerchantID: claims.Merchant, // <--- user yang diedit harus memiliki merchant id yang sama
 		Email:           dto.LowercaseString(request.Email),
 		Name:            dto.UppercaseString(request.Name),
 		Role:            dto.LowercaseString(request.Role),
+		CustomRoleID:    request.CustomRoleID,
 		UpdatedAt:       time.Now().Unix(),
 		DefOutlet:       request.DefOutlet,
 	}
@@ -148,10 +294,53 @@ func (u *userService) EditUser(ctx context.Context, claims mjwt.CustomClaim, req
 	if err != nil {
 		return nil, err
 	}
+	result.ApplyFormat(setting_serv.LoadFormatter(ctx, u.settingDao, claims.Merchant))
 	return result, nil
 }
 
-// Refresh token
+// checkOwnerRole memastikan role owner hanya diberikan, dicabut atau diubah oleh pemegang permission All,
+// permission user.manage saja tidak cukup agar employee tidak dapat mengambil alih atau menurunkan owner.
+// targetID 0 berarti user baru
+func (u *userService) checkOwnerRole(ctx context.Context, claims mjwt.CustomClaim, targetID int, role string) rest_err.APIError {
+	if claims.Can(permissions.All) {
+		return nil
+	}
+	if strings.EqualFold(role, roles.RoleOwner) {
+		return rest_err.NewForbiddenError("Hanya owner yang dapat memberikan role owner")
+	}
+	if targetID == 0 {
+		return nil
+	}
+	target, err := u.dao.GetByID(ctx, targetID)
+	if err != nil {
+		return err
+	}
+	if target.MerchantID == claims.Merchant && target.Role == roles.RoleOwner {
+		return rest_err.NewForbiddenError("Hanya owner yang dapat mengubah atau menghapus user owner")
+	}
+	return nil
+}
+
+// checkCustomRole memastikan custom role yang diberikan kepada user milik merchant yang sama
+// dan tidak memiliki permission di luar permission pemberi, 0 berarti user memakai role bawaan
+func (u *userService) checkCustomRole(ctx context.Context, claims mjwt.CustomClaim, customRoleID int) rest_err.APIError {
+	if customRoleID == 0 {
+		return nil
+	}
+	role, err := u.roleDao.Get(ctx, customRoleID, claims.Merchant)
+	if err != nil {
+		return rest_err.NewBadRequestError(fmt.Sprintf("Role dengan id %d tidak ditemukan", customRoleID))
+	}
+	for _, p := range role.Permissions {
+		if !claims.Can(p) {
+			return rest_err.NewForbiddenError(fmt.Sprintf("Tidak dapat memberikan role %s yang memiliki permission %s di luar permission pemberi", role.Name, p))
+		}
+	}
+	return nil
+}
+
+// Refresh menukar refresh token dengan access token dan refresh token baru (rotasi).
+// Refresh token lama tidak dapat dipakai lagi, memakainya kembali akan mencabut seluruh family
 func (u *userService) Refresh(ctx context.Context, payload dto.UserRefreshTokenRequest) (*dto.UserRefreshTokenResponse, rest_err.APIError) {
 	token, apiErr := u.jwt.ValidateToken(payload.RefreshToken)
 	if apiErr != nil {
@@ -167,21 +356,53 @@ func (u *userService) Refresh(ctx context.Context, payload dto.UserRefreshTokenR
 		return nil, rest_err.NewAPIError("Token tidak valid", http.StatusUnprocessableEntity, "jwt_error", []interface{}{"not a refresh token"})
 	}
 
+	// refresh token yang dibuat sebelum rotasi diterapkan tidak memiliki jti
+	if claims.TokenID == "" || claims.Family == "" {
+		return nil, rest_err.NewUnauthorizedError("Refresh token tidak valid, silahkan login kembali")
+	}
+
 	// mendapatkan data terbaru dari user
 	user, apiErr := u.dao.GetByID(ctx, claims.Identity)
 	if apiErr != nil {
 		return nil, apiErr
 	}
 
+	refreshToken, refreshModel, apiErr := u.generateRefreshToken(*user, claims.Family)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	apiErr = u.refreshDao.Rotate(ctx, claims.TokenID, mcrypt.HashToken(payload.RefreshToken), *refreshModel)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	// sesi yang sudah diakhiri tidak dapat dilanjutkan
+	sessionOutlet, apiErr := u.sessionDao.Touch(ctx, claims.Family, refreshModel.CreatedAt, refreshModel.ExpiredAt)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	// permission dibaca ulang sehingga perubahan custom role berlaku setelah refresh
+	perms, apiErr := u.roleDao.GetUserPermissions(ctx, user.ID)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
 	accessClaims := mjwt.CustomClaim{
 		Identity:    user.ID,
 		Name:        string(user.Name),
-		ExtraMinute: expiredJWTToken, // 1 Hour
+		ExtraMinute: minutes(u.lifetime.Access),
 		Type:        mjwt.Access,
 		Fresh:       false,
 		Role:        string(user.Role),
 		Merchant:    user.MerchantID,
 		Outlet:      user.DefOutlet,
+		Family:      claims.Family,
+		Permissions: perms,
+	}
+	// outlet hasil ganti outlet tetap dipakai selama masih dapat diakses user
+	if sessionOutlet != 0 && sessionOutlet != user.DefOutlet {
+		if u.outletAccess.CheckOutletAccess(ctx, accessClaims, sessionOutlet) == nil {
+			accessClaims.Outlet = sessionOutlet
+		}
 	}
 
 	accessToken, err := u.jwt.GenerateToken(accessClaims)
@@ -190,15 +411,132 @@ func (u *userService) Refresh(ctx context.Context, payload dto.UserRefreshTokenR
 	}
 
 	userRefreshTokenResponse := dto.UserRefreshTokenResponse{
-		AccessToken: accessToken,
-		Expired:     time.Now().Add(time.Minute * time.Duration(expiredJWTToken)).Unix(),
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		Expired:      time.Now().Add(u.lifetime.Access).Unix(),
 	}
+	userRefreshTokenResponse.ApplyFormat(setting_serv.LoadFormatter(ctx, u.settingDao, user.MerchantID))
 
 	return &userRefreshTokenResponse, nil
 }
 
+// Logout mencabut refresh token pada perangkat yang sedang dipakai
+func (u *userService) Logout(ctx context.Context, claims mjwt.CustomClaim) (string, rest_err.APIError) {
+	if claims.Family == "" {
+		return "tidak ada sesi yang perlu diakhiri", nil
+	}
+	if err := u.revokeSession(ctx, claims.Identity, claims.Family); err != nil {
+		return "", err
+	}
+	return "berhasil logout", nil
+}
+
+// LogoutAll mencabut seluruh refresh token user pada semua perangkat
+func (u *userService) LogoutAll(ctx context.Context, claims mjwt.CustomClaim) (string, rest_err.APIError) {
+	if err := u.sessionDao.RevokeAllByUser(ctx, claims.Identity); err != nil {
+		return "", err
+	}
+	if err := u.refreshDao.RevokeAllByUser(ctx, claims.Identity); err != nil {
+		return "", err
+	}
+	return "berhasil logout dari semua perangkat", nil
+}
+
+// FindSessions menampilkan sesi aktif. User dapat melihat sesinya sendiri,
+// owner dapat melihat sesi user lain pada merchant yang sama
+func (u *userService) FindSessions(ctx context.Context, claims mjwt.CustomClaim, userID int) ([]dto.SessionModel, rest_err.APIError) {
+	if err := u.checkSessionOwner(ctx, claims, userID); err != nil {
+		return nil, err
+	}
+
+	sessions, err := u.sessionDao.FindActiveByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	formatter := setting_serv.LoadFormatter(ctx, u.settingDao, claims.Merchant)
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == claims.Family
+		sessions[i].ApplyFormat(formatter)
+	}
+	return sessions, nil
+}
+
+// RevokeSession mengakhiri satu sesi, access token dan refresh token dari sesi tersebut tidak lagi diterima
+func (u *userService) RevokeSession(ctx context.Context, claims mjwt.CustomClaim, userID int, sessionID string) rest_err.APIError {
+	if err := u.checkSessionOwner(ctx, claims, userID); err != nil {
+		return err
+	}
+	return u.revokeSession(ctx, userID, sessionID)
+}
+
+func (u *userService) revokeSession(ctx context.Context, userID int, sessionID string) rest_err.APIError {
+	if err := u.sessionDao.Revoke(ctx, userID, sessionID); err != nil {
+		return err
+	}
+	return u.refreshDao.RevokeFamily(ctx, userID, sessionID)
+}
+
+// checkSessionOwner memastikan claims berhak mengakses sesi milik userID
+func (u *userService) checkSessionOwner(ctx context.Context, claims mjwt.CustomClaim, userID int) rest_err.APIError {
+	if claims.Identity == userID {
+		return nil
+	}
+	if !claims.Can(permissions.UserManage) {
+		return rest_err.NewUnauthorizedError(fmt.Sprintf("Unauthorized, memerlukan permission %s untuk mengelola sesi user lain", permissions.UserManage))
+	}
+
+	user, err := u.dao.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.MerchantID != claims.Merchant {
+		return rest_err.NewBadRequestError(fmt.Sprintf("User dengan id %d tidak ditemukan", userID))
+	}
+	return nil
+}
+
+// generateRefreshToken membuat refresh token dengan jti baru pada family yang diberikan
+// beserta data yang perlu disimpan di database
+func (u *userService) generateRefreshToken(user dto.UserModel, family string) (string, *dto.RefreshTokenModel, rest_err.APIError) {
+	tokenID, err := mcrypt.NewID()
+	if err != nil {
+		return "", nil, err
+	}
+
+	refreshClaims := mjwt.CustomClaim{
+		Identity:    user.ID,
+		Name:        string(user.Name),
+		ExtraMinute: minutes(u.lifetime.Refresh),
+		Type:        mjwt.Refresh,
+		Fresh:       false,
+		Role:        string(user.Role),
+		Merchant:    user.MerchantID,
+		Outlet:      user.DefOutlet,
+		TokenID:     tokenID,
+		Family:      family,
+	}
+
+	refreshToken, err := u.jwt.GenerateToken(refreshClaims)
+	if err != nil {
+		return "", nil, err
+	}
+
+	timeNow := time.Now()
+	return refreshToken, &dto.RefreshTokenModel{
+		TokenID:   tokenID,
+		FamilyID:  family,
+		UserID:    user.ID,
+		TokenHash: mcrypt.HashToken(refreshToken),
+		CreatedAt: timeNow.Unix(),
+		ExpiredAt: timeNow.Add(u.lifetime.Refresh).Unix(),
+	}, nil
+}
+
 // DeleteUser
 func (u *userService) DeleteUser(ctx context.Context, claims mjwt.CustomClaim, userID int) rest_err.APIError {
+	if err := u.checkOwnerRole(ctx, claims, userID, ""); err != nil {
+		return err
+	}
 	err := u.dao.Delete(ctx, userID, claims.Merchant)
 	if err != nil {
 		return err
@@ -212,6 +550,7 @@ func (u *userService) GetUserByID(ctx context.Context, userID int) (*dto.UserMod
 	if err != nil {
 		return nil, err
 	}
+	user.ApplyFormat(setting_serv.LoadFormatter(ctx, u.settingDao, user.MerchantID))
 	return user, nil
 }
 
@@ -225,5 +564,116 @@ func (u *userService) FindUsers(ctx context.Context, search string, limit int, o
 	if err != nil {
 		return nil, err
 	}
+	// daftar user dapat berasal dari beberapa merchant
+	formatters := setting_serv.NewFormatters(u.settingDao)
+	for i := range userList {
+		userList[i].ApplyFormat(formatters.Get(ctx, userList[i].MerchantID))
+	}
 	return userList, nil
 }
+
+// BootstrapSuperUser membuat super user pertama, tidak melakukan apapun apabila super user sudah ada
+func (u *userService) BootstrapSuperUser(ctx context.Context, user dto.UserModel) (string, rest_err.APIError) {
+	exist, err := u.dao.IsRoleExist(ctx, roles.RoleSuper)
+	if err != nil {
+		return "", err
+	}
+	if exist {
+		return "super user sudah tersedia", nil
+	}
+
+	return u.CreateSuperUser(ctx, user)
+}
+
+// CreateSuperUser membuat super user baru meskipun super user lain sudah ada
+func (u *userService) CreateSuperUser(ctx context.Context, user dto.UserModel) (string, rest_err.APIError) {
+	hashPassword, err := u.crypto.GenerateHash(user.Password)
+	if err != nil {
+		return "", err
+	}
+
+	timeNow := time.Now().Unix()
+	user.Password = hashPassword
+	user.Role = roles.RoleSuper
+	user.MerchantID = 0 // super user tidak terikat dengan merchant
+	user.DefOutlet = 0
+	user.CreatedAt = timeNow
+	user.UpdatedAt = timeNow
+
+	return u.dao.Insert(ctx, user)
+}
+
+// Impersonate membuat token akses berumur pendek atas nama owner merchant untuk super user.
+// Setiap impersonate dicatat dan token tidak memiliki refresh token serta tidak fresh
+func (u *userService) Impersonate(ctx context.Context, claims mjwt.CustomClaim, request dto.ImpersonateRequest) (*dto.ImpersonateResponse, rest_err.APIError) {
+	owner, err := u.dao.GetOwnerByMerchant(ctx, request.MerchantID)
+	if err != nil {
+		return nil, rest_err.NewBadRequestError(fmt.Sprintf("Owner untuk merchant %d tidak ditemukan", request.MerchantID))
+	}
+
+	perms, err := u.roleDao.GetUserPermissions(ctx, owner.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	timeNow := time.Now()
+	expired := timeNow.Add(u.lifetime.Impersonate).Unix()
+
+	_, err = u.impersonationDao.Insert(ctx, dto.ImpersonationModel{
+		SuperID:    claims.Identity,
+		MerchantID: request.MerchantID,
+		OwnerID:    owner.ID,
+		Reason:     request.Reason,
+		CreatedAt:  timeNow.Unix(),
+		ExpiredAt:  expired,
+	})
+	if err != nil {
+		return nil, err
+	}
+	logger.InfoCtx(ctx, fmt.Sprintf("super user %d impersonate owner %d merchant %d", claims.Identity, owner.ID, request.MerchantID))
+
+	accessClaims := mjwt.CustomClaim{
+		Identity:     owner.ID,
+		Name:         string(owner.Name),
+		ExtraMinute:  minutes(u.lifetime.Impersonate),
+		Type:         mjwt.Access,
+		Fresh:        false,
+		Role:         string(owner.Role),
+		Merchant:     owner.MerchantID,
+		Outlet:       owner.DefOutlet,
+		Impersonator: claims.Identity,
+		Permissions:  perms,
+	}
+
+	accessToken, err := u.jwt.GenerateToken(accessClaims)
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.ImpersonateResponse{
+		MerchantID:  request.MerchantID,
+		OwnerID:     owner.ID,
+		OwnerName:   string(owner.Name),
+		AccessToken: accessToken,
+		Expired:     expired,
+	}
+	response.ApplyFormat(setting_serv.LoadFormatter(ctx, u.settingDao, request.MerchantID))
+	return &response, nil
+}
+
+// FindImpersonations menampilkan catatan impersonate, merchantID 0 berarti semua merchant
+func (u *userService) FindImpersonations(ctx context.Context, merchantID int, limit int, offset int) ([]dto.ImpersonationModel, rest_err.APIError) {
+	impersonationList, err := u.impersonationDao.FindWithPagination(ctx, impersonation_dao.FindParams{
+		MerchantID: merchantID,
+		Limit:      limit,
+		Offset:     offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	formatters := setting_serv.NewFormatters(u.settingDao)
+	for i := range impersonationList {
+		impersonationList[i].ApplyFormat(formatters.Get(ctx, impersonationList[i].MerchantID))
+	}
+	return impersonationList, nil
+}
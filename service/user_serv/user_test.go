@@ -0,0 +1,222 @@
+package user_serv
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/muchlist/mini_pos/configs/permissions"
+	"github.com/muchlist/mini_pos/configs/roles"
+	"github.com/muchlist/mini_pos/dao/plan_dao"
+	"github.com/muchlist/mini_pos/dao/user_dao"
+	"github.com/muchlist/mini_pos/dto"
+	"github.com/muchlist/mini_pos/utils/mcrypt"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"github.com/stretchr/testify/assert"
+)
+
+// userDaoMock menyimpan user di memory, method yang tidak dipakai test memanggil interface nil dan panic
+type userDaoMock struct {
+	user_dao.UserDaoAssumer
+	users map[int]dto.UserModel
+}
+
+func (m *userDaoMock) GetByEmail(_ context.Context, email string) (*dto.UserModel, rest_err.APIError) {
+	for _, user := range m.users {
+		if string(user.Email) == email {
+			return &user, nil
+		}
+	}
+	return nil, rest_err.NewNotFoundError("user tidak ditemukan")
+}
+
+func (m *userDaoMock) GetByID(_ context.Context, id int) (*dto.UserModel, rest_err.APIError) {
+	user, ok := m.users[id]
+	if !ok {
+		return nil, rest_err.NewNotFoundError("user tidak ditemukan")
+	}
+	return &user, nil
+}
+
+func (m *userDaoMock) Insert(_ context.Context, user dto.UserModel) (string, rest_err.APIError) {
+	user.ID = len(m.users) + 1
+	m.users[user.ID] = user
+	return "ok", nil
+}
+
+func (m *userDaoMock) Edit(_ context.Context, input dto.UserEditModel) (*dto.UserModel, rest_err.APIError) {
+	user := m.users[input.WhereID]
+	user.Role = input.Role
+	user.CustomRoleID = input.CustomRoleID
+	m.users[input.WhereID] = user
+	return &user, nil
+}
+
+func (m *userDaoMock) Delete(_ context.Context, id int, _ int) rest_err.APIError {
+	delete(m.users, id)
+	return nil
+}
+
+// RecordLoginFailure sama dengan dao: akun dikunci ketika hitungan mencapai maxFailures
+func (m *userDaoMock) RecordLoginFailure(_ context.Context, id int, maxFailures int, lockedUntil int64) (int, int64, rest_err.APIError) {
+	user := m.users[id]
+	user.FailedLogin++
+	if user.FailedLogin >= maxFailures {
+		user.LockedUntil = lockedUntil
+	}
+	m.users[id] = user
+	return user.FailedLogin, user.LockedUntil, nil
+}
+
+func (m *userDaoMock) ResetLoginFailure(_ context.Context, id int, _ int) rest_err.APIError {
+	user := m.users[id]
+	user.FailedLogin = 0
+	user.LockedUntil = 0
+	m.users[id] = user
+	return nil
+}
+
+type planDaoMock struct {
+	plan_dao.PlanLoader
+}
+
+func (planDaoMock) GetUsage(_ context.Context, merchantID int) (*dto.MerchantUsage, rest_err.APIError) {
+	return &dto.MerchantUsage{MerchantID: merchantID}, nil
+}
+
+func TestOwnerRoleRequiresAllPermission(t *testing.T) {
+	owner := mjwt.CustomClaim{Identity: 1, Merchant: 1, Permissions: []string{permissions.All}}
+	manager := mjwt.CustomClaim{Identity: 2, Merchant: 1, Permissions: []string{permissions.UserManage}}
+
+	tests := []struct {
+		name   string
+		claims mjwt.CustomClaim
+		call   func(service UserServiceAssumer, claims mjwt.CustomClaim) rest_err.APIError
+		status int
+	}{
+		{
+			name:   "manager memberikan role owner pada user baru",
+			claims: manager,
+			call: func(service UserServiceAssumer, claims mjwt.CustomClaim) rest_err.APIError {
+				_, err := service.InsertUser(context.Background(), claims, dto.UserModel{Role: roles.RoleOwner, Password: "password"})
+				return err
+			},
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "manager menaikkan employee menjadi owner",
+			claims: manager,
+			call: func(service UserServiceAssumer, claims mjwt.CustomClaim) rest_err.APIError {
+				_, err := service.EditUser(context.Background(), claims, dto.UserEditRequest{ID: 3, Role: "OWNER"})
+				return err
+			},
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "manager menurunkan owner",
+			claims: manager,
+			call: func(service UserServiceAssumer, claims mjwt.CustomClaim) rest_err.APIError {
+				_, err := service.EditUser(context.Background(), claims, dto.UserEditRequest{ID: 1, Role: roles.RoleEmployee})
+				return err
+			},
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "manager menghapus owner",
+			claims: manager,
+			call: func(service UserServiceAssumer, claims mjwt.CustomClaim) rest_err.APIError {
+				return service.DeleteUser(context.Background(), claims, 1)
+			},
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "manager mengubah employee",
+			claims: manager,
+			call: func(service UserServiceAssumer, claims mjwt.CustomClaim) rest_err.APIError {
+				_, err := service.EditUser(context.Background(), claims, dto.UserEditRequest{ID: 3, Role: roles.RoleEmployee})
+				return err
+			},
+		},
+		{
+			name:   "owner menaikkan employee menjadi owner",
+			claims: owner,
+			call: func(service UserServiceAssumer, claims mjwt.CustomClaim) rest_err.APIError {
+				_, err := service.EditUser(context.Background(), claims, dto.UserEditRequest{ID: 3, Role: roles.RoleOwner})
+				return err
+			},
+		},
+		{
+			name:   "owner menambah owner baru",
+			claims: owner,
+			call: func(service UserServiceAssumer, claims mjwt.CustomClaim) rest_err.APIError {
+				_, err := service.InsertUser(context.Background(), claims, dto.UserModel{Role: roles.RoleOwner, Password: "password"})
+				return err
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			userDao := &userDaoMock{users: map[int]dto.UserModel{
+				1: {ID: 1, MerchantID: 1, Role: roles.RoleOwner},
+				2: {ID: 2, MerchantID: 1, Role: roles.RoleEmployee},
+				3: {ID: 3, MerchantID: 1, Role: roles.RoleEmployee},
+			}}
+			service := NewUserService(userDao, nil, planDaoMock{}, nil, nil, nil, nil, nil, settingDaoMock{}, nil, mcrypt.NewCrypto(), nil, nil, TokenLifetime{})
+
+			err := tc.call(service, tc.claims)
+			if tc.status == 0 {
+				assert.Nil(t, err)
+				return
+			}
+			assert.NotNil(t, err)
+			assert.Equal(t, tc.status, err.Status())
+			assert.Equal(t, roles.RoleEmployee, string(userDao.users[3].Role))
+			assert.Equal(t, roles.RoleOwner, string(userDao.users[1].Role))
+		})
+	}
+}
+
+func TestCustomRoleLimitedToAssignerPermissions(t *testing.T) {
+	// pemegang user.manage saja tidak boleh memasang role yang berisi permission lain
+	manager := mjwt.CustomClaim{Identity: 2, Merchant: 1, Permissions: []string{permissions.UserManage, permissions.ProductEdit}}
+	roleDao := roleDaoMock{roles: map[int]dto.RoleModel{
+		10: {ID: 10, MerchantID: 1, Name: "admin", Permissions: []string{permissions.UserManage, permissions.APIKeyManage}},
+		11: {ID: 11, MerchantID: 1, Name: "gudang", Permissions: []string{permissions.ProductEdit}},
+		12: {ID: 12, MerchantID: 2, Name: "lain", Permissions: []string{}},
+	}}
+
+	tests := []struct {
+		name   string
+		roleID int
+		status int
+	}{
+		{name: "role melebihi permission pemberi", roleID: 10, status: http.StatusForbidden},
+		{name: "role merchant lain", roleID: 12, status: http.StatusBadRequest},
+		{name: "role di dalam permission pemberi", roleID: 11},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			userDao := &userDaoMock{users: map[int]dto.UserModel{
+				2: {ID: 2, MerchantID: 1, Role: roles.RoleEmployee},
+			}}
+			service := NewUserService(userDao, nil, planDaoMock{}, nil, nil, nil, roleDao, nil, settingDaoMock{}, nil, mcrypt.NewCrypto(), nil, nil, TokenLifetime{})
+
+			_, errInsert := service.InsertUser(context.Background(), manager, dto.UserModel{Role: roles.RoleEmployee, CustomRoleID: tc.roleID, Password: "password"})
+			_, errEdit := service.EditUser(context.Background(), manager, dto.UserEditRequest{ID: 2, Role: roles.RoleEmployee, CustomRoleID: tc.roleID})
+			if tc.status == 0 {
+				assert.Nil(t, errInsert)
+				assert.Nil(t, errEdit)
+				return
+			}
+			if assert.NotNil(t, errInsert) && assert.NotNil(t, errEdit) {
+				assert.Equal(t, tc.status, errInsert.Status())
+				assert.Equal(t, tc.status, errEdit.Status())
+			}
+			assert.Equal(t, 1, len(userDao.users))
+			assert.Equal(t, 0, userDao.users[2].CustomRoleID)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package audit
+
+import (
+	"context"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+)
+
+const (
+	ActionCreate = "create"
+	ActionEdit   = "edit"
+	ActionDelete = "delete"
+	ActionRevoke = "revoke"
+
+	EntityMerchant        = "merchant"
+	EntityMerchantSetting = "merchant_setting"
+	EntityMerchantPlan    = "merchant_plan"
+	EntityPlan            = "plan"
+	EntityOutlet          = "outlet"
+	EntityUser            = "user"
+	EntityUserOutlet      = "user_outlet"
+	EntityRole            = "role"
+	EntityProduct         = "product"
+	EntityProductPrice    = "product_price"
+	EntityTax             = "tax"
+	EntityTaxSetting      = "tax_setting"
+	EntityDevice          = "device"
+	EntityAPIKey          = "api_key"
+)
+
+const (
+	// KeyIP key locals fiber berisi ip client, locals fiber dapat dibaca melalui ctx.Value(key)
+	// karena c.UserContext() yang diteruskan handler diturunkan dari c.Context() oleh middleware tracing
+	KeyIP = "audit_ip"
+	// KeyRequestID key locals fiber berisi request id yang dipasang middleware RequestID
+	KeyRequestID = "requestid"
+)
+
+// Actor pelaku perubahan, seluruh nilai 0 atau kosong apabila perubahan dilakukan oleh sistem
+type Actor struct {
+	UserID       int
+	Impersonator int
+	APIKey       int
+	Merchant     int
+	IP           string
+	RequestID    string
+}
+
+// ActorFromContext membaca claims, ip dan request id yang dipasang middleware pada context request
+func ActorFromContext(ctx context.Context) Actor {
+	var actor Actor
+	if claims, ok := ctx.Value(mjwt.CLAIMS).(*mjwt.CustomClaim); ok && claims != nil {
+		actor.UserID = claims.Identity
+		actor.Impersonator = claims.Impersonator
+		actor.APIKey = claims.APIKey
+		actor.Merchant = claims.Merchant
+	}
+	actor.IP, _ = ctx.Value(KeyIP).(string)
+	actor.RequestID, _ = ctx.Value(KeyRequestID).(string)
+	return actor
+}
@@ -0,0 +1,62 @@
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// ignoredFields tidak dicatat karena selalu berubah pada setiap edit
+var ignoredFields = map[string]bool{
+	"updated_at": true,
+}
+
+// Change nilai field sebelum dan sesudah perubahan, before kosong pada create dan after kosong pada delete
+type Change struct {
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+// Diff membandingkan representasi json before dan after lalu mengembalikan field yang berubah saja.
+// Field dengan tag json:"-" (password, hash, secret) otomatis tidak ikut tercatat. Nil berarti entitas
+// belum ada (create) atau sudah tidak ada (delete)
+func Diff(before interface{}, after interface{}) (map[string]Change, error) {
+	beforeMap, err := toMap(before)
+	if err != nil {
+		return nil, err
+	}
+	afterMap, err := toMap(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]Change)
+	for key, beforeValue := range beforeMap {
+		afterValue, exist := afterMap[key]
+		if ignoredFields[key] || (exist && reflect.DeepEqual(beforeValue, afterValue)) {
+			continue
+		}
+		changes[key] = Change{Before: beforeValue, After: afterValue}
+	}
+	for key, afterValue := range afterMap {
+		if _, exist := beforeMap[key]; exist || ignoredFields[key] {
+			continue
+		}
+		changes[key] = Change{After: afterValue}
+	}
+	return changes, nil
+}
+
+func toMap(value interface{}) (map[string]interface{}, error) {
+	res := make(map[string]interface{})
+	if value == nil || (reflect.ValueOf(value).Kind() == reflect.Ptr && reflect.ValueOf(value).IsNil()) {
+		return res, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
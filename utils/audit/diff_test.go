@@ -0,0 +1,47 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type entity struct {
+	ID        int      `json:"id"`
+	Name      string   `json:"name"`
+	Price     int      `json:"price"`
+	Tags      []string `json:"tags"`
+	Secret    string   `json:"-"`
+	UpdatedAt int64    `json:"updated_at"`
+}
+
+func TestDiffEdit(t *testing.T) {
+	before := entity{ID: 1, Name: "kopi", Price: 10000, Tags: []string{"a"}, Secret: "x", UpdatedAt: 1}
+	after := entity{ID: 1, Name: "kopi", Price: 12000, Tags: []string{"a", "b"}, Secret: "y", UpdatedAt: 2}
+
+	changes, err := Diff(before, after)
+	assert.Nil(t, err)
+	assert.Len(t, changes, 2)
+	assert.Equal(t, Change{Before: float64(10000), After: float64(12000)}, changes["price"])
+	assert.Equal(t, Change{Before: []interface{}{"a"}, After: []interface{}{"a", "b"}}, changes["tags"])
+}
+
+func TestDiffNoChange(t *testing.T) {
+	changes, err := Diff(entity{ID: 1, UpdatedAt: 1}, &entity{ID: 1, UpdatedAt: 2})
+	assert.Nil(t, err)
+	assert.Len(t, changes, 0)
+}
+
+func TestDiffCreateAndDelete(t *testing.T) {
+	var nilEntity *entity
+	created, err := Diff(nilEntity, entity{ID: 1, Name: "kopi"})
+	assert.Nil(t, err)
+	assert.Equal(t, Change{After: "kopi"}, created["name"])
+	assert.Len(t, created, 4)
+
+	deleted, err := Diff(entity{ID: 1, Name: "kopi"}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, Change{Before: "kopi"}, deleted["name"])
+	_, ok := deleted["updated_at"]
+	assert.False(t, ok)
+}
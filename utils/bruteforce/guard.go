@@ -0,0 +1,152 @@
+// Package bruteforce mencatat kegagalan login per key (email, ip, dll) di memory dan
+// memberikan jeda yang bertambah secara eksponensial setelah beberapa kegagalan
+package bruteforce
+
+import (
+	"sync"
+	"time"
+)
+
+// Rule aturan backoff untuk satu jenis key
+type Rule struct {
+	Name string
+	// Free jumlah kegagalan yang tidak dikenai jeda
+	Free int
+	// Base jeda setelah kegagalan pertama melewati Free, berlipat dua untuk setiap kegagalan berikutnya
+	Base time.Duration
+	// Max batas atas jeda
+	Max time.Duration
+	// Window catatan kegagalan dihapus apabila tidak ada kegagalan baru selama durasi ini
+	Window time.Duration
+}
+
+var (
+	// RuleEmail percobaan login untuk satu email
+	RuleEmail = Rule{Name: "email", Free: 3, Base: 2 * time.Second, Max: 15 * time.Minute, Window: time.Hour}
+	// RuleIP percobaan login dari satu ip, lebih longgar karena satu ip dapat dipakai banyak user (NAT)
+	RuleIP = Rule{Name: "ip", Free: 20, Base: 2 * time.Second, Max: 15 * time.Minute, Window: time.Hour}
+	// RulePin percobaan login PIN untuk satu user
+	RulePin = Rule{Name: "pin", Free: 3, Base: 5 * time.Second, Max: 30 * time.Minute, Window: time.Hour}
+)
+
+type GuardAssumer interface {
+	// Check mengembalikan sisa waktu tunggu apabila key sedang diblokir
+	Check(rule Rule, key string) (time.Duration, bool)
+	// Fail mencatat kegagalan dan mengembalikan jumlah kegagalan serta jeda yang diberikan
+	Fail(rule Rule, key string) (int, time.Duration)
+	// Reset menghapus catatan kegagalan, dipanggil ketika login berhasil
+	Reset(rule Rule, key string)
+}
+
+type entry struct {
+	failures     int
+	lastFailure  time.Time
+	blockedUntil time.Time
+}
+
+type guard struct {
+	mu      sync.Mutex
+	entries map[string]*entry
+	now     func() time.Time
+	sweepAt time.Time
+}
+
+func NewGuard() GuardAssumer {
+	return newGuard(time.Now)
+}
+
+func newGuard(now func() time.Time) *guard {
+	return &guard{
+		entries: make(map[string]*entry),
+		now:     now,
+	}
+}
+
+func (g *guard) Check(rule Rule, key string) (time.Duration, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	e, ok := g.get(rule, key)
+	if !ok {
+		return 0, false
+	}
+	wait := e.blockedUntil.Sub(g.now())
+	if wait <= 0 {
+		return 0, false
+	}
+	return wait, true
+}
+
+func (g *guard) Fail(rule Rule, key string) (int, time.Duration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	g.sweep(now)
+
+	e, ok := g.get(rule, key)
+	if !ok {
+		e = &entry{}
+		g.entries[rule.Name+":"+key] = e
+	}
+	e.failures++
+	e.lastFailure = now
+
+	delay := Delay(rule, e.failures)
+	if delay > 0 {
+		e.blockedUntil = now.Add(delay)
+	}
+	return e.failures, delay
+}
+
+func (g *guard) Reset(rule Rule, key string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.entries, rule.Name+":"+key)
+}
+
+// get mengambil entry yang masih berada dalam window
+func (g *guard) get(rule Rule, key string) (*entry, bool) {
+	id := rule.Name + ":" + key
+	e, ok := g.entries[id]
+	if !ok {
+		return nil, false
+	}
+	if g.now().Sub(e.lastFailure) > rule.Window && !g.now().Before(e.blockedUntil) {
+		delete(g.entries, id)
+		return nil, false
+	}
+	return e, true
+}
+
+// sweep membersihkan entry lama paling sering sekali per menit agar map tidak terus membesar
+func (g *guard) sweep(now time.Time) {
+	if now.Before(g.sweepAt) {
+		return
+	}
+	g.sweepAt = now.Add(time.Minute)
+	for id, e := range g.entries {
+		if now.Sub(e.lastFailure) > 24*time.Hour && now.After(e.blockedUntil) {
+			delete(g.entries, id)
+		}
+	}
+}
+
+// Delay jeda untuk kegagalan ke-n sesuai rule
+func Delay(rule Rule, failures int) time.Duration {
+	over := failures - rule.Free
+	if over <= 0 {
+		return 0
+	}
+	delay := rule.Base
+	for i := 1; i < over; i++ {
+		delay *= 2
+		if delay >= rule.Max {
+			return rule.Max
+		}
+	}
+	if delay > rule.Max {
+		return rule.Max
+	}
+	return delay
+}
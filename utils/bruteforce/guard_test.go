@@ -0,0 +1,56 @@
+package bruteforce
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelay(t *testing.T) {
+	rule := Rule{Free: 3, Base: time.Second, Max: 10 * time.Second}
+
+	assert.Equal(t, time.Duration(0), Delay(rule, 3))
+	assert.Equal(t, time.Second, Delay(rule, 4))
+	assert.Equal(t, 2*time.Second, Delay(rule, 5))
+	assert.Equal(t, 8*time.Second, Delay(rule, 7))
+	assert.Equal(t, 10*time.Second, Delay(rule, 8))
+	assert.Equal(t, 10*time.Second, Delay(rule, 100))
+}
+
+func TestGuard(t *testing.T) {
+	now := time.Unix(1631341964, 0)
+	g := newGuard(func() time.Time { return now })
+	rule := Rule{Name: "email", Free: 2, Base: time.Second, Max: time.Minute, Window: time.Hour}
+
+	g.Fail(rule, "a@example.com")
+	g.Fail(rule, "a@example.com")
+	_, blocked := g.Check(rule, "a@example.com")
+	assert.False(t, blocked)
+
+	failures, delay := g.Fail(rule, "a@example.com")
+	assert.Equal(t, 3, failures)
+	assert.Equal(t, time.Second, delay)
+	wait, blocked := g.Check(rule, "a@example.com")
+	assert.True(t, blocked)
+	assert.Equal(t, time.Second, wait)
+
+	// key dan rule lain tidak terpengaruh
+	_, blocked = g.Check(rule, "b@example.com")
+	assert.False(t, blocked)
+	_, blocked = g.Check(Rule{Name: "ip", Window: time.Hour}, "a@example.com")
+	assert.False(t, blocked)
+
+	now = now.Add(2 * time.Second)
+	_, blocked = g.Check(rule, "a@example.com")
+	assert.False(t, blocked)
+
+	// setelah window berakhir hitungan dimulai dari awal
+	now = now.Add(2 * time.Hour)
+	failures, _ = g.Fail(rule, "a@example.com")
+	assert.Equal(t, 1, failures)
+
+	g.Reset(rule, "a@example.com")
+	failures, _ = g.Fail(rule, "a@example.com")
+	assert.Equal(t, 1, failures)
+}
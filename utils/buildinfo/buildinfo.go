@@ -0,0 +1,25 @@
+package buildinfo
+
+import "runtime"
+
+// Commit dan BuildTime diisi saat build melalui ldflags, contoh
+// go build -ldflags "-X github.com/muchlist/mini_pos/utils/buildinfo.Commit=$(git rev-parse --short HEAD) -X github.com/muchlist/mini_pos/utils/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	Commit    = "unknown"
+	BuildTime = "unknown"
+)
+
+// Info informasi build binary yang sedang berjalan
+type Info struct {
+	Commit    string `json:"commit" example:"7acacac"`
+	BuildTime string `json:"build_time" example:"2021-10-19T08:00:00Z"`
+	GoVersion string `json:"go_version" example:"go1.17"`
+}
+
+func Get() Info {
+	return Info{
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+}
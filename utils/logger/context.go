@@ -0,0 +1,49 @@
+package logger
+
+import (
+	"context"
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// requestIDKey key locals fiber berisi request id, sama dengan audit.KeyRequestID
+const requestIDKey = "requestid"
+
+// ContextFields field korelasi dari context request: request id, user, merchant dan trace id.
+// Context tanpa request (misalnya perintah admin) menghasilkan slice kosong
+func ContextFields(ctx context.Context) []zap.Field {
+	if ctx == nil {
+		return nil
+	}
+	fields := make([]zap.Field, 0, 4)
+	if requestID, ok := ctx.Value(requestIDKey).(string); ok && requestID != "" {
+		fields = append(fields, zap.String("request_id", requestID))
+	}
+	if claims, ok := ctx.Value(mjwt.CLAIMS).(*mjwt.CustomClaim); ok && claims != nil {
+		fields = append(fields, zap.Int("user_id", claims.Identity), zap.Int("merchant_id", claims.Merchant))
+		if claims.Impersonator != 0 {
+			fields = append(fields, zap.Int("impersonator_id", claims.Impersonator))
+		}
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		fields = append(fields, zap.String("trace_id", spanContext.TraceID().String()))
+	}
+	return fields
+}
+
+// InfoCtx sama dengan Info ditambah field korelasi dari ctx
+func InfoCtx(ctx context.Context, msg string, tags ...zap.Field) {
+	Info(msg, append(ContextFields(ctx), tags...)...)
+}
+
+// WarnCtx sama dengan Warn ditambah field korelasi dari ctx
+func WarnCtx(ctx context.Context, msg string, tags ...zap.Field) {
+	Warn(msg, append(ContextFields(ctx), tags...)...)
+}
+
+// ErrorCtx sama dengan Error ditambah field korelasi dari ctx, dipakai dari handler sampai dao
+// agar log error dapat dicari berdasarkan request id yang diterima client
+func ErrorCtx(ctx context.Context, msg string, err error, tags ...zap.Field) {
+	Error(msg, err, append(ContextFields(ctx), tags...)...)
+}
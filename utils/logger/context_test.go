@@ -0,0 +1,36 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/muchlist/mini_pos/utils/mjwt"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap/zapcore"
+)
+
+func fieldMap(ctx context.Context) map[string]interface{} {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range ContextFields(ctx) {
+		field.AddTo(enc)
+	}
+	return enc.Fields
+}
+
+func TestContextFields(t *testing.T) {
+	assert.Empty(t, fieldMap(context.Background()))
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}))
+	ctx = context.WithValue(ctx, requestIDKey, "req-1")
+	ctx = context.WithValue(ctx, mjwt.CLAIMS, &mjwt.CustomClaim{Identity: 7, Merchant: 3})
+
+	assert.Equal(t, map[string]interface{}{
+		"request_id":  "req-1",
+		"user_id":     int64(7),
+		"merchant_id": int64(3),
+		"trace_id":    "4bf92f3577b34da6a3ce929d0e0e4736",
+	}, fieldMap(ctx))
+}
@@ -16,10 +16,11 @@ type logger struct {
 	log *zap.Logger
 }
 
-func InitLogger() {
+// InitLogger menyiapkan logger global sesuai level dan tujuan output pada konfigurasi
+func InitLogger(cfg configs.LogConfig) {
 	logConfig := zap.Config{
-		OutputPaths: []string{getOutput()},
-		Level:       zap.NewAtomicLevelAt(getLevel()),
+		OutputPaths: []string{getOutput(cfg.Output)},
+		Level:       zap.NewAtomicLevelAt(getLevel(cfg.Level)),
 		Encoding:    "json",
 		EncoderConfig: zapcore.EncoderConfig{
 			LevelKey:     "lvl",
@@ -37,8 +38,8 @@ func InitLogger() {
 	}
 }
 
-func getLevel() zapcore.Level {
-	switch strings.ToLower(strings.TrimSpace(configs.Config.LOGLEVEL)) {
+func getLevel(level string) zapcore.Level {
+	switch strings.ToLower(strings.TrimSpace(level)) {
 	case "debug":
 		return zap.DebugLevel
 	case "info":
@@ -50,8 +51,8 @@ func getLevel() zapcore.Level {
 	}
 }
 
-func getOutput() string {
-	output := strings.TrimSpace(configs.Config.LOGOUTPUT)
+func getOutput(output string) string {
+	output = strings.TrimSpace(output)
 	if output == "" {
 		return "stdout"
 	}
@@ -80,6 +81,12 @@ func Info(msg string, tags ...zap.Field) {
 	_ = log.log.Sync()
 }
 
+// Warn dipakai untuk kejadian yang perlu diperhatikan namun bukan error aplikasi, misalnya event keamanan
+func Warn(msg string, tags ...zap.Field) {
+	log.log.Warn(msg, tags...)
+	_ = log.log.Sync()
+}
+
 func Error(msg string, err error, tags ...zap.Field) {
 	tags = append(tags, zap.NamedError("error", err))
 	log.log.Error(msg, tags...)
@@ -0,0 +1,166 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"go.uber.org/zap"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// Message email yang akan dikirim, body berupa text biasa
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer pengirim email, implementasi dapat diganti sesuai lingkungan (smtp, log)
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// defaultTimeout batas waktu koneksi sampai email selesai dikirim apabila SMTPConfig.Timeout 0
+const defaultTimeout = 10 * time.Second
+
+// SMTPConfig konfigurasi server SMTP, Username kosong berarti tanpa autentikasi
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+	// Timeout batas waktu dial sampai email selesai dikirim, deadline ctx yang lebih cepat dipakai lebih dulu
+	Timeout time.Duration
+}
+
+// NewSMTPMailer membuat Mailer yang mengirim email melalui server SMTP
+func NewSMTPMailer(cfg SMTPConfig) Mailer {
+	return &smtpMailer{
+		cfg: cfg,
+	}
+}
+
+type smtpMailer struct {
+	cfg SMTPConfig
+}
+
+func (s *smtpMailer) Send(ctx context.Context, msg Message) error {
+	if err := s.send(ctx, msg); err != nil {
+		return fmt.Errorf("gagal mengirim email ke %s: %w", msg.To, err)
+	}
+	return nil
+}
+
+// send sama dengan smtp.SendMail namun dial dan seluruh percakapan smtp dibatasi timeout dan ctx,
+// koneksi ditutup ketika ctx dibatalkan agar request tidak menunggu server smtp yang macet
+func (s *smtpMailer) send(ctx context.Context, msg Message) error {
+	timeout := s.cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(s.cfg.Host, s.cfg.Port))
+	if err != nil {
+		return err
+	}
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		_ = conn.Close()
+		return err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+		case <-done:
+		}
+	}()
+
+	client, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.cfg.Host}); err != nil {
+			return err
+		}
+	}
+	if s.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(s.cfg.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(msg.To); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(buildMessage(s.cfg.From, msg)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// NewLogMailer membuat Mailer yang hanya menuliskan email ke log apabila SMTP belum dikonfigurasi.
+// Isi email memuat token aktif (reset password, verifikasi) sehingga hanya ditulis apabila logBody true,
+// opsi yang hanya boleh dipakai saat development
+func NewLogMailer(logBody bool) Mailer {
+	return &logMailer{logBody: logBody}
+}
+
+type logMailer struct {
+	logBody bool
+}
+
+func (l *logMailer) Send(ctx context.Context, msg Message) error {
+	body := zap.Int("body_length", len(msg.Body))
+	if l.logBody {
+		body = zap.String("body", msg.Body)
+	}
+	logger.InfoCtx(ctx, "email tidak dikirim (log mailer)",
+		zap.String("to", msg.To),
+		zap.String("subject", msg.Subject),
+		body)
+	return nil
+}
+
+func buildMessage(from string, msg Message) []byte {
+	sb := strings.Builder{}
+	sb.WriteString("From: " + headerValue(from) + "\r\n")
+	sb.WriteString("To: " + headerValue(msg.To) + "\r\n")
+	sb.WriteString("Subject: " + headerValue(msg.Subject) + "\r\n")
+	sb.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	sb.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	sb.WriteString("\r\n")
+	sb.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	sb.WriteString("\r\n")
+	return []byte(sb.String())
+}
+
+// headerValue membuang karakter baris baru agar input tidak dapat menyisipkan header lain
+func headerValue(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
@@ -0,0 +1,124 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// smtpSink server SMTP minimal yang menyimpan isi DATA dari email yang diterima
+func smtpSink(t *testing.T) (host string, port string, received chan string) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	received = make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		write := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+		write("220 sink ready")
+
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				write("250 sink")
+			case strings.HasPrefix(cmd, "DATA"):
+				write("354 end with .")
+				var data strings.Builder
+				for {
+					dataLine, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if dataLine == ".\r\n" {
+						break
+					}
+					data.WriteString(dataLine)
+				}
+				received <- data.String()
+				write("250 ok")
+			case strings.HasPrefix(cmd, "QUIT"):
+				write("221 bye")
+				return
+			default:
+				write("250 ok")
+			}
+		}
+	}()
+
+	host, port, err = net.SplitHostPort(ln.Addr().String())
+	require.NoError(t, err)
+	return host, port, received
+}
+
+func TestSMTPMailerSend(t *testing.T) {
+	host, port, received := smtpSink(t)
+
+	m := NewSMTPMailer(SMTPConfig{
+		Host: host,
+		Port: port,
+		From: "noreply@minipos.local",
+	})
+
+	err := m.Send(context.Background(), Message{
+		To:      "owner@example.com",
+		Subject: "Verifikasi\r\nBcc: evil@example.com",
+		Body:    "baris 1\nbaris 2",
+	})
+	require.NoError(t, err)
+
+	data := <-received
+	assert.Contains(t, data, "To: owner@example.com\r\n")
+	assert.Contains(t, data, "Subject: VerifikasiBcc: evil@example.com\r\n")
+	assert.NotContains(t, data, "\r\nBcc:")
+	assert.Contains(t, data, "baris 1\r\nbaris 2")
+}
+
+func TestSMTPMailerSendFailed(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	_ = ln.Close()
+
+	m := NewSMTPMailer(SMTPConfig{Host: host, Port: port, From: "noreply@minipos.local"})
+	assert.Error(t, m.Send(context.Background(), Message{To: "owner@example.com"}))
+}
+
+func TestSMTPMailerSendTimeout(t *testing.T) {
+	// server menerima koneksi namun tidak pernah membalas greeting
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		conn, err := ln.Accept()
+		if err == nil {
+			t.Cleanup(func() { _ = conn.Close() })
+		}
+	}()
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+
+	m := NewSMTPMailer(SMTPConfig{Host: host, Port: port, From: "noreply@minipos.local", Timeout: time.Minute})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	assert.Error(t, m.Send(ctx, Message{To: "owner@example.com"}))
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
@@ -0,0 +1,44 @@
+package mcrypt
+
+import (
+	"crypto/subtle"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+	"strings"
+)
+
+const (
+	apiKeyPrefix    = "mpk_"
+	apiKeyPrefixLen = 12
+)
+
+// GenerateAPIKey membuat api key dengan format mpk_<prefix>_<secret>. Prefix disimpan apa adanya
+// untuk pencarian, secret hanya disimpan hash-nya
+func GenerateAPIKey() (key string, prefix string, secretHash string, apiErr rest_err.APIError) {
+	id, apiErr := NewID()
+	if apiErr != nil {
+		return "", "", "", apiErr
+	}
+	secret, secretHash, apiErr := GenerateToken()
+	if apiErr != nil {
+		return "", "", "", apiErr
+	}
+	prefix = id[:apiKeyPrefixLen]
+	return apiKeyPrefix + prefix + "_" + secret, prefix, secretHash, nil
+}
+
+// SplitAPIKey memisahkan prefix dan secret, ok false jika format api key tidak sesuai
+func SplitAPIKey(key string) (prefix string, secret string, ok bool) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return "", "", false
+	}
+	parts := strings.Split(strings.TrimPrefix(key, apiKeyPrefix), "_")
+	if len(parts) != 2 || len(parts[0]) != apiKeyPrefixLen || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// IsSecretMatch membandingkan secret dengan hash tersimpan dalam waktu konstan
+func IsSecretMatch(secret string, secretHash string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashToken(secret)), []byte(secretHash)) == 1
+}
@@ -0,0 +1,32 @@
+package mcrypt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateAndSplitAPIKey(t *testing.T) {
+	key, prefix, secretHash, err := GenerateAPIKey()
+	assert.Nil(t, err)
+
+	gotPrefix, secret, ok := SplitAPIKey(key)
+	assert.True(t, ok)
+	assert.Equal(t, prefix, gotPrefix)
+	assert.True(t, IsSecretMatch(secret, secretHash))
+	assert.False(t, IsSecretMatch(secret+"0", secretHash))
+}
+
+func TestSplitAPIKeyInvalid(t *testing.T) {
+	for _, key := range []string{
+		"",
+		"3f9a1c7d20be_abc",
+		"mpk_3f9a1c7d20be",
+		"mpk_3f9a_abc",
+		"mpk_3f9a1c7d20be_",
+		"mpk_3f9a1c7d20be_abc_def",
+	} {
+		_, _, ok := SplitAPIKey(key)
+		assert.False(t, ok, key)
+	}
+}
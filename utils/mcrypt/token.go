@@ -0,0 +1,34 @@
+package mcrypt
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/muchlist/mini_pos/utils/rest_err"
+)
+
+// GenerateToken membuat token acak yang dikirim ke user beserta hash-nya untuk disimpan di database,
+// token asli tidak pernah disimpan
+func GenerateToken() (token string, hash string, apiErr rest_err.APIError) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", rest_err.NewInternalServerError("Crypto error", err)
+	}
+	token = hex.EncodeToString(b)
+	return token, HashToken(token), nil
+}
+
+// NewID membuat id acak 32 karakter hex, dipakai sebagai jti maupun id family token
+func NewID() (string, rest_err.APIError) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", rest_err.NewInternalServerError("Crypto error", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// HashToken hash sha256 dari token, dipakai untuk mencari token di database
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
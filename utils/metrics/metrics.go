@@ -0,0 +1,132 @@
+package metrics
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/valyala/fasthttp/fasthttpadaptor"
+	"strconv"
+	"time"
+)
+
+const namespace = "minipos"
+
+const (
+	LoginSuccess       = "success"
+	LoginUnknownEmail  = "unknown_email"
+	LoginWrongPassword = "wrong_password"
+
+	// unmatchedRoute label route untuk request yang tidak cocok dengan route manapun,
+	// path asli tidak dipakai agar jumlah label tidak membengkak
+	unmatchedRoute = "unmatched"
+)
+
+var (
+	registry = prometheus.NewRegistry()
+
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Jumlah request http per route dan status code.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Lama pemrosesan request http per route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	logins = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "logins_total",
+		Help:      "Jumlah login per merchant dan hasil, merchant 0 apabila email tidak terdaftar.",
+	}, []string{"merchant", "result"})
+
+	productsCreated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "products_created_total",
+		Help:      "Jumlah product yang dibuat per merchant.",
+	}, []string{"merchant"})
+
+	imagesUploaded = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "images_uploaded_total",
+		Help:      "Jumlah gambar yang diupload per merchant dan jenis.",
+	}, []string{"merchant", "kind"})
+
+	imageBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "images_uploaded_bytes_total",
+		Help:      "Total ukuran gambar yang diupload per jenis.",
+	}, []string{"kind"})
+)
+
+func init() {
+	registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		logins,
+		productsCreated,
+		imagesUploaded,
+		imageBytes,
+	)
+}
+
+// Register mendaftarkan collector tambahan, misalnya NewPoolCollector
+func Register(collector prometheus.Collector) {
+	registry.MustRegister(collector)
+}
+
+// Middleware mencatat jumlah dan lama request berdasarkan template route, misalnya /api/v1/products/:id
+func Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			// error yang belum ditulis ke response akan diubah oleh error handler fiber
+			if fiberErr, ok := err.(*fiber.Error); ok {
+				status = fiberErr.Code
+			} else {
+				status = fiber.StatusInternalServerError
+			}
+		}
+
+		route := c.Route().Path
+		if status == fiber.StatusNotFound && route == "/" && c.Path() != "/" {
+			route = unmatchedRoute
+		}
+
+		httpRequests.WithLabelValues(c.Method(), route, strconv.Itoa(status)).Inc()
+		httpDuration.WithLabelValues(c.Method(), route).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// Handler menampilkan seluruh metric dalam format prometheus
+func Handler() fiber.Handler {
+	handler := fasthttpadaptor.NewFastHTTPHandler(promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	return func(c *fiber.Ctx) error {
+		handler(c.Context())
+		return nil
+	}
+}
+
+// Login mencatat hasil login, merchantID 0 apabila user tidak ditemukan
+func Login(merchantID int, result string) {
+	logins.WithLabelValues(strconv.Itoa(merchantID), result).Inc()
+}
+
+func ProductCreated(merchantID int) {
+	productsCreated.WithLabelValues(strconv.Itoa(merchantID)).Inc()
+}
+
+// ImageUploaded kind adalah folder gambar, products atau merchants
+func ImageUploaded(merchantID int, kind string, size int64) {
+	imagesUploaded.WithLabelValues(strconv.Itoa(merchantID), kind).Inc()
+	imageBytes.WithLabelValues(kind).Add(float64(size))
+}
@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddlewareUsesRouteTemplate(t *testing.T) {
+	app := fiber.New()
+	app.Use(Middleware())
+	app.Get("/products/:id", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+	app.Get("/metrics", Handler())
+
+	for _, path := range []string{"/products/1", "/products/2", "/tidak-ada"} {
+		_, err := app.Test(httptest.NewRequest("GET", path, nil))
+		assert.Nil(t, err)
+	}
+
+	res, err := app.Test(httptest.NewRequest("GET", "/metrics", nil))
+	assert.Nil(t, err)
+	body, _ := ioutil.ReadAll(res.Body)
+
+	assert.Contains(t, string(body), `minipos_http_requests_total{method="GET",route="/products/:id",status="200"} 2`)
+	assert.Contains(t, string(body), `minipos_http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	assert.NotContains(t, string(body), "/products/1")
+}
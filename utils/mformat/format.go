@@ -0,0 +1,120 @@
+package mformat
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultCurrency  = "IDR"
+	DefaultMinorUnit = 0
+	DefaultTimezone  = "Asia/Jakarta"
+	dateTimeLayout   = "02-01-2006 15:04 MST"
+)
+
+var currencySymbol = map[string]string{
+	"IDR": "Rp",
+	"USD": "$",
+	"SGD": "S$",
+	"MYR": "RM",
+}
+
+// GetCashRoundingAvailable pembulatan pembayaran tunai yang didukung, 0 berarti tanpa pembulatan
+func GetCashRoundingAvailable() []int {
+	return []int{0, 100, 500}
+}
+
+// Formatter memformat uang dan waktu sesuai pengaturan merchant
+type Formatter struct {
+	Currency     string
+	MinorUnit    int
+	CashRounding int
+	Location     *time.Location
+}
+
+// NewFormatter membuat Formatter, timezone yang tidak dikenali akan memakai DefaultTimezone
+func NewFormatter(currency string, minorUnit int, timezone string, cashRounding int) Formatter {
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		loc, err = time.LoadLocation(DefaultTimezone)
+		if err != nil {
+			loc = time.UTC
+		}
+	}
+	return Formatter{
+		Currency:     currency,
+		MinorUnit:    minorUnit,
+		CashRounding: cashRounding,
+		Location:     loc,
+	}
+}
+
+// Money amount dalam satuan terkecil (minor unit) dirubah menjadi teks,
+// contoh IDR minor 0 : 1050000 -> "Rp1.050.000", USD minor 2 : 105050 -> "$1.050,50"
+func (f Formatter) Money(amount int) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	divisor := 1
+	for i := 0; i < f.MinorUnit; i++ {
+		divisor *= 10
+	}
+
+	major := groupThousand(amount / divisor)
+	if f.MinorUnit > 0 {
+		major = fmt.Sprintf("%s,%0*d", major, f.MinorUnit, amount%divisor)
+	}
+
+	symbol, ok := currencySymbol[f.Currency]
+	if !ok {
+		symbol = f.Currency + " "
+	}
+	return sign + symbol + major
+}
+
+// RoundCash membulatkan amount ke kelipatan CashRounding terdekat untuk pembayaran tunai
+func (f Formatter) RoundCash(amount int) int {
+	if f.CashRounding <= 0 {
+		return amount
+	}
+	remainder := amount % f.CashRounding
+	if remainder*2 >= f.CashRounding {
+		return amount - remainder + f.CashRounding
+	}
+	return amount - remainder
+}
+
+// Time merubah unix detik menjadi teks sesuai timezone merchant
+func (f Formatter) Time(unix int64) string {
+	if unix == 0 {
+		return ""
+	}
+	return time.Unix(unix, 0).In(f.Location).Format(dateTimeLayout)
+}
+
+func groupThousand(number int) string {
+	text := fmt.Sprintf("%d", number)
+	if len(text) <= 3 {
+		return text
+	}
+
+	sb := strings.Builder{}
+	firstGroup := len(text) % 3
+	if firstGroup > 0 {
+		sb.WriteString(text[:firstGroup])
+	}
+	for i := firstGroup; i < len(text); i += 3 {
+		if sb.Len() > 0 {
+			sb.WriteString(".")
+		}
+		sb.WriteString(text[i : i+3])
+	}
+	return sb.String()
+}
@@ -0,0 +1,38 @@
+package mformat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoney(t *testing.T) {
+	idr := NewFormatter("IDR", 0, "Asia/Jakarta", 0)
+	assert.Equal(t, "Rp0", idr.Money(0))
+	assert.Equal(t, "Rp999", idr.Money(999))
+	assert.Equal(t, "Rp1.050.000", idr.Money(1050000))
+	assert.Equal(t, "-Rp10.000", idr.Money(-10000))
+
+	usd := NewFormatter("USD", 2, "UTC", 0)
+	assert.Equal(t, "$1.050,05", usd.Money(105005))
+
+	other := NewFormatter("JPY", 0, "Asia/Tokyo", 0)
+	assert.Equal(t, "JPY 12.000", other.Money(12000))
+}
+
+func TestRoundCash(t *testing.T) {
+	assert.Equal(t, 10049, NewFormatter("IDR", 0, "", 0).RoundCash(10049))
+	assert.Equal(t, 10000, NewFormatter("IDR", 0, "", 100).RoundCash(10049))
+	assert.Equal(t, 10100, NewFormatter("IDR", 0, "", 100).RoundCash(10050))
+	assert.Equal(t, 10000, NewFormatter("IDR", 0, "", 500).RoundCash(10249))
+	assert.Equal(t, 10500, NewFormatter("IDR", 0, "", 500).RoundCash(10250))
+}
+
+func TestTime(t *testing.T) {
+	f := NewFormatter("IDR", 0, "Asia/Makassar", 0)
+	assert.Equal(t, "11-09-2021 14:32 WITA", f.Time(1631341964))
+	assert.Equal(t, "", f.Time(0))
+
+	// timezone tidak valid memakai default
+	assert.Equal(t, DefaultTimezone, NewFormatter("IDR", 0, "Mars/Olympus", 0).Location.String())
+}
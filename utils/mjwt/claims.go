@@ -1,6 +1,7 @@
 package mjwt
 
 import (
+	"github.com/muchlist/mini_pos/configs/permissions"
 	"time"
 )
 
@@ -9,6 +10,8 @@ type TokenType string
 const (
 	Access  TokenType = "Access"
 	Refresh TokenType = "Refresh"
+	// Challenge token sementara setelah password benar namun masih memerlukan kode 2FA
+	Challenge TokenType = "Challenge"
 )
 
 type CustomClaim struct {
@@ -21,4 +24,29 @@ type CustomClaim struct {
 	Role        string
 	Merchant    int
 	Outlet      int
+	// Impersonator berisi ID super user apabila token dibuat melalui impersonate, 0 jika bukan
+	Impersonator int
+	// TokenID (jti) id unik refresh token yang tersimpan di database
+	TokenID string
+	// Family id rangkaian refresh token sejak login, dipakai untuk rotasi dan logout
+	Family string
+	// Device berisi ID perangkat kasir apabila token dibuat melalui login PIN, token
+	// tersebut hanya berlaku untuk outlet perangkat
+	Device int
+	// Permissions daftar permission dari role bawaan atau custom role user saat token dibuat
+	Permissions []string
+	// APIKey berisi ID api key apabila identitas berasal dari header Authorization ApiKey,
+	// tidak pernah ditulis ke jwt
+	APIKey int
+}
+
+// Can return true jika token memiliki permission yang diminta
+func (c CustomClaim) Can(permission string) bool {
+	return permissions.Has(c.Permissions, permission)
+}
+
+// OutletBound return true jika identitas hanya berlaku untuk satu outlet (claims.Outlet),
+// yaitu token perangkat kasir dan api key yang dibatasi outlet
+func (c CustomClaim) OutletBound() bool {
+	return c.Device != 0 || (c.APIKey != 0 && c.Outlet != 0)
 }
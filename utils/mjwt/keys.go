@@ -0,0 +1,250 @@
+package mjwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v4"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+
+	rsaKeyBits    = 2048
+	keyFileExt    = ".pem"
+	activeKeyFile = "active"
+	pemTypePKCS8  = "PRIVATE KEY"
+)
+
+// SigningKey pasangan kunci asimetris untuk menandatangani dan memverifikasi token
+type SigningKey struct {
+	KID     string
+	Alg     string
+	private crypto.Signer
+}
+
+// Public kunci publik untuk verifikasi
+func (k SigningKey) Public() crypto.PublicKey {
+	return k.private.Public()
+}
+
+func (k SigningKey) method() jwt.SigningMethod {
+	if k.Alg == AlgEdDSA {
+		return jwt.SigningMethodEdDSA
+	}
+	return jwt.SigningMethodRS256
+}
+
+// KeySet seluruh kunci pada direktori kunci. Active dipakai untuk menandatangani token baru,
+// seluruh kunci dipakai untuk verifikasi sehingga token lama tetap berlaku selama rotasi
+type KeySet struct {
+	Active *SigningKey
+	Keys   map[string]SigningKey
+}
+
+// GenerateKey membuat kunci baru dengan algoritma RS256 atau EdDSA
+func GenerateKey(alg string) (*SigningKey, error) {
+	var signer crypto.Signer
+	switch alg {
+	case AlgRS256:
+		key, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return nil, err
+		}
+		signer = key
+	case AlgEdDSA:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		signer = key
+	default:
+		return nil, fmt.Errorf("algoritma %s tidak didukung, gunakan %s atau %s", alg, AlgRS256, AlgEdDSA)
+	}
+
+	kid, err := keyID(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+	return &SigningKey{KID: kid, Alg: alg, private: signer}, nil
+}
+
+// keyID kid diturunkan dari hash kunci publik sehingga selalu sama untuk kunci yang sama
+func keyID(public crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+// SaveKey menyimpan private key dalam format PKCS8 PEM dengan nama <kid>.pem
+func SaveKey(dir string, key *SigningKey) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key.private)
+	if err != nil {
+		return err
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: pemTypePKCS8, Bytes: der})
+	return ioutil.WriteFile(filepath.Join(dir, key.KID+keyFileExt), data, 0600)
+}
+
+// SetActiveKey menandai kid sebagai kunci penandatangan token baru
+func SetActiveKey(dir string, kid string) error {
+	if _, err := os.Stat(filepath.Join(dir, kid+keyFileExt)); err != nil {
+		return fmt.Errorf("kunci %s tidak ditemukan", kid)
+	}
+	return ioutil.WriteFile(filepath.Join(dir, activeKeyFile), []byte(kid+"\n"), 0600)
+}
+
+// RemoveKey menghapus kunci yang tidak aktif, token yang ditandatangani kunci tersebut tidak lagi valid
+func RemoveKey(dir string, kid string) error {
+	set, err := LoadKeys(dir)
+	if err != nil {
+		return err
+	}
+	if set.Active != nil && set.Active.KID == kid {
+		return errors.New("kunci aktif tidak dapat dihapus, aktifkan kunci lain terlebih dahulu")
+	}
+	if _, ok := set.Keys[kid]; !ok {
+		return fmt.Errorf("kunci %s tidak ditemukan", kid)
+	}
+	return os.Remove(filepath.Join(dir, kid+keyFileExt))
+}
+
+// LoadKeys membaca seluruh kunci pada direktori, direktori yang tidak ada menghasilkan KeySet kosong
+func LoadKeys(dir string) (*KeySet, error) {
+	set := &KeySet{Keys: map[string]SigningKey{}}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return set, nil
+		}
+		return nil, err
+	}
+
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != keyFileExt {
+			continue
+		}
+		key, err := loadKey(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("gagal membaca kunci %s: %w", file.Name(), err)
+		}
+		set.Keys[key.KID] = *key
+	}
+
+	active, err := ioutil.ReadFile(filepath.Join(dir, activeKeyFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return set, nil
+		}
+		return nil, err
+	}
+	activeKID := strings.TrimSpace(string(active))
+	key, ok := set.Keys[activeKID]
+	if !ok {
+		return nil, fmt.Errorf("kunci aktif %s tidak ditemukan pada %s", activeKID, dir)
+	}
+	set.Active = &key
+
+	return set, nil
+}
+
+func loadKey(path string) (*SigningKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != pemTypePKCS8 {
+		return nil, errors.New("bukan PEM PKCS8 private key")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var alg string
+	var signer crypto.Signer
+	switch key := parsed.(type) {
+	case *rsa.PrivateKey:
+		alg, signer = AlgRS256, key
+	case ed25519.PrivateKey:
+		alg, signer = AlgEdDSA, key
+	default:
+		return nil, errors.New("jenis kunci tidak didukung")
+	}
+
+	kid, err := keyID(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+	return &SigningKey{KID: kid, Alg: alg, private: signer}, nil
+}
+
+// SortedKIDs daftar kid terurut agar keluaran jwks dan cli stabil
+func (s *KeySet) SortedKIDs() []string {
+	kids := make([]string, 0, len(s.Keys))
+	for kid := range s.Keys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+	return kids
+}
+
+// JWK representasi kunci publik sesuai RFC 7517
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS kunci publik seluruh kunci verifikasi untuk layanan lain yang memverifikasi token
+func (s *KeySet) JWKS() JWKS {
+	res := JWKS{Keys: make([]JWK, 0, len(s.Keys))}
+	for _, kid := range s.SortedKIDs() {
+		key := s.Keys[kid]
+		jwk := JWK{Kid: kid, Use: "sig", Alg: key.Alg}
+		switch public := key.Public().(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		res.Keys = append(res.Keys, jwk)
+	}
+	return res
+}
@@ -0,0 +1,73 @@
+package mjwt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyRotation(t *testing.T) {
+	dir := t.TempDir()
+	j := NewJwt()
+	claims := CustomClaim{Identity: 1, Name: "owner", ExtraMinute: 5, Type: Access, Role: "owner", Merchant: 1}
+
+	oldKey, err := GenerateKey(AlgRS256)
+	assert.Nil(t, err)
+	assert.Nil(t, SaveKey(dir, oldKey))
+	assert.Nil(t, SetActiveKey(dir, oldKey.KID))
+
+	keySet, err = LoadKeys(dir)
+	assert.Nil(t, err)
+	oldToken, apiErr := j.GenerateToken(claims)
+	assert.Nil(t, apiErr)
+
+	// rotasi ke kunci EdDSA, token lama tetap valid
+	newKey, err := GenerateKey(AlgEdDSA)
+	assert.Nil(t, err)
+	assert.Nil(t, SaveKey(dir, newKey))
+	assert.Nil(t, SetActiveKey(dir, newKey.KID))
+
+	keySet, err = LoadKeys(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, newKey.KID, keySet.Active.KID)
+	assert.Len(t, keySet.JWKS().Keys, 2)
+
+	newToken, apiErr := j.GenerateToken(claims)
+	assert.Nil(t, apiErr)
+	for _, tokenString := range []string{oldToken, newToken} {
+		token, apiErr := j.ValidateToken(tokenString)
+		assert.Nil(t, apiErr)
+		read, apiErr := j.ReadToken(token)
+		assert.Nil(t, apiErr)
+		assert.Equal(t, claims.Identity, read.Identity)
+	}
+
+	// kunci aktif tidak dapat dihapus, kunci lama dapat dihapus dan tokennya tidak lagi valid
+	assert.NotNil(t, RemoveKey(dir, newKey.KID))
+	assert.Nil(t, RemoveKey(dir, oldKey.KID))
+	keySet, err = LoadKeys(dir)
+	assert.Nil(t, err)
+	_, apiErr = j.ValidateToken(oldToken)
+	assert.NotNil(t, apiErr)
+}
+
+func TestLegacySecretToken(t *testing.T) {
+	j := NewJwt()
+	claims := CustomClaim{Identity: 1, Name: "owner", ExtraMinute: 5, Type: Access, Role: "owner"}
+
+	keySet = &KeySet{Keys: map[string]SigningKey{}}
+	secret = []byte("secret")
+	legacyToken, apiErr := j.GenerateToken(claims)
+	assert.Nil(t, apiErr)
+
+	// setelah kunci aktif dipasang token HS256 tanpa kid tetap diterima selama secret diisi
+	key, err := GenerateKey(AlgEdDSA)
+	assert.Nil(t, err)
+	keySet = &KeySet{Active: key, Keys: map[string]SigningKey{key.KID: *key}}
+	_, apiErr = j.ValidateToken(legacyToken)
+	assert.Nil(t, apiErr)
+
+	secret = nil
+	_, apiErr = j.ValidateToken(legacyToken)
+	assert.NotNil(t, apiErr)
+}
@@ -2,7 +2,6 @@ package mjwt
 
 import (
 	"github.com/golang-jwt/jwt/v4"
-	"github.com/muchlist/mini_pos/configs"
 	"github.com/muchlist/mini_pos/utils/rest_err"
 	"log"
 	"net/http"
@@ -10,34 +9,60 @@ import (
 )
 
 const (
-	CLAIMS       = "claims"
-	identityKey  = "identity"
-	nameKey      = "name"
-	rolesKey     = "roles"
-	tokenTypeKey = "type"
-	expKey       = "exp"
-	freshKey     = "fresh"
-	merchantKey  = "merchant"
-	outletKey    = "outlet"
+	CLAIMS          = "claims"
+	identityKey     = "identity"
+	nameKey         = "name"
+	rolesKey        = "roles"
+	tokenTypeKey    = "type"
+	expKey          = "exp"
+	freshKey        = "fresh"
+	merchantKey     = "merchant"
+	outletKey       = "outlet"
+	impersonatorKey = "impersonator"
+	tokenIDKey      = "jti"
+	familyKey       = "family"
+	deviceKey       = "device"
+	permissionsKey  = "perms"
 )
 
 const (
 	mappingError = "gagal mapping token"
 )
 
+const (
+	kidHeader = "kid"
+)
+
 var (
+	// secret kunci HS256, hanya dipakai untuk menandatangani apabila belum ada kunci aktif dan
+	// untuk memverifikasi token lama tanpa kid selama masa transisi
 	secret []byte
+	keySet = &KeySet{Keys: map[string]SigningKey{}}
 )
 
 func NewJwt() JWTAssumer {
 	return &jwtUtils{}
 }
 
-func Init() {
-	secret = []byte(configs.Config.SECRETKEY)
-	if string(secret) == "" {
-		log.Fatal("Secret key tidak boleh kosong, ENV : SECRET_KEY")
+// Init memuat kunci RS256/EdDSA dari keyDir. Token baru ditandatangani dengan kunci
+// aktif dan header kid, seluruh kunci pada direktori tetap dipakai untuk verifikasi
+func Init(secretKey string, keyDir string) {
+	secret = []byte(secretKey)
+
+	set, err := LoadKeys(keyDir)
+	if err != nil {
+		log.Fatalf("Gagal memuat kunci jwt : %s", err)
 	}
+	keySet = set
+
+	if keySet.Active == nil && len(secret) == 0 {
+		log.Fatal("Belum ada kunci jwt aktif dan secret key kosong, jalankan `keys rotate` atau isi ENV : BA_SECRET_KEY")
+	}
+}
+
+// GetJWKS kunci publik yang sedang dimuat untuk endpoint /.well-known/jwks.json
+func GetJWKS() JWKS {
+	return keySet.JWKS()
 }
 
 type JWTAssumer interface {
@@ -62,10 +87,31 @@ func (j *jwtUtils) GenerateToken(claims CustomClaim) (string, rest_err.APIError)
 	jwtClaim[freshKey] = claims.Fresh
 	jwtClaim[merchantKey] = claims.Merchant
 	jwtClaim[outletKey] = claims.Outlet
+	if claims.Impersonator != 0 {
+		jwtClaim[impersonatorKey] = claims.Impersonator
+	}
+	if claims.TokenID != "" {
+		jwtClaim[tokenIDKey] = claims.TokenID
+	}
+	if claims.Family != "" {
+		jwtClaim[familyKey] = claims.Family
+	}
+	if claims.Device != 0 {
+		jwtClaim[deviceKey] = claims.Device
+	}
+	if len(claims.Permissions) != 0 {
+		jwtClaim[permissionsKey] = claims.Permissions
+	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwtClaim)
-
-	signedToken, err := token.SignedString(secret)
+	var signedToken string
+	var err error
+	if active := keySet.Active; active != nil {
+		token := jwt.NewWithClaims(active.method(), jwtClaim)
+		token.Header[kidHeader] = active.KID
+		signedToken, err = token.SignedString(active.private)
+	} else {
+		signedToken, err = jwt.NewWithClaims(jwt.SigningMethodHS256, jwtClaim).SignedString(secret)
+	}
 	if err != nil {
 		return "", rest_err.NewInternalServerError("gagal menandatangani token", err)
 	}
@@ -114,6 +160,23 @@ func (j *jwtUtils) ReadToken(token *jwt.Token) (*CustomClaim, rest_err.APIError)
 		return nil, rest_err.NewInternalServerError(mappingError, nil)
 	}
 
+	// impersonator opsional, hanya ada pada token hasil impersonate
+	impersonatorID, _ := claims[impersonatorKey].(float64)
+	// jti dan family opsional, token lama maupun token impersonate tidak memilikinya
+	tokenID, _ := claims[tokenIDKey].(string)
+	family, _ := claims[familyKey].(string)
+	// device opsional, hanya ada pada token hasil login PIN
+	deviceID, _ := claims[deviceKey].(float64)
+	// permission opsional, token tanpa permission hanya dapat mengakses endpoint tanpa permission
+	var perms []string
+	if rawPerms, ok := claims[permissionsKey].([]interface{}); ok {
+		for _, p := range rawPerms {
+			if perm, ok := p.(string); ok {
+				perms = append(perms, perm)
+			}
+		}
+	}
+
 	customClaim := CustomClaim{
 		Identity: int(identity),
 		Name:     name,
@@ -123,6 +186,12 @@ func (j *jwtUtils) ReadToken(token *jwt.Token) (*CustomClaim, rest_err.APIError)
 		Fresh:    fresh,
 		Merchant: int(merchant),
 		Outlet:   int(outlet),
+
+		Impersonator: int(impersonatorID),
+		TokenID:      tokenID,
+		Family:       family,
+		Device:       int(deviceID),
+		Permissions:  perms,
 	}
 
 	return &customClaim, nil
@@ -130,13 +199,7 @@ func (j *jwtUtils) ReadToken(token *jwt.Token) (*CustomClaim, rest_err.APIError)
 
 // ValidateToken memvalidasi apakah token string masukan valid, termasuk memvalidasi apabila field exp nya kadaluarsa
 func (j *jwtUtils) ValidateToken(tokenString string) (*jwt.Token, rest_err.APIError) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		_, ok := token.Method.(*jwt.SigningMethodHMAC)
-		if !ok {
-			return nil, rest_err.NewAPIError("Token signing method salah", http.StatusUnprocessableEntity, "jwt_error", nil)
-		}
-		return secret, nil
-	})
+	token, err := jwt.Parse(tokenString, verificationKey)
 
 	// Jika expired akan muncul disini asalkan ada claims exp
 	if err != nil {
@@ -145,3 +208,24 @@ func (j *jwtUtils) ValidateToken(tokenString string) (*jwt.Token, rest_err.APIEr
 
 	return token, nil
 }
+
+// verificationKey memilih kunci verifikasi berdasarkan header kid, algoritma token harus sama dengan
+// algoritma kunci. Token tanpa kid hanya diterima sebagai HS256 apabila secret key diisi
+func verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header[kidHeader].(string)
+	if kid == "" {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok || len(secret) == 0 {
+			return nil, rest_err.NewAPIError("Token signing method salah", http.StatusUnprocessableEntity, "jwt_error", nil)
+		}
+		return secret, nil
+	}
+
+	key, ok := keySet.Keys[kid]
+	if !ok {
+		return nil, rest_err.NewAPIError("Kunci token tidak dikenal", http.StatusUnprocessableEntity, "jwt_error", nil)
+	}
+	if token.Method.Alg() != key.Alg {
+		return nil, rest_err.NewAPIError("Token signing method salah", http.StatusUnprocessableEntity, "jwt_error", nil)
+	}
+	return key.Public(), nil
+}
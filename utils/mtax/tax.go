@@ -0,0 +1,59 @@
+package mtax
+
+const (
+	// RateBase adalah pembagi rate pajak dalam basis poin, 1100 = 11%
+	RateBase = 10000
+
+	RoundHalfUp = "half_up"
+	RoundUp     = "up"
+	RoundDown   = "down"
+)
+
+func GetRoundingAvailable() []string {
+	return []string{RoundHalfUp, RoundUp, RoundDown}
+}
+
+// Breakdown rincian harga setelah perhitungan pajak
+type Breakdown struct {
+	Net   int
+	Tax   int
+	Gross int
+}
+
+// Calculate menghitung harga net, pajak dan gross dari price.
+// rate dalam basis poin (1100 = 11%), rate 0 berarti bebas pajak.
+// inclusive true berarti price sudah termasuk pajak, false berarti pajak ditambahkan diatas price.
+func Calculate(price int, rate int, inclusive bool, rounding string) Breakdown {
+	if rate <= 0 {
+		return Breakdown{Net: price, Tax: 0, Gross: price}
+	}
+
+	if inclusive {
+		net := divide(price*RateBase, RateBase+rate, rounding)
+		return Breakdown{Net: net, Tax: price - net, Gross: price}
+	}
+
+	tax := divide(price*rate, RateBase, rounding)
+	return Breakdown{Net: price, Tax: tax, Gross: price + tax}
+}
+
+// divide melakukan pembagian bilangan bulat sesuai aturan pembulatan
+func divide(numerator int, denominator int, rounding string) int {
+	quotient := numerator / denominator
+	remainder := numerator % denominator
+	if remainder == 0 {
+		return quotient
+	}
+
+	switch rounding {
+	case RoundUp:
+		return quotient + 1
+	case RoundDown:
+		return quotient
+	default:
+		if remainder*2 >= denominator {
+			return quotient + 1
+		}
+		return quotient
+	}
+}
@@ -0,0 +1,33 @@
+package mtax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculate(t *testing.T) {
+	tests := []struct {
+		name      string
+		price     int
+		rate      int
+		inclusive bool
+		rounding  string
+		expected  Breakdown
+	}{
+		{"bebas pajak", 10000, 0, false, RoundHalfUp, Breakdown{Net: 10000, Tax: 0, Gross: 10000}},
+		{"exclusive ppn 11%", 10000, 1100, false, RoundHalfUp, Breakdown{Net: 10000, Tax: 1100, Gross: 11100}},
+		{"exclusive half up", 1005, 1100, false, RoundHalfUp, Breakdown{Net: 1005, Tax: 111, Gross: 1116}},
+		{"exclusive down", 1005, 1100, false, RoundDown, Breakdown{Net: 1005, Tax: 110, Gross: 1115}},
+		{"exclusive up", 1001, 1100, false, RoundUp, Breakdown{Net: 1001, Tax: 111, Gross: 1112}},
+		{"inclusive ppn 11%", 11100, 1100, true, RoundHalfUp, Breakdown{Net: 10000, Tax: 1100, Gross: 11100}},
+		{"inclusive half up", 10000, 1100, true, RoundHalfUp, Breakdown{Net: 9009, Tax: 991, Gross: 10000}},
+		{"inclusive up", 10000, 1100, true, RoundUp, Breakdown{Net: 9010, Tax: 990, Gross: 10000}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Calculate(tt.price, tt.rate, tt.inclusive, tt.rounding))
+		})
+	}
+}
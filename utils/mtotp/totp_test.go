@@ -0,0 +1,57 @@
+package mtotp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// secret referensi RFC 6238 lampiran B ("12345678901234567890")
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCode(t *testing.T) {
+	tests := []struct {
+		unix     int64
+		expected string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		code, err := Code(rfcSecret, time.Unix(tt.unix, 0))
+		assert.Nil(t, err)
+		assert.Equal(t, tt.expected, code)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	code, _ := Code(rfcSecret, now)
+
+	assert.True(t, Validate(rfcSecret, code, now))
+	assert.True(t, Validate(rfcSecret, code, now.Add(Period*time.Second)))
+	assert.False(t, Validate(rfcSecret, code, now.Add(3*Period*time.Second)))
+	assert.False(t, Validate(rfcSecret, "12345", now))
+	assert.False(t, Validate("bukan-base32!", code, now))
+
+	step, ok := Match(rfcSecret, code, now.Add(Period*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, now.Unix()/Period, step)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	assert.Nil(t, err)
+	assert.Len(t, secret, 32)
+
+	uri := ProvisioningURI(secret, "MINI POS", "owner@example.com")
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/MINI%20POS:owner@example.com?"))
+	assert.Contains(t, uri, "secret="+secret)
+}
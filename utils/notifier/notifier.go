@@ -0,0 +1,55 @@
+package notifier
+
+import (
+	"context"
+	"github.com/muchlist/mini_pos/utils/logger"
+	"github.com/muchlist/mini_pos/utils/mailer"
+	"go.uber.org/zap"
+)
+
+// Notification pesan untuk user, To berisi alamat tujuan sesuai kanal (email)
+type Notification struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Notifier pengirim pemberitahuan ke user, implementasi dapat diganti tanpa merubah service
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
+
+// NewMailNotifier membuat Notifier yang mengirim pemberitahuan melalui email
+func NewMailNotifier(mail mailer.Mailer) Notifier {
+	return &mailNotifier{
+		mail: mail,
+	}
+}
+
+type mailNotifier struct {
+	mail mailer.Mailer
+}
+
+func (m *mailNotifier) Notify(ctx context.Context, notification Notification) error {
+	return m.mail.Send(ctx, mailer.Message{
+		To:      notification.To,
+		Subject: notification.Subject,
+		Body:    notification.Body,
+	})
+}
+
+// NewLogNotifier membuat Notifier yang hanya menuliskan pemberitahuan ke log,
+// isi pemberitahuan tidak ditulis karena dapat memuat token aktif
+func NewLogNotifier() Notifier {
+	return &logNotifier{}
+}
+
+type logNotifier struct{}
+
+func (l *logNotifier) Notify(ctx context.Context, notification Notification) error {
+	logger.InfoCtx(ctx, "pemberitahuan tidak dikirim (log notifier)",
+		zap.String("to", notification.To),
+		zap.String("subject", notification.Subject),
+		zap.Int("body_length", len(notification.Body)))
+	return nil
+}
@@ -0,0 +1,142 @@
+// Package ratelimit membatasi jumlah request per key (api key, user, merchant, ip) memakai
+// token bucket. Store bawaan menyimpan bucket di memory, instance yang berjalan lebih dari
+// satu dapat memakai Store bersama (misalnya redis) dengan mengimplementasikan interface Store
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// ByIdentity key api key atau user dari claims, ip apabila request tanpa token
+	ByIdentity = "identity"
+	// ByMerchant key merchant dari claims, request tanpa merchant tidak dibatasi
+	ByMerchant = "merchant"
+	// ByIP key ip client, dipakai untuk endpoint tanpa token seperti login
+	ByIP = "ip"
+)
+
+// Rule aturan token bucket untuk satu grup route
+type Rule struct {
+	Name string
+	// By sumber key, salah satu ByIdentity, ByMerchant atau ByIP
+	By string
+	// Requests jumlah token yang diisi ulang setiap Per, 0 mematikan rule
+	Requests int
+	Per      time.Duration
+	// Burst kapasitas bucket, default sama dengan Requests
+	Burst int
+}
+
+// Enabled rule dengan Requests atau Per 0 tidak dibatasi
+func (r Rule) Enabled() bool {
+	return r.Requests > 0 && r.Per > 0
+}
+
+func (r Rule) capacity() float64 {
+	if r.Burst > 0 {
+		return float64(r.Burst)
+	}
+	return float64(r.Requests)
+}
+
+// rate jumlah token per detik
+func (r Rule) rate() float64 {
+	return float64(r.Requests) / r.Per.Seconds()
+}
+
+// Result hasil pengambilan token, dipakai untuk header RateLimit-*
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset waktu sampai bucket kembali penuh
+	Reset time.Duration
+	// RetryAfter waktu sampai satu token tersedia, 0 apabila Allowed
+	RetryAfter time.Duration
+}
+
+// Store penyimpanan bucket, Take wajib atomic untuk satu key
+type Store interface {
+	// Take mengambil satu token dari bucket rule dan key
+	Take(ctx context.Context, rule Rule, key string) (Result, error)
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	// full waktu bucket kembali penuh apabila tidak ada request, dipakai untuk sweep
+	full time.Time
+}
+
+type memoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+	sweepAt time.Time
+}
+
+// NewMemoryStore bucket disimpan di memory proses, batas berlaku per instance
+func NewMemoryStore() Store {
+	return newMemoryStore(time.Now)
+}
+
+func newMemoryStore(now func() time.Time) *memoryStore {
+	return &memoryStore{
+		buckets: make(map[string]*bucket),
+		now:     now,
+	}
+}
+
+func (m *memoryStore) Take(_ context.Context, rule Rule, key string) (Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.sweep(now)
+
+	capacity := rule.capacity()
+	rate := rule.rate()
+
+	id := rule.Name + ":" + key
+	b, ok := m.buckets[id]
+	if !ok {
+		b = &bucket{tokens: capacity, last: now}
+		m.buckets[id] = b
+	}
+	b.tokens = math.Min(capacity, b.tokens+now.Sub(b.last).Seconds()*rate)
+	b.last = now
+
+	result := Result{Limit: int(capacity)}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = seconds((1 - b.tokens) / rate)
+	}
+	result.Remaining = int(math.Floor(b.tokens))
+	result.Reset = seconds((capacity - b.tokens) / rate)
+	b.full = now.Add(result.Reset)
+	return result, nil
+}
+
+// sweep membersihkan bucket yang sudah penuh kembali paling sering sekali per menit,
+// bucket penuh sama dengan bucket yang belum pernah dipakai
+func (m *memoryStore) sweep(now time.Time) {
+	if now.Before(m.sweepAt) {
+		return
+	}
+	m.sweepAt = now.Add(time.Minute)
+	for id, b := range m.buckets {
+		if !now.Before(b.full) {
+			delete(m.buckets, id)
+		}
+	}
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
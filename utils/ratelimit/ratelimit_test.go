@@ -0,0 +1,52 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStoreTokenBucket(t *testing.T) {
+	now := time.Unix(1000, 0)
+	store := newMemoryStore(func() time.Time { return now })
+	rule := Rule{Name: "api", By: ByIdentity, Requests: 60, Per: time.Minute, Burst: 3}
+
+	for i := 2; i >= 0; i-- {
+		result, err := store.Take(context.Background(), rule, "user:1")
+		assert.Nil(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 3, result.Limit)
+		assert.Equal(t, i, result.Remaining)
+	}
+
+	// burst habis, token berikutnya tersedia setelah 1 detik
+	result, _ := store.Take(context.Background(), rule, "user:1")
+	assert.False(t, result.Allowed)
+	assert.Equal(t, time.Second, result.RetryAfter)
+	assert.Equal(t, 3*time.Second, result.Reset)
+
+	// key lain memiliki bucket sendiri
+	result, _ = store.Take(context.Background(), rule, "user:2")
+	assert.True(t, result.Allowed)
+
+	now = now.Add(1500 * time.Millisecond)
+	result, _ = store.Take(context.Background(), rule, "user:1")
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+}
+
+func TestMemoryStoreSweep(t *testing.T) {
+	now := time.Unix(1000, 0)
+	store := newMemoryStore(func() time.Time { return now })
+	rule := Rule{Name: "auth", By: ByIP, Requests: 10, Per: time.Minute}
+
+	_, _ = store.Take(context.Background(), rule, "ip:10.0.0.1")
+	assert.Equal(t, 1, len(store.buckets))
+
+	// setelah bucket penuh kembali, entry dihapus ketika sweep berikutnya
+	now = now.Add(2 * time.Minute)
+	_, _ = store.Take(context.Background(), rule, "ip:10.0.0.2")
+	assert.Equal(t, 1, len(store.buckets))
+}
@@ -102,3 +102,33 @@ func NewBadRequestError(message string) APIError {
 		ACauses:  []interface{}{},
 	}
 }
+
+// NewForbiddenError membuat error ketika user sudah login namun tidak berhak mengakses resource
+func NewForbiddenError(message string) APIError {
+	return &apiError{
+		AStatus:  http.StatusForbidden,
+		AMessage: message,
+		AnError:  "forbidden",
+		ACauses:  []interface{}{},
+	}
+}
+
+// NewQuotaExceededError membuat error ketika pemakaian resource melebihi batas paket langganan
+func NewQuotaExceededError(message string) APIError {
+	return &apiError{
+		AStatus:  http.StatusPaymentRequired,
+		AMessage: message,
+		AnError:  "quota_exceeded",
+		ACauses:  []interface{}{},
+	}
+}
+
+// NewTooManyRequestsError membuat error ketika percobaan terlalu sering atau akun dikunci sementara
+func NewTooManyRequestsError(message string) APIError {
+	return &apiError{
+		AStatus:  http.StatusTooManyRequests,
+		AMessage: message,
+		AnError:  "too_many_requests",
+		ACauses:  []interface{}{},
+	}
+}
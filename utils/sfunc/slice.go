@@ -26,3 +26,41 @@ func AllValueInSliceIsValid(inputSlice []string, validSlice []string) bool {
 	}
 	return true
 }
+
+// IntInSlice seperti InSlice untuk slice int
+func IntInSlice(target int, slice []int) bool {
+	for _, value := range slice {
+		if target == value {
+			return true
+		}
+	}
+	return false
+}
+
+// UniqueInt membuang nilai ganda dengan urutan tetap
+func UniqueInt(slice []int) []int {
+	seen := make(map[int]bool, len(slice))
+	res := make([]int, 0, len(slice))
+	for _, value := range slice {
+		if seen[value] {
+			continue
+		}
+		seen[value] = true
+		res = append(res, value)
+	}
+	return res
+}
+
+// Unique membuang nilai string ganda dengan urutan tetap
+func Unique(slice []string) []string {
+	seen := make(map[string]bool, len(slice))
+	res := make([]string, 0, len(slice))
+	for _, value := range slice {
+		if seen[value] {
+			continue
+		}
+		seen[value] = true
+		res = append(res, value)
+	}
+	return res
+}
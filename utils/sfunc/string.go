@@ -0,0 +1,10 @@
+package sfunc
+
+// Truncate memotong text menjadi maksimal max karakter (rune)
+func Truncate(text string, max int) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	return string(runes[:max])
+}
@@ -7,6 +7,20 @@ type ErrorExample400 struct {
 	Causes  []string `json:"causes" example:"causes 1,causes 2"`
 }
 
+type ErrorExample402 struct {
+	Status  int      `json:"status" example:"402"`
+	Message string   `json:"message" example:"kuota product paket FREE sudah habis (terpakai 100 dari 100), silahkan upgrade paket"`
+	Error   string   `json:"error" example:"quota_exceeded"`
+	Causes  []string `json:"causes" example:""`
+}
+
+type ErrorExample429 struct {
+	Status  int      `json:"status" example:"429"`
+	Message string   `json:"message" example:"Terlalu banyak percobaan login, coba lagi dalam 30 detik"`
+	Error   string   `json:"error" example:"too_many_requests"`
+	Causes  []string `json:"causes" example:""`
+}
+
 type ErrorExample500 struct {
 	Status  int      `json:"status" example:"500"`
 	Message string   `json:"message" example:"gagal saat penghapusan item"`